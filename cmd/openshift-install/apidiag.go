@@ -0,0 +1,63 @@
+package main
+
+import (
+	"crypto/x509"
+	"net"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+)
+
+// apiFailureLayer buckets an API connection error by the layer it
+// failed at, so the wait loops can tell DNS trouble from a TLS or
+// authorization problem.
+func apiFailureLayer(err error) string {
+	if err == nil {
+		return ""
+	}
+	message := err.Error()
+	var dnsError *net.DNSError
+	switch {
+	case asNetError(err, &dnsError) || strings.Contains(message, "no such host"):
+		return "DNS resolution"
+	case strings.Contains(message, "connection refused") || strings.Contains(message, "connection reset"):
+		return "TCP connection"
+	case strings.Contains(message, "i/o timeout") || strings.Contains(message, "deadline exceeded"):
+		return "TCP connection (timeout)"
+	case isCertError(err) || strings.Contains(message, "x509") || strings.Contains(message, "tls"):
+		return "TLS handshake"
+	case errors.IsForbidden(err) || strings.Contains(message, "forbidden"):
+		return "authorization (403)"
+	}
+	return "request"
+}
+
+func asNetError(err error, target **net.DNSError) bool {
+	for err != nil {
+		if dnsErr, ok := err.(*net.DNSError); ok {
+			*target = dnsErr
+			return true
+		}
+		unwrapper, ok := err.(interface{ Unwrap() error })
+		if !ok {
+			return false
+		}
+		err = unwrapper.Unwrap()
+	}
+	return false
+}
+
+func isCertError(err error) bool {
+	for err != nil {
+		switch err.(type) {
+		case x509.UnknownAuthorityError, x509.CertificateInvalidError, x509.HostnameError:
+			return true
+		}
+		unwrapper, ok := err.(interface{ Unwrap() error })
+		if !ok {
+			return false
+		}
+		err = unwrapper.Unwrap()
+	}
+	return false
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	assetstore "github.com/openshift/installer/pkg/asset/store"
+	"github.com/openshift/installer/pkg/gather/ssh"
+	"github.com/openshift/installer/pkg/terraform"
+)
+
+// relayBootstrapProgress tails the bootkube journal on the bootstrap
+// node over SSH and relays it at debug level, so a crash-looping
+// bootkube is visible while the configmap poll is still waiting.
+// Every failure degrades silently back to the plain polling behavior:
+// progress streaming is best-effort.
+func relayBootstrapProgress(ctx context.Context, directory string) {
+	tfStateFilePath := filepath.Join(directory, terraform.StateFileName)
+	if _, err := os.Stat(tfStateFilePath); err != nil {
+		return
+	}
+
+	assetStore, err := assetstore.NewStore(directory)
+	if err != nil {
+		logrus.Debugf("Not streaming bootstrap progress: %v", err)
+		return
+	}
+	config := &installconfig.InstallConfig{}
+	if err := assetStore.Fetch(config); err != nil {
+		logrus.Debugf("Not streaming bootstrap progress: %v", err)
+		return
+	}
+	tfstate, err := terraform.ReadState(tfStateFilePath)
+	if err != nil {
+		logrus.Debugf("Not streaming bootstrap progress: %v", err)
+		return
+	}
+	bootstrap, port, _, err := extractHostAddresses(config.Config, tfstate)
+	if err != nil || bootstrap == "" {
+		logrus.Debugf("Not streaming bootstrap progress: no bootstrap address (%v)", err)
+		return
+	}
+
+	client, err := ssh.NewClient("core", net.JoinHostPort(bootstrap, strconv.Itoa(port)), nil)
+	if err != nil {
+		logrus.Debugf("Not streaming bootstrap progress: SSH connection failed: %v", err)
+		return
+	}
+	defer client.Close()
+
+	logrus.Debugf("Streaming the bootkube journal from the bootstrap node at %s", bootstrap)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		// ssh.Run relays the command output line by line at debug level.
+		if err := ssh.Run(client, "sudo journalctl -b -f --no-tail -u bootkube.service -u release-image.service"); err != nil {
+			logrus.Debugf("Bootstrap progress stream ended: %v", err)
+		}
+	}()
+
+	select {
+	case <-ctx.Done():
+		client.Close()
+	case <-done:
+	}
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/sirupsen/logrus"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/openshift/installer/pkg/asset/cluster"
+	"github.com/openshift/installer/pkg/types"
+)
+
+const clusterInfoFilename = "cluster-info.yaml"
+
+// installStartTime anchors the installation duration recorded in the
+// cluster card; it covers the current invocation, so create cluster
+// reports the full install and wait-for only the waiting.
+var installStartTime = time.Now()
+
+// clusterInfo is the "cluster card" persisted after a successful
+// install so the crucial facts do not scroll away in the logs.
+type clusterInfo struct {
+	ClusterName     string   `json:"clusterName,omitempty"`
+	InfraID         string   `json:"infraID,omitempty"`
+	Platform        string   `json:"platform,omitempty"`
+	Region          string   `json:"region,omitempty"`
+	APIURL          string   `json:"apiURL,omitempty"`
+	ConsoleURL      string   `json:"consoleURL,omitempty"`
+	ReleaseImage    string   `json:"releaseImage,omitempty"`
+	NetworkType     string   `json:"networkType,omitempty"`
+	MachineNetwork  []string `json:"machineNetwork,omitempty"`
+	ClusterNetwork  []string `json:"clusterNetwork,omitempty"`
+	ServiceNetwork  []string `json:"serviceNetwork,omitempty"`
+	ControlPlane    int64    `json:"controlPlaneReplicas,omitempty"`
+	Compute         int64    `json:"computeReplicas,omitempty"`
+	InstallDuration string   `json:"installDuration,omitempty"`
+	CredentialsDir  string   `json:"credentialsDir,omitempty"`
+}
+
+// writeClusterInfo assembles the cluster card from the metadata, the
+// consumed install config in the state file and the kubeconfig, writes
+// it to cluster-info.yaml in the asset directory and prints a compact
+// version. Every source is best-effort so UPI flows that lack some of
+// them still get a card.
+func writeClusterInfo(directory, consoleURL string) {
+	info := clusterInfo{
+		ConsoleURL:      consoleURL,
+		InstallDuration: time.Since(installStartTime).Round(time.Second).String(),
+	}
+
+	if absDir, err := filepath.Abs(directory); err == nil {
+		info.CredentialsDir = filepath.Join(absDir, "auth")
+	}
+
+	if metadata, err := cluster.LoadMetadata(directory); err == nil {
+		info.ClusterName = metadata.ClusterName
+		info.InfraID = metadata.InfraID
+		info.Platform = metadata.Platform()
+		switch {
+		case metadata.AWS != nil:
+			info.Region = metadata.AWS.Region
+		case metadata.Azure != nil:
+			info.Region = metadata.Azure.Region
+		case metadata.GCP != nil:
+			info.Region = metadata.GCP.Region
+		}
+		if metadata.Provenance != nil {
+			info.ReleaseImage = metadata.Provenance.ReleaseImage
+		}
+	}
+
+	if config := loadConsumedInstallConfig(directory); config != nil {
+		if config.Networking != nil {
+			info.NetworkType = config.Networking.NetworkType
+			for _, network := range config.Networking.MachineNetwork {
+				info.MachineNetwork = append(info.MachineNetwork, network.CIDR.String())
+			}
+			for _, network := range config.Networking.ClusterNetwork {
+				info.ClusterNetwork = append(info.ClusterNetwork, network.CIDR.String())
+			}
+			for _, network := range config.Networking.ServiceNetwork {
+				info.ServiceNetwork = append(info.ServiceNetwork, network.String())
+			}
+		}
+		if config.ControlPlane != nil && config.ControlPlane.Replicas != nil {
+			info.ControlPlane = *config.ControlPlane.Replicas
+		}
+		for _, pool := range config.Compute {
+			if pool.Replicas != nil {
+				info.Compute += *pool.Replicas
+			}
+		}
+	}
+
+	if kubeconfig, err := clientcmd.LoadFromFile(filepath.Join(directory, "auth", "kubeconfig")); err == nil {
+		for _, cluster := range kubeconfig.Clusters {
+			info.APIURL = cluster.Server
+			break
+		}
+	}
+
+	data, err := yaml.Marshal(info)
+	if err != nil {
+		logrus.Debugf("Failed to marshal cluster info: %v", err)
+		return
+	}
+	if err := ioutil.WriteFile(filepath.Join(directory, clusterInfoFilename), data, 0644); err != nil {
+		logrus.Debugf("Failed to write %s: %v", clusterInfoFilename, err)
+		return
+	}
+
+	if info.InfraID != "" {
+		logrus.Infof("Cluster %s (%s) on %s %s", info.ClusterName, info.InfraID, info.Platform, info.Region)
+	}
+	if info.APIURL != "" {
+		logrus.Infof("API at %s, duration %s; details saved to %s", info.APIURL, info.InstallDuration, clusterInfoFilename)
+	}
+}
+
+// loadConsumedInstallConfig reads the install config back out of the
+// state file, where it lives after being consumed from the asset
+// directory. It never triggers asset generation.
+func loadConsumedInstallConfig(directory string) *types.InstallConfig {
+	data, err := ioutil.ReadFile(filepath.Join(directory, ".openshift_install_state.json"))
+	if err != nil {
+		return nil
+	}
+	state := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil
+	}
+	raw, ok := state["*installconfig.InstallConfig"]
+	if !ok {
+		return nil
+	}
+	wrapper := struct {
+		Config *types.InstallConfig `json:"config"`
+	}{}
+	if err := json.Unmarshal(raw, &wrapper); err != nil {
+		return nil
+	}
+	return wrapper.Config
+}
@@ -29,10 +29,17 @@ import (
 	configclient "github.com/openshift/client-go/config/clientset/versioned"
 	routeclient "github.com/openshift/client-go/route/clientset/versioned"
 	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/cluster"
 	"github.com/openshift/installer/pkg/asset/installconfig"
+	"github.com/openshift/installer/pkg/asset/kubeconfig"
+	"github.com/openshift/installer/pkg/asset/manifests"
 	assetstore "github.com/openshift/installer/pkg/asset/store"
 	targetassets "github.com/openshift/installer/pkg/asset/targets"
 	destroybootstrap "github.com/openshift/installer/pkg/destroy/bootstrap"
+	installererrors "github.com/openshift/installer/pkg/errors"
+	installergather "github.com/openshift/installer/pkg/gather/installer"
+	"github.com/openshift/installer/pkg/installer"
+	"github.com/openshift/installer/pkg/terraform"
 	"github.com/openshift/installer/pkg/types/baremetal"
 	cov1helpers "github.com/openshift/library-go/pkg/config/clusteroperator/v1helpers"
 )
@@ -68,6 +75,24 @@ var (
 		assets: targetassets.Manifests,
 	}
 
+	permissionsManifestTarget = target{
+		name: "Permissions Manifest",
+		command: &cobra.Command{
+			Use:   "permissions-manifest",
+			Short: "Generates a manifest of the cloud permissions the install will need",
+		},
+		assets: targetassets.PermissionsManifest,
+	}
+
+	permissionsReportTarget = target{
+		name: "Permissions Report",
+		command: &cobra.Command{
+			Use:   "permissions-report",
+			Short: "Generates a report of the cloud permissions the installer and the release image's operators need",
+		},
+		assets: targetassets.PermissionsReport,
+	}
+
 	ignitionConfigsTarget = target{
 		name: "Ignition Configs",
 		command: &cobra.Command{
@@ -96,6 +121,7 @@ var (
 				if err != nil {
 					logrus.Fatal(errors.Wrap(err, "loading kubeconfig"))
 				}
+				applyAPIServerOverride(config)
 
 				err = waitForBootstrapComplete(ctx, config, rootOpts.dir)
 				if err != nil {
@@ -126,12 +152,21 @@ var (
 					}
 					logrus.Fatal(err)
 				}
+
+				if mergeKubeconfigPath != "" {
+					src := filepath.Join(rootOpts.dir, "auth", "kubeconfig")
+					if err := kubeconfig.Merge(mergeKubeconfigPath, src, forceMergeKubeconfig); err != nil {
+						logrus.Error(errors.Wrap(err, "failed to merge kubeconfig"))
+					} else {
+						logrus.Infof("Merged the cluster's kubeconfig into %s", mergeKubeconfigPath)
+					}
+				}
 			},
 		},
 		assets: targetassets.Cluster,
 	}
 
-	targets = []target{installConfigTarget, manifestsTarget, ignitionConfigsTarget, clusterTarget}
+	targets = []target{installConfigTarget, manifestsTarget, permissionsManifestTarget, permissionsReportTarget, ignitionConfigsTarget, clusterTarget}
 )
 
 func newCreateCmd() *cobra.Command {
@@ -146,47 +181,66 @@ func newCreateCmd() *cobra.Command {
 	for _, t := range targets {
 		t.command.Args = cobra.ExactArgs(0)
 		t.command.Run = runTargetCmd(t.assets...)
+		if t.name == "Ignition Configs" || t.name == "Cluster" {
+			t.command.PersistentFlags().StringArrayVar(&manifests.ExtraManifestDirs, "manifests-dir", nil, "directory with additional manifests to include in the cluster (can be specified multiple times)")
+			t.command.PersistentFlags().StringVar(&kubeconfig.ContextName, "kubeconfig-context-name", "", "context name to use in the generated admin kubeconfig instead of admin@<infra-id>")
+		}
+		if t.name == "Cluster" {
+			addWaitTimeoutFlag(t.command)
+			t.command.PersistentFlags().BoolVar(&resumeClusterCreate, "resume", false, "resume an interrupted create cluster by re-applying terraform against the leftover state")
+			t.command.PersistentFlags().StringVar(&mergeKubeconfigPath, "merge-kubeconfig", "", "kubeconfig file to merge the cluster's admin kubeconfig into once the install completes")
+			t.command.PersistentFlags().BoolVar(&forceMergeKubeconfig, "force", false, "overwrite colliding entries when merging with --merge-kubeconfig")
+		}
+		if t.name == "Manifests" {
+			t.command.PersistentFlags().BoolVar(&manifests.KustomizeBase, "kustomize", false, "additionally write kustomization.yaml files so the generated manifests can be managed as a kustomize base; overlays placed in overlays/manifests and overlays/openshift are applied when the manifests are consumed")
+		}
 		cmd.AddCommand(t.command)
 	}
 
 	return cmd
 }
 
-func runTargetCmd(targets ...asset.WritableAsset) func(cmd *cobra.Command, args []string) {
-	runner := func(directory string) error {
-		assetStore, err := assetstore.NewStore(directory)
-		if err != nil {
-			return errors.Wrap(err, "failed to create asset store")
-		}
+// resumeClusterCreate opts in to re-applying terraform against a
+// leftover state from an interrupted create cluster.
+var resumeClusterCreate bool
 
-		for _, a := range targets {
-			err := assetStore.Fetch(a, targets...)
-			if err != nil {
-				err = errors.Wrapf(err, "failed to fetch %s", a.Name())
-			}
-
-			if err2 := asset.PersistToFile(a, directory); err2 != nil {
-				err2 = errors.Wrapf(err2, "failed to write asset (%s) to disk", a.Name())
-				if err != nil {
-					logrus.Error(err2)
-					return err
-				}
-				return err2
-			}
-
-			if err != nil {
-				return err
-			}
-		}
-		return nil
-	}
+// mergeKubeconfigPath, when set, receives the cluster's admin
+// kubeconfig after the install completes; forceMergeKubeconfig lets
+// the merge overwrite colliding entries.
+var (
+	mergeKubeconfigPath  string
+	forceMergeKubeconfig bool
+)
 
+func runTargetCmd(targets ...asset.WritableAsset) func(cmd *cobra.Command, args []string) {
 	return func(cmd *cobra.Command, args []string) {
 		cleanup := setupFileHook(rootOpts.dir)
 		defer cleanup()
 
-		err := runner(rootOpts.dir)
+		statePath := filepath.Join(rootOpts.dir, terraform.StateFileName)
+		if _, err := os.Stat(statePath); err == nil && resumeClusterCreate {
+			// The user asked to resume an interrupted apply; seed
+			// terraform with the leftover state so it reconciles the
+			// drift. Without --resume the cluster asset keeps its
+			// long-standing refusal to touch a directory that may
+			// hold a running cluster.
+			cluster.ResumeStatePath = statePath
+		}
+
+		// The CLI drives the same generation path as programmatic
+		// embedders; see pkg/installer.
+		err := installer.Generate(rootOpts.dir, nil, targets...)
 		if err != nil {
+			// An infrastructure-stage failure leaves terraform state
+			// behind; bundle it with the redacted tfvars and the
+			// installer log so support gets everything in one archive.
+			if _, err2 := os.Stat(statePath); err2 == nil {
+				if bundle, err2 := installergather.Gather(rootOpts.dir); err2 != nil {
+					logrus.Error("Attempted to gather installer artifacts after installation failure: ", err2)
+				} else {
+					logrus.Infof("Installer failure artifacts captured here %q", bundle)
+				}
+			}
 			logrus.Fatal(err)
 		}
 	}
@@ -250,9 +304,16 @@ func waitForBootstrapComplete(ctx context.Context, config *rest.Config, director
 		return errors.Wrap(err, "creating a Kubernetes client")
 	}
 
+	// Best-effort: stream bootkube progress from the bootstrap node so
+	// a crash loop is visible before the timeout. Failures fall back to
+	// the plain configmap polling below.
+	progressCtx, cancelProgress := context.WithCancel(ctx)
+	defer cancelProgress()
+	go relayBootstrapProgress(progressCtx, directory)
+
 	discovery := client.Discovery()
 
-	apiTimeout := 20 * time.Minute
+	apiTimeout := waitTimeout(20 * time.Minute)
 	logrus.Infof("Waiting up to %v for the Kubernetes API at %s...", apiTimeout, config.Host)
 	apiContext, cancel := context.WithTimeout(ctx, apiTimeout)
 	defer cancel()
@@ -262,38 +323,53 @@ func waitForBootstrapComplete(ctx context.Context, config *rest.Config, director
 	logDownsample := 15
 	silenceRemaining := logDownsample
 	previousErrorSuffix := ""
+	lastFailureLayer := ""
 	wait.Until(func() {
 		version, err := discovery.ServerVersion()
 		if err == nil {
 			logrus.Infof("API %s up", version)
 			cancel()
 		} else {
+			lastFailureLayer = apiFailureLayer(err)
 			silenceRemaining--
 			chunks := strings.Split(err.Error(), ":")
 			errorSuffix := chunks[len(chunks)-1]
 			if previousErrorSuffix != errorSuffix {
-				logrus.Debugf("Still waiting for the Kubernetes API: %v", err)
+				logrus.Debugf("Still waiting for the Kubernetes API (failing at the %s layer): %v", lastFailureLayer, err)
 				previousErrorSuffix = errorSuffix
 				silenceRemaining = logDownsample
 			} else if silenceRemaining == 0 {
-				logrus.Debugf("Still waiting for the Kubernetes API: %v", err)
+				logrus.Debugf("Still waiting for the Kubernetes API (failing at the %s layer): %v", lastFailureLayer, err)
 				silenceRemaining = logDownsample
 			}
 		}
 	}, 2*time.Second, apiContext.Done())
 	err = apiContext.Err()
 	if err != nil && err != context.Canceled {
-		return errors.Wrap(err, "waiting for Kubernetes API")
+		if lastFailureLayer != "" {
+			err = errors.Wrapf(err, "the %s layer never succeeded; fix that layer (or point the installer at a reachable endpoint with --api-server-url) and re-run wait-for", lastFailureLayer)
+		}
+		return installererrors.Classify(errors.Wrap(err, "waiting for Kubernetes API"), installererrors.ErrTimeoutWaitingForBootstrap)
 	}
 
-	return waitForBootstrapConfigMap(ctx, client)
+	return waitForBootstrapConfigMap(ctx, client, directory)
 }
 
 // waitForBootstrapConfigMap watches the configmaps in the kube-system namespace
 // and waits for the bootstrap configmap to report that bootstrapping has
 // completed.
-func waitForBootstrapConfigMap(ctx context.Context, client *kubernetes.Clientset) error {
-	timeout := 40 * time.Minute
+func waitForBootstrapConfigMap(ctx context.Context, client *kubernetes.Clientset, directory string) error {
+	defaultTimeout := 40 * time.Minute
+	// Keep the wait consistent with any install-time bootstrap timeout
+	// overrides, so the installer does not give up while the bootstrap
+	// control plane is still within its own budget.
+	if config := loadConsumedInstallConfig(directory); config != nil && config.ExperimentalBootstrapTimeouts != nil {
+		overrides := config.ExperimentalBootstrapTimeouts
+		if total := overrides.EtcdBootstrapSeconds + overrides.APIServerReadySeconds + overrides.CVOBootstrapSeconds; total > 0 {
+			defaultTimeout = time.Duration(total) * time.Second
+		}
+	}
+	timeout := waitTimeout(defaultTimeout)
 	logrus.Infof("Waiting up to %v for bootstrapping to complete...", timeout)
 
 	waitCtx, cancel := context.WithTimeout(ctx, timeout)
@@ -325,7 +401,10 @@ func waitForBootstrapConfigMap(ctx context.Context, client *kubernetes.Clientset
 		},
 	)
 
-	return errors.Wrap(err, "failed to wait for bootstrapping to complete")
+	if err != nil {
+		return installererrors.Classify(errors.Wrap(err, "failed to wait for bootstrapping to complete"), installererrors.ErrTimeoutWaitingForBootstrap)
+	}
+	return nil
 }
 
 // waitForInitializedCluster watches the ClusterVersion waiting for confirmation
@@ -343,6 +422,8 @@ func waitForInitializedCluster(ctx context.Context, config *rest.Config) error {
 		}
 	}
 
+	timeout = waitTimeout(timeout)
+
 	logrus.Infof("Waiting up to %v for the cluster at %s to initialize...", timeout, config.Host)
 	cc, err := configclient.NewForConfig(config)
 	if err != nil {
@@ -469,6 +550,7 @@ func logComplete(directory, consoleURL string) error {
 	logrus.Infof("To access the cluster as the system:admin user when using 'oc', run 'export KUBECONFIG=%s'", kubeconfig)
 	logrus.Infof("Access the OpenShift web-console here: %s", consoleURL)
 	logrus.Infof("Login to the console with user: kubeadmin, password: %s", pw)
+	writeClusterInfo(directory, consoleURL)
 	return nil
 }
 
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"net"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	certificatesv1beta1 "k8s.io/api/certificates/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/openshift/installer/pkg/types"
+)
+
+var csrApprovalOpts struct {
+	approve      bool
+	maxApprovals int
+	timeout      time.Duration
+}
+
+func newWaitForCSRApprovalCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "csr-approval",
+		Short: "Watch pending node CSRs while UPI workers join, optionally approving matching ones",
+		Args:  cobra.ExactArgs(0),
+		RunE: func(_ *cobra.Command, _ []string) error {
+			cleanup := setupFileHook(rootOpts.dir)
+			defer cleanup()
+			return runWaitForCSRApproval(rootOpts.dir)
+		},
+	}
+	cmd.PersistentFlags().BoolVar(&csrApprovalOpts.approve, "approve", false, "auto-approve pending node-bootstrapper and kubelet-serving CSRs whose identities match this cluster")
+	cmd.PersistentFlags().IntVar(&csrApprovalOpts.maxApprovals, "max-approvals", 10, "stop after approving this many CSRs")
+	cmd.PersistentFlags().DurationVar(&csrApprovalOpts.timeout, "timeout", 30*time.Minute, "stop watching after this long")
+	return cmd
+}
+
+// runWaitForCSRApproval polls the pending CSRs: node-bootstrapper
+// requests (the first round) and kubelet-serving requests from already
+// joined nodes (the second round users routinely miss). Auto-approval
+// is off by default and bounded by --max-approvals and --timeout.
+func runWaitForCSRApproval(directory string) error {
+	config, err := clientcmd.BuildConfigFromFlags("", filepath.Join(directory, "auth", "kubeconfig"))
+	if err != nil {
+		return errors.Wrap(err, "loading kubeconfig")
+	}
+	applyAPIServerOverride(config)
+	client, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return errors.Wrap(err, "creating a Kubernetes client")
+	}
+
+	installConfig := loadConsumedInstallConfig(directory)
+
+	approved := 0
+	deadline := time.Now().Add(csrApprovalOpts.timeout)
+	for time.Now().Before(deadline) {
+		list, err := client.CertificatesV1beta1().CertificateSigningRequests().List(metav1.ListOptions{})
+		if err != nil {
+			return errors.Wrap(err, "listing certificate signing requests")
+		}
+
+		pending := 0
+		for i := range list.Items {
+			csr := &list.Items[i]
+			if isCSRResolved(csr) {
+				continue
+			}
+			kind := csrKind(csr)
+			if kind == "" {
+				continue
+			}
+			pending++
+			logrus.Infof("Pending %s CSR %s from %s", kind, csr.Name, csr.Spec.Username)
+
+			if !csrApprovalOpts.approve || approved >= csrApprovalOpts.maxApprovals {
+				continue
+			}
+			if err := verifyCSRIdentity(csr, kind, installConfig); err != nil {
+				logrus.Warnf("Not approving %s CSR %s: %v", kind, csr.Name, err)
+				continue
+			}
+			csr.Status.Conditions = append(csr.Status.Conditions, certificatesv1beta1.CertificateSigningRequestCondition{
+				Type:    certificatesv1beta1.CertificateApproved,
+				Reason:  "InstallerApproved",
+				Message: "approved by openshift-install wait-for csr-approval",
+			})
+			if _, err := client.CertificatesV1beta1().CertificateSigningRequests().UpdateApproval(csr); err != nil {
+				logrus.Warnf("Failed to approve CSR %s: %v", csr.Name, err)
+				continue
+			}
+			approved++
+			logrus.Infof("Approved %s CSR %s (%d of at most %d)", kind, csr.Name, approved, csrApprovalOpts.maxApprovals)
+		}
+
+		nodes, err := client.CoreV1().Nodes().List(metav1.ListOptions{})
+		if err == nil {
+			ready := 0
+			for _, node := range nodes.Items {
+				for _, condition := range node.Status.Conditions {
+					if condition.Type == "Ready" && condition.Status == "True" {
+						ready++
+					}
+				}
+			}
+			logrus.Infof("%d node(s) joined and ready, %d CSR(s) pending", ready, pending)
+		}
+
+		if pending == 0 && approved > 0 {
+			logrus.Info("No CSRs pending")
+			return nil
+		}
+		time.Sleep(15 * time.Second)
+	}
+	logrus.Infof("Stopped watching after %s; %d CSR(s) approved", csrApprovalOpts.timeout, approved)
+	return nil
+}
+
+// verifyCSRIdentity parses the actual certificate request and checks
+// the identity it asks for, so --approve only rubber-stamps requests
+// a joining node would legitimately make: bootstrapper client certs
+// must name a node and request no SANs, and serving certs may only
+// cover the requesting node's own name and addresses inside the
+// machine network.
+func verifyCSRIdentity(csr *certificatesv1beta1.CertificateSigningRequest, kind string, installConfig *types.InstallConfig) error {
+	block, _ := pem.Decode(csr.Spec.Request)
+	if block == nil || block.Type != "CERTIFICATE REQUEST" {
+		return errors.New("the request is not a PEM certificate request")
+	}
+	request, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse the certificate request")
+	}
+
+	switch kind {
+	case "node-bootstrapper":
+		nodeName := strings.TrimPrefix(request.Subject.CommonName, "system:node:")
+		if nodeName == request.Subject.CommonName || nodeName == "" {
+			return errors.Errorf("subject %q does not name a node", request.Subject.CommonName)
+		}
+		organizations := sets.NewString(request.Subject.Organization...)
+		if !organizations.Has("system:nodes") {
+			return errors.Errorf("subject organizations %v do not include system:nodes", request.Subject.Organization)
+		}
+		if len(request.DNSNames)+len(request.IPAddresses)+len(request.EmailAddresses)+len(request.URIs) > 0 {
+			return errors.New("a node client certificate must not request subject alternative names")
+		}
+	case "kubelet-serving":
+		nodeName := strings.TrimPrefix(csr.Spec.Username, "system:node:")
+		if request.Subject.CommonName != csr.Spec.Username {
+			return errors.Errorf("subject %q does not match the requesting user %q", request.Subject.CommonName, csr.Spec.Username)
+		}
+		if len(request.DNSNames) == 0 && len(request.IPAddresses) == 0 {
+			return errors.New("a serving certificate must request the node's names or addresses")
+		}
+		for _, dnsName := range request.DNSNames {
+			if dnsName != nodeName && !strings.HasPrefix(nodeName, dnsName+".") && !strings.HasPrefix(dnsName, nodeName+".") {
+				return errors.Errorf("DNS name %q does not belong to node %q", dnsName, nodeName)
+			}
+		}
+		for _, ip := range request.IPAddresses {
+			if installConfig != nil && !machineNetworkContains(installConfig, ip) {
+				return errors.Errorf("IP address %s is outside the machine network", ip)
+			}
+		}
+	}
+	return nil
+}
+
+// machineNetworkContains reports whether ip falls inside one of the
+// install config's machine networks.
+func machineNetworkContains(installConfig *types.InstallConfig, ip net.IP) bool {
+	if installConfig.Networking == nil || len(installConfig.Networking.MachineNetwork) == 0 {
+		// Nothing recorded to check against.
+		return true
+	}
+	for _, entry := range installConfig.Networking.MachineNetwork {
+		if entry.CIDR.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// csrKind classifies a CSR as one the node-join flow produces.
+func csrKind(csr *certificatesv1beta1.CertificateSigningRequest) string {
+	switch {
+	case csr.Spec.Username == "system:serviceaccount:openshift-machine-config-operator:node-bootstrapper":
+		return "node-bootstrapper"
+	case strings.HasPrefix(csr.Spec.Username, "system:node:"):
+		return "kubelet-serving"
+	}
+	return ""
+}
+
+func isCSRResolved(csr *certificatesv1beta1.CertificateSigningRequest) bool {
+	for _, condition := range csr.Status.Conditions {
+		if condition.Type == certificatesv1beta1.CertificateApproved || condition.Type == certificatesv1beta1.CertificateDenied {
+			return true
+		}
+	}
+	return false
+}
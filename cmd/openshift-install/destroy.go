@@ -1,6 +1,8 @@
 package main
 
 import (
+	"context"
+	"fmt"
 	"os"
 	"path/filepath"
 
@@ -8,18 +10,25 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 
+	"github.com/openshift/installer/pkg/asset/cluster"
 	assetstore "github.com/openshift/installer/pkg/asset/store"
 	"github.com/openshift/installer/pkg/destroy"
 	_ "github.com/openshift/installer/pkg/destroy/aws"
-	_ "github.com/openshift/installer/pkg/destroy/azure"
+	azuredestroy "github.com/openshift/installer/pkg/destroy/azure"
 	_ "github.com/openshift/installer/pkg/destroy/baremetal"
 	"github.com/openshift/installer/pkg/destroy/bootstrap"
 	_ "github.com/openshift/installer/pkg/destroy/gcp"
 	_ "github.com/openshift/installer/pkg/destroy/libvirt"
 	_ "github.com/openshift/installer/pkg/destroy/openstack"
-	_ "github.com/openshift/installer/pkg/destroy/ovirt"
+	ovirtdestroy "github.com/openshift/installer/pkg/destroy/ovirt"
+	"github.com/openshift/installer/pkg/destroy/providers"
 	_ "github.com/openshift/installer/pkg/destroy/vsphere"
+	"github.com/openshift/installer/pkg/ipam"
 	"github.com/openshift/installer/pkg/terraform"
+	"github.com/openshift/installer/pkg/types"
+	awstypes "github.com/openshift/installer/pkg/types/aws"
+	azuretypes "github.com/openshift/installer/pkg/types/azure"
+	gcptypes "github.com/openshift/installer/pkg/types/gcp"
 )
 
 func newDestroyCmd() *cobra.Command {
@@ -36,8 +45,16 @@ func newDestroyCmd() *cobra.Command {
 	return cmd
 }
 
+var destroyClusterOpts struct {
+	identifyOnly bool
+	platform     string
+	infraID      string
+	region       string
+	projectID    string
+}
+
 func newDestroyClusterCmd() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "cluster",
 		Short: "Destroy an OpenShift cluster",
 		Args:  cobra.ExactArgs(0),
@@ -45,12 +62,93 @@ func newDestroyClusterCmd() *cobra.Command {
 			cleanup := setupFileHook(rootOpts.dir)
 			defer cleanup()
 
-			err := runDestroyCmd(rootOpts.dir)
+			var err error
+			if destroyClusterOpts.identifyOnly {
+				err = runIdentifyCmd(rootOpts.dir)
+			} else {
+				err = runDestroyCmd(rootOpts.dir)
+			}
 			if err != nil {
 				logrus.Fatal(err)
 			}
 		},
 	}
+	cmd.PersistentFlags().BoolVar(&destroyClusterOpts.identifyOnly, "identify-only", false, "list the resources that would be deleted without deleting anything")
+	cmd.PersistentFlags().StringVar(&destroyClusterOpts.platform, "platform", "", "with --identify-only, the platform to search when no metadata.json is available (aws, azure or gcp)")
+	cmd.PersistentFlags().StringVar(&destroyClusterOpts.infraID, "infra-id", "", "with --identify-only, the cluster infra ID to search for when no metadata.json is available")
+	cmd.PersistentFlags().StringVar(&destroyClusterOpts.region, "region", "", "with --identify-only, the region to search when no metadata.json is available")
+	cmd.PersistentFlags().StringVar(&destroyClusterOpts.projectID, "project-id", "", "with --identify-only, the GCP project to search when no metadata.json is available")
+	cmd.PersistentFlags().BoolVar(&azuredestroy.SkipAppRegistrations, "skip-app-registrations", false, "on Azure, leave the cluster's AAD application registrations and service principals in place")
+	cmd.PersistentFlags().BoolVar(&ovirtdestroy.NamePrefixFallback, "ovirt-name-prefix-fallback", false, "on oVirt, additionally find VMs by the <infra-id>- name prefix, for clusters created by older installers that did not tag every VM")
+	return cmd
+}
+
+// identifyMetadata loads the cluster metadata from the asset directory,
+// falling back to metadata reconstructed from the --platform, --infra-id
+// and --region flags so leaked clusters can be inventoried after
+// metadata.json has been lost.
+func identifyMetadata(directory string) (*types.ClusterMetadata, error) {
+	metadata, err := cluster.LoadMetadata(directory)
+	if err == nil {
+		return metadata, nil
+	}
+	if destroyClusterOpts.platform == "" || destroyClusterOpts.infraID == "" {
+		return nil, errors.Wrap(err, "no metadata.json; specify --platform, --infra-id and --region to identify resources without it")
+	}
+
+	metadata = &types.ClusterMetadata{InfraID: destroyClusterOpts.infraID}
+	switch destroyClusterOpts.platform {
+	case awstypes.Name:
+		metadata.AWS = &awstypes.Metadata{
+			Region: destroyClusterOpts.region,
+			Identifier: []map[string]string{
+				{fmt.Sprintf("kubernetes.io/cluster/%s", destroyClusterOpts.infraID): "owned"},
+			},
+		}
+	case azuretypes.Name:
+		metadata.Azure = &azuretypes.Metadata{Region: destroyClusterOpts.region}
+	case gcptypes.Name:
+		metadata.GCP = &gcptypes.Metadata{
+			Region:    destroyClusterOpts.region,
+			ProjectID: destroyClusterOpts.projectID,
+		}
+	default:
+		return nil, errors.Errorf("--identify-only is not supported for platform %q", destroyClusterOpts.platform)
+	}
+	return metadata, nil
+}
+
+func runIdentifyCmd(directory string) error {
+	metadata, err := identifyMetadata(directory)
+	if err != nil {
+		return err
+	}
+
+	destroyer, err := destroy.NewFromMetadata(logrus.StandardLogger(), metadata)
+	if err != nil {
+		return errors.Wrap(err, "Failed while preparing to identify cluster resources")
+	}
+
+	identifier, ok := destroyer.(providers.Identifier)
+	if !ok {
+		return errors.Errorf("platform %q does not support --identify-only", metadata.Platform())
+	}
+	return identifier.Identify()
+}
+
+// releaseIPAMAllocations returns addresses allocated from an external
+// IPAM service during installation. Failures are logged rather than
+// fatal: the cloud resources are already gone at this point and the
+// allocation IDs remain in metadata.json for manual release.
+func releaseIPAMAllocations(directory string) {
+	metadata, err := cluster.LoadMetadata(directory)
+	if err != nil || metadata.IPAM == nil || len(metadata.IPAM.AllocationIDs) == 0 {
+		return
+	}
+	logrus.Infof("Releasing %d IPAM allocation(s)", len(metadata.IPAM.AllocationIDs))
+	if err := ipam.Release(context.TODO(), metadata.IPAM.Config, metadata.IPAM.AllocationIDs); err != nil {
+		logrus.Warnf("Failed to release IPAM allocations: %v", err)
+	}
 }
 
 func runDestroyCmd(directory string) error {
@@ -62,6 +160,8 @@ func runDestroyCmd(directory string) error {
 		return errors.Wrap(err, "Failed to destroy cluster")
 	}
 
+	releaseIPAMAllocations(directory)
+
 	store, err := assetstore.NewStore(directory)
 	if err != nil {
 		return errors.Wrap(err, "failed to create asset store")
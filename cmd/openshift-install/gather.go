@@ -18,6 +18,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/rest"
 
+	"github.com/openshift/installer/pkg/asset/cluster"
 	"github.com/openshift/installer/pkg/asset/installconfig"
 	assetstore "github.com/openshift/installer/pkg/asset/store"
 	"github.com/openshift/installer/pkg/gather/ssh"
@@ -37,6 +38,7 @@ import (
 	openstacktypes "github.com/openshift/installer/pkg/types/openstack"
 	ovirttypes "github.com/openshift/installer/pkg/types/ovirt"
 	vspheretypes "github.com/openshift/installer/pkg/types/vsphere"
+	cryptossh "golang.org/x/crypto/ssh"
 )
 
 func newGatherCmd() *cobra.Command {
@@ -58,9 +60,10 @@ to debug the installation failures`,
 
 var (
 	gatherBootstrapOpts struct {
-		bootstrap string
-		masters   []string
-		sshKeys   []string
+		bootstrap  string
+		masters    []string
+		sshKeys    []string
+		sshBastion string
 	}
 )
 
@@ -81,9 +84,45 @@ func newGatherBootstrapCmd() *cobra.Command {
 	cmd.PersistentFlags().StringVar(&gatherBootstrapOpts.bootstrap, "bootstrap", "", "Hostname or IP of the bootstrap host")
 	cmd.PersistentFlags().StringArrayVar(&gatherBootstrapOpts.masters, "master", []string{}, "Hostnames or IPs of all control plane hosts")
 	cmd.PersistentFlags().StringArrayVar(&gatherBootstrapOpts.sshKeys, "key", []string{}, "Path to SSH private keys that should be used for authentication. If no key was provided, SSH private keys from user's environment will be used")
+	cmd.PersistentFlags().StringArrayVar(&gatherBootstrapOpts.sshKeys, "ssh-key", []string{}, "alias for --key")
+	cmd.PersistentFlags().StringVar(&gatherBootstrapOpts.sshBastion, "ssh-bastion", "", "SSH bastion to chain the connection through, as user@host[:port]")
 	return cmd
 }
 
+// bastionForGather returns the bastion to use: the --ssh-bastion flag
+// wins, otherwise internal installs fall back to the platform's
+// bastionHost field.
+func bastionForGather(config *types.InstallConfig) string {
+	if gatherBootstrapOpts.sshBastion != "" {
+		return gatherBootstrapOpts.sshBastion
+	}
+	if config == nil || config.Publish != types.InternalPublishingStrategy {
+		return ""
+	}
+	switch {
+	case config.Platform.AWS != nil:
+		return config.Platform.AWS.BastionHost
+	case config.Platform.Azure != nil:
+		return config.Platform.Azure.BastionHost
+	case config.Platform.GCP != nil:
+		return config.Platform.GCP.BastionHost
+	}
+	return ""
+}
+
+// splitBastion parses user@host[:port], defaulting the user to core
+// and the port to 22.
+func splitBastion(bastion string) (user, address string) {
+	user = "core"
+	if idx := strings.Index(bastion, "@"); idx >= 0 {
+		user, bastion = bastion[:idx], bastion[idx+1:]
+	}
+	if _, _, err := net.SplitHostPort(bastion); err != nil {
+		bastion = net.JoinHostPort(bastion, "22")
+	}
+	return user, bastion
+}
+
 func runGatherBootstrapCmd(directory string) error {
 	tfStateFilePath := filepath.Join(directory, terraform.StateFileName)
 	_, err := os.Stat(tfStateFilePath)
@@ -117,12 +156,35 @@ func runGatherBootstrapCmd(directory string) error {
 		return errors.Wrapf(err, "failed to get bootstrap and control plane host addresses from %q", tfStateFilePath)
 	}
 
-	return logGatherBootstrap(bootstrap, port, masters, directory)
+	bastion := bastionForGather(config.Config)
+	if bastion == "" && config.Config.Publish == types.InternalPublishingStrategy && config.Config.Platform.AWS != nil {
+		// An installer-provisioned bastion (bastion section in the
+		// install config) is recorded in metadata.json; fall back to
+		// the terraform state for clusters created before it was.
+		if metadata, err := cluster.LoadMetadata(directory); err == nil && metadata.AWS != nil && metadata.AWS.BastionIP != "" {
+			bastion = metadata.AWS.BastionIP
+		} else if ip := gatheraws.BastionIP(tfstate); ip != "" {
+			bastion = ip
+		}
+	}
+	return logGatherBootstrapVia(bootstrap, port, masters, directory, bastion)
 }
 
 func logGatherBootstrap(bootstrap string, port int, masters []string, directory string) error {
+	return logGatherBootstrapVia(bootstrap, port, masters, directory, bastionForGather(nil))
+}
+
+func logGatherBootstrapVia(bootstrap string, port int, masters []string, directory string, bastion string) error {
 	logrus.Info("Pulling debug logs from the bootstrap machine")
-	client, err := ssh.NewClient("core", net.JoinHostPort(bootstrap, strconv.Itoa(port)), gatherBootstrapOpts.sshKeys)
+	var client *cryptossh.Client
+	var err error
+	if bastion != "" {
+		bastionUser, bastionAddress := splitBastion(bastion)
+		logrus.Infof("Chaining the connection through the bastion %s", bastionAddress)
+		client, err = ssh.NewClientWithBastion("core", net.JoinHostPort(bootstrap, strconv.Itoa(port)), bastionUser, bastionAddress, gatherBootstrapOpts.sshKeys)
+	} else {
+		client, err = ssh.NewClient("core", net.JoinHostPort(bootstrap, strconv.Itoa(port)), gatherBootstrapOpts.sshKeys)
+	}
 	if err != nil && strings.Contains(err.Error(), "ssh: handshake failed: ssh: unable to authenticate") {
 		return errors.Wrap(err, "failed to create SSH client, ensure the private key is added to your authentication agent (ssh-agent) or specified with the --key parameter")
 	} else if err != nil {
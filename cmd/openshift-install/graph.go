@@ -1,11 +1,15 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"reflect"
 	"regexp"
+	"sort"
 
 	"github.com/awalterschulze/gographviz"
 	"github.com/sirupsen/logrus"
@@ -17,6 +21,7 @@ import (
 var (
 	graphOpts struct {
 		outputFile string
+		format     string
 	}
 )
 
@@ -29,10 +34,95 @@ func newGraphCmd() *cobra.Command {
 		RunE:  runGraphCmd,
 	}
 	cmd.PersistentFlags().StringVar(&graphOpts.outputFile, "output-file", "", "file where the graph is written, if empty prints the graph to Stdout.")
+	cmd.PersistentFlags().StringVar(&graphOpts.format, "output", "dot", "output format: dot or json")
 	return cmd
 }
 
+// graphNode describes one asset in the JSON rendering of the graph.
+type graphNode struct {
+	Name         string   `json:"name"`
+	Writable     bool     `json:"writable"`
+	Targets      []string `json:"targets,omitempty"`
+	Dependencies []string `json:"dependencies,omitempty"`
+	Files        []string `json:"files,omitempty"`
+	// InState reports whether the asset has a fingerprint in the
+	// current asset directory's state file, i.e. it was generated.
+	InState bool `json:"inState"`
+}
+
+// runGraphJSON walks the registered target assets' dependency DAG and
+// emits it as JSON, including the generation state from the current
+// asset directory.
+func runGraphJSON(writer io.Writer) error {
+	state := map[string]json.RawMessage{}
+	if data, err := ioutil.ReadFile(filepath.Join(rootOpts.dir, ".openshift_install_state.json")); err == nil {
+		if err := json.Unmarshal(data, &state); err != nil {
+			logrus.Debugf("Ignoring unreadable state file: %v", err)
+		}
+	}
+
+	nodes := map[string]*graphNode{}
+	var walk func(a asset.Asset)
+	walk = func(a asset.Asset) {
+		name := a.Name()
+		if _, seen := nodes[name]; seen {
+			return
+		}
+		node := &graphNode{Name: name}
+		if writable, ok := a.(asset.WritableAsset); ok {
+			node.Writable = true
+			for _, file := range writable.Files() {
+				node.Files = append(node.Files, file.Filename)
+			}
+		}
+		_, node.InState = state[reflect.TypeOf(a).String()]
+		for _, dep := range a.Dependencies() {
+			node.Dependencies = append(node.Dependencies, dep.Name())
+			walk(dep)
+		}
+		sort.Strings(node.Dependencies)
+		nodes[name] = node
+	}
+
+	for _, t := range targets {
+		for _, a := range t.assets {
+			walk(a)
+			nodes[a.Name()].Targets = append(nodes[a.Name()].Targets, t.name)
+		}
+	}
+
+	names := make([]string, 0, len(nodes))
+	for name := range nodes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	ordered := make([]*graphNode, 0, len(names))
+	for _, name := range names {
+		ordered = append(ordered, nodes[name])
+	}
+
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(ordered)
+}
+
 func runGraphCmd(cmd *cobra.Command, args []string) error {
+	if graphOpts.format == "json" {
+		out := io.Writer(os.Stdout)
+		if graphOpts.outputFile != "" {
+			f, err := os.Create(graphOpts.outputFile)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			out = f
+		}
+		return runGraphJSON(out)
+	}
+	if graphOpts.format != "dot" {
+		return fmt.Errorf("unsupported graph output format %q (supported: dot, json)", graphOpts.format)
+	}
+
 	g := gographviz.NewGraph()
 	g.SetName("G")
 	g.SetDir(true)
@@ -0,0 +1,224 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	ignconfig "github.com/coreos/ignition/config/v2_2"
+	igntypes "github.com/coreos/ignition/config/v2_2/types"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/vincent-petithory/dataurl"
+)
+
+// Per-platform user-data limits in bytes; pointer configs above these
+// cannot be passed through instance metadata.
+var userDataLimits = map[string]int{
+	"aws (instance user data)": 16384,
+	"openstack (config drive)": 65535,
+	"azure (custom data)":      87380,
+	"gcp (instance metadata)":  262144,
+}
+
+const previewLength = 120
+
+func newInspectCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "inspect",
+		Short: "Inspect generated assets",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+	cmd.AddCommand(newInspectIgnitionCmd())
+	return cmd
+}
+
+func newInspectIgnitionCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "ignition FILE",
+		Short: "Print the files, units and users an ignition config creates",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			return inspectIgnition(args[0])
+		},
+	}
+}
+
+func inspectIgnition(path string) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	config, report, err := ignconfig.Parse(data)
+	for _, entry := range report.Entries {
+		fmt.Printf("schema %s: %s (line %d, column %d)\n", entry.Kind, entry.Message, entry.Line, entry.Column)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to parse %s", path)
+	}
+
+	fmt.Printf("%s: ignition %s, %d bytes total\n", filepath.Base(path), config.Ignition.Version, len(data))
+	for _, name := range sortedLimitNames() {
+		if len(data) > userDataLimits[name] {
+			fmt.Printf("  WARNING: config exceeds the %s limit of %d bytes\n", name, userDataLimits[name])
+		}
+	}
+
+	if refs := config.Ignition.Config.Append; len(refs) > 0 {
+		fmt.Println("\nappended configs (pointer config):")
+		for _, ref := range refs {
+			fmt.Printf("  %s\n", ref.Source)
+			inspectLocalReference(ref.Source, path)
+		}
+	}
+	if ref := config.Ignition.Config.Replace; ref != nil {
+		fmt.Printf("\nreplaces config: %s\n", ref.Source)
+		inspectLocalReference(ref.Source, path)
+	}
+
+	printIgnitionContents(config)
+	diffManifestInventory(config)
+	return nil
+}
+
+// diffManifestInventory verifies the manifests recorded in the
+// embedded inventory against what the config actually carries, so a
+// missing or altered user manifest is called out.
+func diffManifestInventory(config igntypes.Config) {
+	var inventory []struct {
+		Path          string `json:"path"`
+		SHA256        string `json:"sha256"`
+		Source        string `json:"source"`
+		SkippedReason string `json:"skippedReason"`
+	}
+	contents := map[string][]byte{}
+	for _, file := range config.Storage.Files {
+		if file.Contents.Source == "" {
+			continue
+		}
+		decoded, err := dataurl.DecodeString(file.Contents.Source)
+		if err != nil {
+			continue
+		}
+		contents[file.Path] = decoded.Data
+		if file.Path == "/opt/openshift/manifest-inventory.json" {
+			if err := json.Unmarshal(decoded.Data, &inventory); err != nil {
+				fmt.Printf("\nmanifest inventory is unreadable: %v\n", err)
+				return
+			}
+		}
+	}
+	if inventory == nil {
+		return
+	}
+
+	fmt.Printf("\nmanifest inventory (%d entries):\n", len(inventory))
+	for _, entry := range inventory {
+		if entry.SkippedReason != "" {
+			fmt.Printf("  SKIPPED %s (%s): %s\n", entry.Path, entry.Source, entry.SkippedReason)
+			continue
+		}
+		data, ok := contents["/opt/openshift/"+entry.Path]
+		if !ok {
+			fmt.Printf("  MISSING %s (%s): inventoried but not present in the ignition\n", entry.Path, entry.Source)
+			continue
+		}
+		if fmt.Sprintf("%x", sha256.Sum256(data)) != entry.SHA256 {
+			fmt.Printf("  MODIFIED %s (%s): content differs from the inventoried digest\n", entry.Path, entry.Source)
+			continue
+		}
+		fmt.Printf("  ok %s (%s)\n", entry.Path, entry.Source)
+	}
+}
+
+// inspectLocalReference follows a merge/append reference when the
+// referenced config happens to sit next to the inspected file (e.g.
+// master.ign referencing a config also present in the asset dir).
+func inspectLocalReference(source, inspectedPath string) {
+	parsed, err := url.Parse(source)
+	if err != nil || parsed.Scheme != "" && parsed.Scheme != "file" {
+		return
+	}
+	local := filepath.Join(filepath.Dir(inspectedPath), filepath.Base(parsed.Path))
+	if data, err := ioutil.ReadFile(local); err == nil {
+		fmt.Printf("  (following local file %s, %d bytes)\n", local, len(data))
+		if config, _, err := ignconfig.Parse(data); err == nil {
+			printIgnitionContents(config)
+		}
+	}
+}
+
+func printIgnitionContents(config igntypes.Config) {
+	if len(config.Storage.Files) > 0 {
+		fmt.Printf("\nfiles (%d):\n", len(config.Storage.Files))
+		for _, file := range config.Storage.Files {
+			size := 0
+			preview := ""
+			if file.Contents.Source != "" {
+				if decoded, err := dataurl.DecodeString(file.Contents.Source); err == nil {
+					size = len(decoded.Data)
+					preview = contentPreview(decoded.Data)
+				}
+			}
+			mode := 0644
+			if file.Mode != nil {
+				mode = *file.Mode
+			}
+			fmt.Printf("  %s mode=%04o size=%d", file.Path, mode, size)
+			if preview != "" {
+				fmt.Printf(" | %s", preview)
+			}
+			fmt.Println()
+		}
+	}
+
+	if len(config.Systemd.Units) > 0 {
+		fmt.Printf("\nsystemd units (%d):\n", len(config.Systemd.Units))
+		for _, unit := range config.Systemd.Units {
+			state := "disabled"
+			if unit.Enabled != nil && *unit.Enabled || unit.Enable {
+				state = "enabled"
+			}
+			fmt.Printf("  %s %s size=%d\n", unit.Name, state, len(unit.Contents))
+		}
+	}
+
+	if len(config.Passwd.Users) > 0 {
+		fmt.Printf("\nusers (%d):\n", len(config.Passwd.Users))
+		for _, user := range config.Passwd.Users {
+			fmt.Printf("  %s sshKeys=%d\n", user.Name, len(user.SSHAuthorizedKeys))
+		}
+	}
+}
+
+// contentPreview returns a short, printable, single-line preview.
+func contentPreview(data []byte) string {
+	preview := string(data)
+	if len(preview) > previewLength {
+		preview = preview[:previewLength] + "..."
+	}
+	return strings.Map(func(r rune) rune {
+		if unicode.IsPrint(r) {
+			return r
+		}
+		return ' '
+	}, preview)
+}
+
+func sortedLimitNames() []string {
+	names := make([]string, 0, len(userDataLimits))
+	for name := range userDataLimits {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
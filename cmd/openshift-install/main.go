@@ -6,9 +6,12 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/installer/pkg/metrics"
 	"github.com/spf13/cobra"
 	"golang.org/x/crypto/ssh/terminal"
 	"k8s.io/klog"
@@ -18,8 +21,9 @@ import (
 
 var (
 	rootOpts struct {
-		dir      string
-		logLevel string
+		dir         string
+		logLevel    string
+		metricsFile string
 	}
 )
 
@@ -44,6 +48,13 @@ func main() {
 }
 
 func installerMain() {
+	// The command runners exit through logrus.Fatal (os.Exit, which
+	// skips defers), and CI wants the metrics of exactly those failed
+	// runs; flush through a logrus exit handler plus a deferred flush
+	// so panics and normal returns are covered too.
+	logrus.RegisterExitHandler(flushMetrics)
+	defer flushMetrics()
+
 	rootCmd := newRootCmd()
 
 	for _, subCmd := range []*cobra.Command{
@@ -53,16 +64,28 @@ func installerMain() {
 		newGatherCmd(),
 		newVersionCmd(),
 		newGraphCmd(),
+		newInspectCmd(),
+		newRecoverCmd(),
+		newRotateCredentialsCmd(),
 		newCompletionCmd(),
 	} {
 		rootCmd.AddCommand(subCmd)
 	}
 
-	if err := rootCmd.Execute(); err != nil {
+	metrics.Set("openshift_install_info", map[string]string{"invoker": "cli"}, 1, "Installer run marker.")
+	err := rootCmd.Execute()
+	metrics.Set("openshift_install_duration_seconds", map[string]string{"result": resultLabel(err)}, time.Since(installStartTime).Seconds(), "Total duration of the installer invocation.")
+	if err != nil {
 		logrus.Fatalf("Error executing openshift-install: %v", err)
 	}
 }
 
+// flushMetrics writes the metrics file once, whether the process ends
+// normally, through logrus.Fatal or in a panic.
+func flushMetrics() {
+	metrics.WriteFile()
+}
+
 func newRootCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:              filepath.Base(os.Args[0]),
@@ -74,10 +97,13 @@ func newRootCmd() *cobra.Command {
 	}
 	cmd.PersistentFlags().StringVar(&rootOpts.dir, "dir", ".", "assets directory")
 	cmd.PersistentFlags().StringVar(&rootOpts.logLevel, "log-level", "info", "log level (e.g. \"debug | info | warn | error\")")
+	cmd.PersistentFlags().StringVar(&rootOpts.metricsFile, "metrics-file", "", "write an OpenMetrics summary of the run to this file at exit")
 	return cmd
 }
 
 func runRootCmd(cmd *cobra.Command, args []string) {
+	metrics.SetFile(rootOpts.metricsFile)
+
 	logrus.SetOutput(ioutil.Discard)
 	logrus.SetLevel(logrus.TraceLevel)
 
@@ -101,3 +127,11 @@ func runRootCmd(cmd *cobra.Command, args []string) {
 		logrus.Fatal(errors.Wrap(err, "invalid log-level"))
 	}
 }
+
+// resultLabel renders the final result for the metrics file.
+func resultLabel(err error) string {
+	if err != nil {
+		return "failure"
+	}
+	return "success"
+}
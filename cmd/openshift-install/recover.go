@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/installer/pkg/terraform"
+)
+
+var recoverStateOpts struct {
+	list    bool
+	restore string
+}
+
+func newRecoverCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:    "recover",
+		Short:  "Recover from interrupted installer stages",
+		Hidden: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+	cmd.AddCommand(newRecoverStateCmd())
+	return cmd
+}
+
+func newRecoverStateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "state",
+		Short: "List or restore terraform state backups",
+		Args:  cobra.ExactArgs(0),
+		RunE: func(_ *cobra.Command, _ []string) error {
+			if recoverStateOpts.list {
+				backups, err := terraform.ListBackups(rootOpts.dir)
+				if err != nil {
+					return err
+				}
+				if len(backups) == 0 {
+					fmt.Println("no terraform state backups found")
+					return nil
+				}
+				for _, backup := range backups {
+					fmt.Println(backup)
+				}
+				return nil
+			}
+
+			backup := recoverStateOpts.restore
+			if backup == "" {
+				var err error
+				backup, err = terraform.NewestConsistentBackup(rootOpts.dir)
+				if err != nil {
+					return errors.Wrap(err, "no backup specified and no consistent backup found")
+				}
+			}
+			if err := terraform.RestoreBackup(rootOpts.dir, backup); err != nil {
+				return err
+			}
+			logrus.Infof("Restored terraform state from %s", backup)
+			return nil
+		},
+	}
+	cmd.PersistentFlags().BoolVar(&recoverStateOpts.list, "list", false, "list the available terraform state backups")
+	cmd.PersistentFlags().StringVar(&recoverStateOpts.restore, "restore", "", "restore the given backup (default: the newest consistent one)")
+	return cmd
+}
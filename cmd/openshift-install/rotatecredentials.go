@@ -0,0 +1,101 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+
+	awsconfig "github.com/openshift/installer/pkg/asset/installconfig/aws"
+	azureconfig "github.com/openshift/installer/pkg/asset/installconfig/azure"
+	awstypes "github.com/openshift/installer/pkg/types/aws"
+	azuretypes "github.com/openshift/installer/pkg/types/azure"
+)
+
+var cloudCredsSecretPath = filepath.Join("openshift", "99_cloud-creds-secret.yaml")
+
+func newRotateCredentialsCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "rotate-credentials",
+		Short: "Re-render the generated credential secrets from the current cloud credentials",
+		Args:  cobra.ExactArgs(0),
+		RunE: func(_ *cobra.Command, _ []string) error {
+			cleanup := setupFileHook(rootOpts.dir)
+			defer cleanup()
+			return runRotateCredentials(rootOpts.dir)
+		},
+	}
+}
+
+// runRotateCredentials validates the currently-configured credentials
+// (file/env, exactly like an install would load them) against the
+// platform and rewrites the generated credential secret manifest with
+// them, leaving every other asset untouched.
+func runRotateCredentials(directory string) error {
+	config := loadConsumedInstallConfig(directory)
+	if config == nil {
+		return errors.New("no consumed install config found in the asset directory; rotate-credentials only operates on an existing install's assets")
+	}
+
+	secretPath := filepath.Join(directory, cloudCredsSecretPath)
+	data, err := ioutil.ReadFile(secretPath)
+	if os.IsNotExist(err) {
+		return errors.Errorf("no %s found; generate manifests first, or the manifests were already consumed into the cluster where the cloud-credential-operator owns rotation", cloudCredsSecretPath)
+	} else if err != nil {
+		return err
+	}
+
+	secret := &corev1.Secret{}
+	if err := yaml.Unmarshal(data, secret); err != nil {
+		return errors.Wrapf(err, "failed to parse %s", cloudCredsSecretPath)
+	}
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+
+	switch config.Platform.Name() {
+	case awstypes.Name:
+		// GetSession smoke-tests the credentials before we embed them.
+		ssn, err := awsconfig.GetSession()
+		if err != nil {
+			return err
+		}
+		creds, err := ssn.Config.Credentials.Get()
+		if err != nil {
+			return errors.Wrap(err, "failed to read the new AWS credentials")
+		}
+		permissionGroups := []awsconfig.PermissionGroup{awsconfig.PermissionCreateBase, awsconfig.PermissionDeleteBase}
+		if err := awsconfig.ValidateCreds(ssn, awsconfig.Permissions(permissionGroups), config.Platform.AWS.Region); err != nil {
+			return errors.Wrap(err, "the new AWS credentials fail the permission preflight")
+		}
+		secret.Data["aws_access_key_id"] = []byte(creds.AccessKeyID)
+		secret.Data["aws_secret_access_key"] = []byte(creds.SecretAccessKey)
+	case azuretypes.Name:
+		// GetSession validates the service principal through ARM.
+		session, err := azureconfig.GetSession()
+		if err != nil {
+			return err
+		}
+		secret.Data["azure_subscription_id"] = []byte(session.Credentials.SubscriptionID)
+		secret.Data["azure_client_id"] = []byte(session.Credentials.ClientID)
+		secret.Data["azure_client_secret"] = []byte(session.Credentials.ClientSecret)
+		secret.Data["azure_tenant_id"] = []byte(session.Credentials.TenantID)
+	default:
+		return errors.Errorf("rotate-credentials is not supported for platform %q", config.Platform.Name())
+	}
+
+	rendered, err := yaml.Marshal(secret)
+	if err != nil {
+		return errors.Wrap(err, "failed to render the rotated secret")
+	}
+	if err := ioutil.WriteFile(secretPath, rendered, 0644); err != nil {
+		return err
+	}
+	logrus.Infof("Rotated the credentials in %s; no other assets were touched", cloudCredsSecretPath)
+	return nil
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/rest"
+)
+
+// waitTimeoutEnv is the environment equivalent of --wait-timeout.
+const waitTimeoutEnv = "OPENSHIFT_INSTALL_WAIT_TIMEOUT"
+
+// waitTimeoutFlag scales the hard-coded wait thresholds. Zero keeps
+// the defaults; a non-zero value replaces the bootstrap-complete and
+// cluster-initialize timeouts wholesale so disconnected environments
+// can wait longer and CI can fail fast.
+var waitTimeoutFlag time.Duration
+
+// apiServerOverride replaces the API server URL from the kubeconfig,
+// for UPI hosts where the API hostname is not resolvable yet.
+var apiServerOverride string
+
+// addWaitTimeoutFlag registers --wait-timeout on commands that wait
+// for cluster events.
+func addWaitTimeoutFlag(cmd *cobra.Command) {
+	cmd.PersistentFlags().DurationVar(&waitTimeoutFlag, "wait-timeout", 0, "override how long to wait for bootstrap and install completion (e.g. 90m; 0 keeps the defaults)")
+	cmd.PersistentFlags().StringVar(&apiServerOverride, "api-server-url", "", "connect to this API server URL (or https://IP:port) instead of the one in the kubeconfig, e.g. while the API DNS record does not resolve yet")
+}
+
+// waitTimeout returns the configured override, or the given default
+// when no flag or environment override is set.
+func waitTimeout(defaultTimeout time.Duration) time.Duration {
+	if waitTimeoutFlag != 0 {
+		return waitTimeoutFlag
+	}
+	if value := os.Getenv(waitTimeoutEnv); value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			logrus.Warnf("Ignoring invalid %s %q: %v", waitTimeoutEnv, value, err)
+			return defaultTimeout
+		}
+		return parsed
+	}
+	return defaultTimeout
+}
+
+// applyAPIServerOverride points the client at --api-server-url when
+// set, keeping the kubeconfig's hostname as the TLS server name: the
+// serving certificate only carries the DNS SANs, so dialing an IP
+// without it would fail x509 hostname verification - precisely in the
+// "DNS does not resolve yet" situation the flag exists for.
+func applyAPIServerOverride(config *rest.Config) {
+	if apiServerOverride == "" {
+		return
+	}
+	if config.TLSClientConfig.ServerName == "" {
+		if hostURL, err := url.Parse(config.Host); err == nil && hostURL.Hostname() != "" {
+			config.TLSClientConfig.ServerName = hostURL.Hostname()
+		}
+	}
+	logrus.Infof("Overriding the API server endpoint with %s", apiServerOverride)
+	config.Host = apiServerOverride
+}
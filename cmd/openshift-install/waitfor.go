@@ -25,11 +25,12 @@ them directly.`,
 	}
 	cmd.AddCommand(newWaitForBootstrapCompleteCmd())
 	cmd.AddCommand(newWaitForInstallCompleteCmd())
+	cmd.AddCommand(newWaitForCSRApprovalCmd())
 	return cmd
 }
 
 func newWaitForBootstrapCompleteCmd() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "bootstrap-complete",
 		Short: "Wait until cluster bootstrapping has completed",
 		Args:  cobra.ExactArgs(0),
@@ -43,6 +44,7 @@ func newWaitForBootstrapCompleteCmd() *cobra.Command {
 			if err != nil {
 				logrus.Fatal(errors.Wrap(err, "loading kubeconfig"))
 			}
+			applyAPIServerOverride(config)
 
 			err = waitForBootstrapComplete(ctx, config, rootOpts.dir)
 			if err != nil {
@@ -58,10 +60,12 @@ func newWaitForBootstrapCompleteCmd() *cobra.Command {
 			logrus.Info("It is now safe to remove the bootstrap resources")
 		},
 	}
+	addWaitTimeoutFlag(cmd)
+	return cmd
 }
 
 func newWaitForInstallCompleteCmd() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "install-complete",
 		Short: "Wait until the cluster is ready",
 		Args:  cobra.ExactArgs(0),
@@ -75,6 +79,7 @@ func newWaitForInstallCompleteCmd() *cobra.Command {
 			if err != nil {
 				logrus.Fatal(errors.Wrap(err, "loading kubeconfig"))
 			}
+			applyAPIServerOverride(config)
 
 			err = waitForInstallComplete(ctx, config, rootOpts.dir)
 			if err != nil {
@@ -86,4 +91,6 @@ func newWaitForInstallCompleteCmd() *cobra.Command {
 			}
 		},
 	}
+	addWaitTimeoutFlag(cmd)
+	return cmd
 }
@@ -0,0 +1,54 @@
+resource "aws_security_group" "bastion" {
+  count = var.aws_bastion_enabled ? 1 : 0
+
+  name   = "${var.cluster_id}-bastion-sg"
+  vpc_id = module.vpc.vpc_id
+
+  ingress {
+    protocol    = "tcp"
+    from_port   = 22
+    to_port     = 22
+    cidr_blocks = var.aws_bastion_allowed_cidrs
+  }
+
+  egress {
+    protocol    = "-1"
+    from_port   = 0
+    to_port     = 0
+    cidr_blocks = ["0.0.0.0/0"]
+  }
+
+  tags = merge(
+    {
+      "Name" = "${var.cluster_id}-bastion-sg"
+      "kubernetes.io/cluster/${var.cluster_id}" = "owned"
+    },
+    var.aws_extra_tags,
+  )
+}
+
+resource "aws_key_pair" "bastion" {
+  count = var.aws_bastion_enabled && var.aws_bastion_ssh_key != "" ? 1 : 0
+
+  key_name   = "${var.cluster_id}-bastion"
+  public_key = var.aws_bastion_ssh_key
+}
+
+resource "aws_instance" "bastion" {
+  count = var.aws_bastion_enabled ? 1 : 0
+
+  ami                         = var.aws_ami
+  instance_type               = var.aws_bastion_instance_type
+  subnet_id                   = module.vpc.az_to_public_subnet_id[var.aws_master_availability_zones[0]]
+  vpc_security_group_ids      = [aws_security_group.bastion[0].id]
+  associate_public_ip_address = true
+  key_name                    = var.aws_bastion_ssh_key != "" ? aws_key_pair.bastion[0].key_name : null
+
+  tags = merge(
+    {
+      "Name" = "${var.cluster_id}-bastion"
+      "kubernetes.io/cluster/${var.cluster_id}" = "owned"
+    },
+    var.aws_extra_tags,
+  )
+}
@@ -52,6 +52,9 @@ resource "aws_iam_instance_profile" "bootstrap" {
 }
 
 resource "aws_iam_role" "bootstrap" {
+  permissions_boundary = var.iam_permissions_boundary == "" ? null : var.iam_permissions_boundary
+  tags                 = var.iam_role_tags
+
   name = "${var.cluster_id}-bootstrap-role"
   path = "/"
 
@@ -116,6 +119,8 @@ EOF
 }
 
 resource "aws_instance" "bootstrap" {
+  placement_group = var.placement_group == "" ? null : var.placement_group
+
   ami = var.ami
 
   iam_instance_profile        = aws_iam_instance_profile.bootstrap.name
@@ -78,3 +78,22 @@ variable "publish_strategy" {
   type        = string
   description = "The publishing strategy for endpoints like load balancers"
 }
+
+variable "placement_group" {
+  type        = string
+  default     = ""
+  description = "Name of the placement group the instances join, if any."
+}
+
+variable "iam_permissions_boundary" {
+  type        = string
+  default     = ""
+  description = "ARN of the policy attached as the permissions boundary to the IAM roles."
+}
+
+variable "iam_role_tags" {
+  type        = map(string)
+  default     = {}
+  description = "Additional tags applied to the IAM roles."
+}
+
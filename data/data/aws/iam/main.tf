@@ -11,6 +11,9 @@ resource "aws_iam_instance_profile" "worker" {
 }
 
 resource "aws_iam_role" "worker_role" {
+  permissions_boundary = var.iam_permissions_boundary == "" ? null : var.iam_permissions_boundary
+  tags                 = var.iam_role_tags
+
   name = "${var.cluster_id}-worker-role"
   path = "/"
 
@@ -8,3 +8,15 @@ variable "tags" {
   description = "AWS tags to be applied to created resources."
 }
 
+
+variable "iam_permissions_boundary" {
+  type        = string
+  default     = ""
+  description = "ARN of the policy attached as the permissions boundary to the IAM roles."
+}
+
+variable "iam_role_tags" {
+  type        = map(string)
+  default     = {}
+  description = "Additional tags applied to the IAM roles."
+}
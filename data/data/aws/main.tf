@@ -16,8 +16,13 @@ provider "aws" {
 }
 
 module "bootstrap" {
+  iam_permissions_boundary = var.aws_iam_permissions_boundary
+  iam_role_tags            = var.aws_iam_role_tags
+
   source = "./bootstrap"
 
+  placement_group = local.master_placement_group
+
   ami                      = aws_ami_copy.main.id
   instance_type            = var.aws_bootstrap_instance_type
   cluster_id               = var.cluster_id
@@ -33,9 +38,25 @@ module "bootstrap" {
   tags = local.tags
 }
 
+resource "aws_placement_group" "master" {
+  count = var.aws_master_placement_strategy == "" ? 0 : 1
+
+  name     = "${var.cluster_id}-master-pg"
+  strategy = var.aws_master_placement_strategy
+}
+
+locals {
+  master_placement_group = var.aws_master_placement_group != "" ? var.aws_master_placement_group : (var.aws_master_placement_strategy != "" ? aws_placement_group.master[0].name : "")
+}
+
 module "masters" {
+  iam_permissions_boundary = var.aws_iam_permissions_boundary
+  iam_role_tags            = var.aws_iam_role_tags
+
   source = "./master"
 
+  placement_group = local.master_placement_group
+
   cluster_id    = var.cluster_id
   instance_type = var.aws_master_instance_type
 
@@ -56,6 +77,9 @@ module "masters" {
 }
 
 module "iam" {
+  iam_permissions_boundary = var.aws_iam_permissions_boundary
+  iam_role_tags            = var.aws_iam_role_tags
+
   source = "./iam"
 
   cluster_id = var.cluster_id
@@ -66,6 +90,8 @@ module "iam" {
 module "dns" {
   source = "./route53"
 
+  user_provisioned_dns = var.aws_user_provisioned_dns
+
   api_external_lb_dns_name = module.vpc.aws_lb_api_external_dns_name
   api_external_lb_zone_id  = module.vpc.aws_lb_api_external_zone_id
   api_internal_lb_dns_name = module.vpc.aws_lb_api_internal_dns_name
@@ -83,6 +109,11 @@ module "dns" {
 module "vpc" {
   source = "./vpc"
 
+  mcs_port = var.mcs_port
+
+  extra_machine_cidrs = var.aws_extra_machine_cidrs
+  api_external_port   = var.api_external_port
+
   cidr_block       = var.machine_cidr
   cluster_id       = var.cluster_id
   region           = var.aws_region
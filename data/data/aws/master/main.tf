@@ -13,6 +13,9 @@ resource "aws_iam_instance_profile" "master" {
 }
 
 resource "aws_iam_role" "master_role" {
+  permissions_boundary = var.iam_permissions_boundary == "" ? null : var.iam_permissions_boundary
+  tags                 = var.iam_role_tags
+
   name = "${var.cluster_id}-master-role"
   path = "/"
 
@@ -91,6 +94,8 @@ resource "aws_network_interface" "master" {
 }
   
 resource "aws_instance" "master" {
+  placement_group = var.placement_group == "" ? null : var.placement_group
+
   count = var.instance_count
   ami   = var.ec2_ami
 
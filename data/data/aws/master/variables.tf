@@ -80,3 +80,22 @@ and therefore are force to implicitly assume that the list is of aws_lb_target_g
 helps to decide if the target_group_arns is of length (target_group_arns_length) or (target_group_arns_length - 1)
 EOF
 }
+
+variable "placement_group" {
+  type        = string
+  default     = ""
+  description = "Name of the placement group the instances join, if any."
+}
+
+variable "iam_permissions_boundary" {
+  type        = string
+  default     = ""
+  description = "ARN of the policy attached as the permissions boundary to the IAM roles."
+}
+
+variable "iam_role_tags" {
+  type        = map(string)
+  default     = {}
+  description = "Additional tags applied to the IAM roles."
+}
+
@@ -13,6 +13,8 @@ data "aws_route53_zone" "public" {
 }
 
 resource "aws_route53_zone" "int" {
+  count = var.user_provisioned_dns ? 0 : 1
+
   name          = var.cluster_domain
   force_destroy = true
 
@@ -45,7 +47,9 @@ resource "aws_route53_record" "api_external" {
 }
 
 resource "aws_route53_record" "api_internal" {
-  zone_id = aws_route53_zone.int.zone_id
+  count = var.user_provisioned_dns ? 0 : 1
+
+  zone_id = aws_route53_zone.int[0].zone_id
   name    = "api-int.${var.cluster_domain}"
   type    = "A"
 
@@ -57,7 +61,9 @@ resource "aws_route53_record" "api_internal" {
 }
 
 resource "aws_route53_record" "api_external_internal_zone" {
-  zone_id = aws_route53_zone.int.zone_id
+  count = var.user_provisioned_dns ? 0 : 1
+
+  zone_id = aws_route53_zone.int[0].zone_id
   name    = "api.${var.cluster_domain}"
   type    = "A"
 
@@ -69,10 +75,11 @@ resource "aws_route53_record" "api_external_internal_zone" {
 }
 
 resource "aws_route53_record" "etcd_a_nodes" {
-  count   = var.etcd_count
+
+  count   = var.user_provisioned_dns ? 0 : var.etcd_count
   type    = "A"
   ttl     = "60"
-  zone_id = aws_route53_zone.int.zone_id
+  zone_id = aws_route53_zone.int[0].zone_id
   name    = "etcd-${count.index}.${var.cluster_domain}"
   # TF-UPGRADE-TODO: In Terraform v0.10 and earlier, it was sometimes necessary to
   # force an interpolation expression to be interpreted as a list by wrapping it
@@ -86,9 +93,11 @@ resource "aws_route53_record" "etcd_a_nodes" {
 }
 
 resource "aws_route53_record" "etcd_cluster" {
+  count = var.user_provisioned_dns ? 0 : 1
+
   type    = "SRV"
   ttl     = "60"
-  zone_id = aws_route53_zone.int.zone_id
+  zone_id = aws_route53_zone.int[0].zone_id
   name    = "_etcd-server-ssl._tcp"
   records = formatlist("0 10 2380 %s", aws_route53_record.etcd_a_nodes.*.fqdn)
 }
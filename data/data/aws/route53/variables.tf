@@ -64,3 +64,9 @@ based on if api_external_lb_dns_name for example, which will be null when there
 So publish_strategy serves an coordinated proxy for that decision.
 EOF
 }
+
+variable "user_provisioned_dns" {
+  type        = bool
+  default     = false
+  description = "Skip creating the private hosted zone and internal records; a user-managed resolver serves them."
+}
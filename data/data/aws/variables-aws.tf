@@ -91,3 +91,64 @@ variable "aws_publish_strategy" {
   type = string
   description = "The cluster publishing strategy, either Internal or External"
 }
+
+variable "aws_extra_machine_cidrs" {
+  type        = list(string)
+  default     = []
+  description = "Additional CIDR ranges (e.g. Local Zone subnets) included in node-to-node security group rules."
+}
+
+variable "aws_master_placement_group" {
+  type        = string
+  default     = ""
+  description = "Name of an existing placement group for the control plane."
+}
+
+variable "aws_master_placement_strategy" {
+  type        = string
+  default     = ""
+  description = "Strategy of the placement group the installer creates for the control plane when no existing group is named."
+}
+
+variable "aws_iam_permissions_boundary" {
+  type        = string
+  default     = ""
+  description = "ARN of the policy attached as the permissions boundary to every IAM role the installer creates."
+}
+
+variable "aws_iam_role_tags" {
+  type        = map(string)
+  default     = {}
+  description = "Additional tags applied to every IAM role the installer creates."
+}
+
+variable "aws_bastion_enabled" {
+  type        = bool
+  default     = false
+  description = "Provision a small jump host in a public subnet for reaching internal clusters."
+}
+
+variable "aws_bastion_instance_type" {
+  type        = string
+  default     = "t3.micro"
+  description = "Instance type of the bastion."
+}
+
+variable "aws_bastion_allowed_cidrs" {
+  type        = list(string)
+  default     = ["0.0.0.0/0"]
+  description = "CIDRs allowed to SSH to the bastion."
+}
+
+variable "aws_bastion_ssh_key" {
+  type        = string
+  default     = ""
+  description = "Public SSH key installed on the bastion."
+}
+
+variable "aws_user_provisioned_dns" {
+  type        = bool
+  default     = false
+  description = "Skip creating the private hosted zone and internal records; a user-managed resolver serves them."
+}
+
@@ -97,6 +97,9 @@ resource "aws_lb_target_group" "api_external" {
 resource "aws_lb_target_group" "services" {
   name     = "${var.cluster_id}-sint"
   protocol = "TCP"
+
+  # The machine config server always listens on 22623; only the
+  # listener's frontend port is configurable.
   port     = 22623
   vpc_id   = data.aws_vpc.cluster_vpc.id
 
@@ -133,7 +136,7 @@ resource "aws_lb_listener" "api_internal_api" {
 resource "aws_lb_listener" "api_internal_services" {
   load_balancer_arn = aws_lb.api_internal.arn
   protocol          = "TCP"
-  port              = "22623"
+  port              = var.mcs_port
 
   default_action {
     target_group_arn = aws_lb_target_group.services.arn
@@ -146,7 +149,7 @@ resource "aws_lb_listener" "api_external_api" {
 
   load_balancer_arn = aws_lb.api_external[0].arn
   protocol          = "TCP"
-  port              = "6443"
+  port              = var.api_external_port
 
   default_action {
     target_group_arn = aws_lb_target_group.api_external[0].arn
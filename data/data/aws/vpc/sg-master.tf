@@ -18,7 +18,7 @@ resource "aws_security_group_rule" "master_mcs" {
   security_group_id = aws_security_group.master.id
 
   protocol    = "tcp"
-  cidr_blocks = [data.aws_vpc.cluster_vpc.cidr_block]
+  cidr_blocks = concat([data.aws_vpc.cluster_vpc.cidr_block], var.extra_machine_cidrs)
   from_port   = 22623
   to_port     = 22623
 }
@@ -38,7 +38,7 @@ resource "aws_security_group_rule" "master_ingress_icmp" {
   security_group_id = aws_security_group.master.id
 
   protocol    = "icmp"
-  cidr_blocks = [data.aws_vpc.cluster_vpc.cidr_block]
+  cidr_blocks = concat([data.aws_vpc.cluster_vpc.cidr_block], var.extra_machine_cidrs)
   from_port   = -1
   to_port     = -1
 }
@@ -48,7 +48,7 @@ resource "aws_security_group_rule" "master_ingress_ssh" {
   security_group_id = aws_security_group.master.id
 
   protocol    = "tcp"
-  cidr_blocks = [data.aws_vpc.cluster_vpc.cidr_block]
+  cidr_blocks = concat([data.aws_vpc.cluster_vpc.cidr_block], var.extra_machine_cidrs)
   from_port   = 22
   to_port     = 22
 }
@@ -58,7 +58,7 @@ resource "aws_security_group_rule" "master_ingress_https" {
   security_group_id = aws_security_group.master.id
 
   protocol    = "tcp"
-  cidr_blocks = [data.aws_vpc.cluster_vpc.cidr_block]
+  cidr_blocks = concat([data.aws_vpc.cluster_vpc.cidr_block], var.extra_machine_cidrs)
   from_port   = 6443
   to_port     = 6443
 }
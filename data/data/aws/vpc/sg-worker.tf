@@ -28,7 +28,7 @@ resource "aws_security_group_rule" "worker_ingress_icmp" {
   security_group_id = aws_security_group.worker.id
 
   protocol    = "icmp"
-  cidr_blocks = [data.aws_vpc.cluster_vpc.cidr_block]
+  cidr_blocks = concat([data.aws_vpc.cluster_vpc.cidr_block], var.extra_machine_cidrs)
   from_port   = -1
   to_port     = -1
 }
@@ -38,7 +38,7 @@ resource "aws_security_group_rule" "worker_ingress_ssh" {
   security_group_id = aws_security_group.worker.id
 
   protocol    = "tcp"
-  cidr_blocks = [data.aws_vpc.cluster_vpc.cidr_block]
+  cidr_blocks = concat([data.aws_vpc.cluster_vpc.cidr_block], var.extra_machine_cidrs)
   from_port   = 22
   to_port     = 22
 }
@@ -41,3 +41,21 @@ variable "private_subnets" {
   type        = list(string)
   description = "Existing private subnets into which the cluster should be installed."
 }
+
+variable "mcs_port" {
+  type        = string
+  default     = "22623"
+  description = "The port the machine config server listens on."
+}
+
+variable "extra_machine_cidrs" {
+  type        = list(string)
+  default     = []
+  description = "Additional CIDR ranges (e.g. Local Zone subnets) included in node-to-node security group rules."
+}
+
+variable "api_external_port" {
+  type        = string
+  default     = "6443"
+  description = "The port the Kubernetes API is published on at the external load balancer."
+}
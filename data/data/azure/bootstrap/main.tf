@@ -181,9 +181,12 @@ resource "azurerm_virtual_machine" "bootstrap" {
   delete_os_disk_on_termination    = true
   delete_data_disks_on_termination = true
 
-  identity {
-    type         = "UserAssigned"
-    identity_ids = [var.identity]
+  dynamic "identity" {
+    for_each = var.identity == "" ? [] : [1]
+    content {
+      type         = "UserAssigned"
+      identity_ids = [var.identity]
+    }
   }
 
   storage_os_disk {
@@ -194,8 +197,30 @@ resource "azurerm_virtual_machine" "bootstrap" {
     disk_size_gb      = 100
   }
 
-  storage_image_reference {
-    id = var.vm_image
+  dynamic "storage_image_reference" {
+    for_each = var.image_publisher == "" ? [1] : []
+    content {
+      id = var.vm_image
+    }
+  }
+
+  dynamic "storage_image_reference" {
+    for_each = var.image_publisher == "" ? [] : [1]
+    content {
+      publisher = var.image_publisher
+      offer     = var.image_offer
+      sku       = var.image_sku
+      version   = var.image_version
+    }
+  }
+
+  dynamic "plan" {
+    for_each = var.image_plan_name == "" ? [] : [1]
+    content {
+      name      = var.image_plan_name
+      product   = var.image_offer
+      publisher = var.image_publisher
+    }
   }
 
   os_profile {
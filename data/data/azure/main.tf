@@ -1,4 +1,10 @@
 locals {
+  use_marketplace_image = var.azure_image_publisher != ""
+
+  resource_group_name     = var.azure_resource_group_name == "" ? azurerm_resource_group.main[0].name : data.azurerm_resource_group.main[0].name
+  resource_group_id       = var.azure_resource_group_name == "" ? azurerm_resource_group.main[0].id : data.azurerm_resource_group.main[0].id
+  resource_group_location = var.azure_resource_group_name == "" ? azurerm_resource_group.main[0].location : data.azurerm_resource_group.main[0].location
+
   tags = merge(
     {
       "kubernetes.io_cluster.${var.cluster_id}" = "owned"
@@ -23,11 +29,16 @@ provider "azureprivatedns" {
 
 module "bootstrap" {
   source                 = "./bootstrap"
-  resource_group_name    = azurerm_resource_group.main.name
+  resource_group_name    = local.resource_group_name
   region                 = var.azure_region
   vm_size                = var.azure_bootstrap_vm_type
-  vm_image               = azurerm_image.cluster.id
-  identity               = azurerm_user_assigned_identity.main.id
+  vm_image               = local.use_marketplace_image ? "" : azurerm_image.cluster[0].id
+  image_publisher        = var.azure_image_publisher
+  image_offer            = var.azure_image_offer
+  image_sku              = var.azure_image_sku
+  image_version          = var.azure_image_version
+  image_plan_name        = var.azure_image_plan_name
+  identity               = local.identity_id
   cluster_id             = var.cluster_id
   ignition               = var.ignition_bootstrap
   subnet_id              = module.vnet.master_subnet_id
@@ -47,7 +58,9 @@ module "bootstrap" {
 
 module "vnet" {
   source              = "./vnet"
-  resource_group_name = azurerm_resource_group.main.name
+
+  mcs_port = var.mcs_port
+  resource_group_name = local.resource_group_name
   vnet_v4_cidrs       = var.azure_machine_v4_cidrs
   vnet_v6_cidrs       = var.azure_machine_v6_cidrs
   cluster_id          = var.cluster_id
@@ -60,6 +73,8 @@ module "vnet" {
   master_subnet               = var.azure_control_plane_subnet
   worker_subnet               = var.azure_compute_subnet
   private                     = var.azure_private
+  api_public_ip_prefix_id     = var.azure_api_public_ip_prefix_id
+  api_external_port           = var.api_external_port
 
   use_ipv4                  = var.use_ipv4 || var.azure_emulate_single_stack_ipv6
   use_ipv6                  = var.use_ipv6
@@ -68,13 +83,18 @@ module "vnet" {
 
 module "master" {
   source                 = "./master"
-  resource_group_name    = azurerm_resource_group.main.name
+  resource_group_name    = local.resource_group_name
   cluster_id             = var.cluster_id
   region                 = var.azure_region
   availability_zones     = var.azure_master_availability_zones
   vm_size                = var.azure_master_vm_type
-  vm_image               = azurerm_image.cluster.id
-  identity               = azurerm_user_assigned_identity.main.id
+  vm_image               = local.use_marketplace_image ? "" : azurerm_image.cluster[0].id
+  image_publisher        = var.azure_image_publisher
+  image_offer            = var.azure_image_offer
+  image_sku              = var.azure_image_sku
+  image_version          = var.azure_image_version
+  image_plan_name        = var.azure_image_plan_name
+  identity               = local.identity_id
   ignition               = var.ignition_master
   external_lb_id         = module.vnet.public_lb_id
   elb_backend_pool_v4_id = module.vnet.public_lb_backend_pool_v4_id
@@ -103,7 +123,7 @@ module "dns" {
   external_lb_fqdn_v6             = module.vnet.public_lb_pip_v6_fqdn
   internal_lb_ipaddress_v4        = module.vnet.internal_lb_ip_v4_address
   internal_lb_ipaddress_v6        = module.vnet.internal_lb_ip_v6_address
-  resource_group_name             = azurerm_resource_group.main.name
+  resource_group_name             = local.resource_group_name
   base_domain_resource_group_name = var.azure_base_domain_resource_group_name
   etcd_count                      = var.master_count
   etcd_ip_v4_addresses            = module.master.ip_v4_addresses
@@ -122,11 +142,19 @@ resource "random_string" "storage_suffix" {
 }
 
 resource "azurerm_resource_group" "main" {
+  count = var.azure_resource_group_name == "" ? 1 : 0
+
   name     = "${var.cluster_id}-rg"
   location = var.azure_region
   tags     = local.tags
 }
 
+data "azurerm_resource_group" "main" {
+  count = var.azure_resource_group_name == "" ? 0 : 1
+
+  name = var.azure_resource_group_name
+}
+
 data "azurerm_resource_group" "network" {
   count = var.azure_preexisting_network ? 1 : 0
 
@@ -135,58 +163,74 @@ data "azurerm_resource_group" "network" {
 
 resource "azurerm_storage_account" "cluster" {
   name                     = "cluster${random_string.storage_suffix.result}"
-  resource_group_name      = azurerm_resource_group.main.name
+  resource_group_name      = local.resource_group_name
   location                 = var.azure_region
   account_tier             = "Standard"
   account_replication_type = "LRS"
+  tags                     = local.tags
+}
+
+locals {
+  create_identity = var.azure_use_identity && var.azure_user_assigned_identity_id == ""
+  identity_id     = var.azure_user_assigned_identity_id != "" ? var.azure_user_assigned_identity_id : (local.create_identity ? azurerm_user_assigned_identity.main[0].id : "")
 }
 
 resource "azurerm_user_assigned_identity" "main" {
-  resource_group_name = azurerm_resource_group.main.name
-  location            = azurerm_resource_group.main.location
+  count = local.create_identity ? 1 : 0
+
+  resource_group_name = local.resource_group_name
+  location            = local.resource_group_location
 
   name = "${var.cluster_id}-identity"
 }
 
 resource "azurerm_role_assignment" "main" {
-  scope                = azurerm_resource_group.main.id
+  count = local.create_identity ? 1 : 0
+
+  scope                = local.resource_group_id
   role_definition_name = "Contributor"
-  principal_id         = azurerm_user_assigned_identity.main.principal_id
+  principal_id         = azurerm_user_assigned_identity.main[0].principal_id
 }
 
 resource "azurerm_role_assignment" "network" {
-  count = var.azure_preexisting_network ? 1 : 0
+  count = var.azure_preexisting_network && local.create_identity ? 1 : 0
 
   scope                = data.azurerm_resource_group.network[0].id
   role_definition_name = "Contributor"
-  principal_id         = azurerm_user_assigned_identity.main.principal_id
+  principal_id         = azurerm_user_assigned_identity.main[0].principal_id
 }
 
 # copy over the vhd to cluster resource group and create an image using that
 resource "azurerm_storage_container" "vhd" {
+  count = local.use_marketplace_image ? 0 : 1
+
   name                 = "vhd"
-  resource_group_name  = azurerm_resource_group.main.name
+  resource_group_name  = local.resource_group_name
   storage_account_name = azurerm_storage_account.cluster.name
 }
 
 resource "azurerm_storage_blob" "rhcos_image" {
+  count = local.use_marketplace_image ? 0 : 1
+
   name                   = "rhcos${random_string.storage_suffix.result}.vhd"
-  resource_group_name    = azurerm_resource_group.main.name
+  resource_group_name    = local.resource_group_name
   storage_account_name   = azurerm_storage_account.cluster.name
-  storage_container_name = azurerm_storage_container.vhd.name
+  storage_container_name = azurerm_storage_container.vhd[0].name
   type                   = "block"
   source_uri             = var.azure_image_url
   metadata               = map("source_uri", var.azure_image_url)
 }
 
 resource "azurerm_image" "cluster" {
+  count = local.use_marketplace_image ? 0 : 1
+
   name                = var.cluster_id
-  resource_group_name = azurerm_resource_group.main.name
+  resource_group_name = local.resource_group_name
   location            = var.azure_region
 
   os_disk {
     os_type  = "Linux"
     os_state = "Generalized"
-    blob_uri = azurerm_storage_blob.rhcos_image.url
+    blob_uri = azurerm_storage_blob.rhcos_image[0].url
   }
 }
@@ -114,3 +114,33 @@ variable "emulate_single_stack_ipv6" {
   type        = bool
   description = "This determines whether a dual-stack cluster is configured to emulate single-stack IPv6."
 }
+
+variable "image_publisher" {
+  type        = string
+  default     = ""
+  description = "The publisher of the marketplace image to boot from instead of the uploaded RHCOS image."
+}
+
+variable "image_offer" {
+  type        = string
+  default     = ""
+  description = "The offer of the marketplace image."
+}
+
+variable "image_sku" {
+  type        = string
+  default     = ""
+  description = "The SKU of the marketplace image."
+}
+
+variable "image_version" {
+  type        = string
+  default     = ""
+  description = "The version of the marketplace image."
+}
+
+variable "image_plan_name" {
+  type        = string
+  default     = ""
+  description = "The purchase plan name for third-party marketplace images."
+}
@@ -134,3 +134,57 @@ variable "azure_emulate_single_stack_ipv6" {
   type        = bool
   description = "This determines whether a dual-stack cluster is configured to emulate single-stack IPv6."
 }
+
+variable "azure_api_public_ip_prefix_id" {
+  type        = string
+  default     = ""
+  description = "The ID of an existing public IP prefix from which the API load balancer's public IP is allocated."
+}
+
+variable "azure_image_publisher" {
+  type        = string
+  default     = ""
+  description = "The publisher of the marketplace image to boot machines from instead of uploading the RHCOS VHD."
+}
+
+variable "azure_image_offer" {
+  type        = string
+  default     = ""
+  description = "The offer of the marketplace image."
+}
+
+variable "azure_image_sku" {
+  type        = string
+  default     = ""
+  description = "The SKU of the marketplace image."
+}
+
+variable "azure_image_version" {
+  type        = string
+  default     = ""
+  description = "The version of the marketplace image."
+}
+
+variable "azure_image_plan_name" {
+  type        = string
+  default     = ""
+  description = "The purchase plan name for third-party marketplace images."
+}
+
+variable "azure_use_identity" {
+  type        = bool
+  default     = true
+  description = "Whether to create and assign a user-assigned managed identity; Azure Stack Hub has no managed identities."
+}
+
+variable "azure_user_assigned_identity_id" {
+  type        = string
+  default     = ""
+  description = "Resource ID of an existing user-assigned managed identity to assign to the VMs instead of creating one."
+}
+
+variable "azure_resource_group_name" {
+  type        = string
+  default     = ""
+  description = "Name of a pre-created resource group to install into; when empty the installer creates <cluster_id>-rg."
+}
@@ -92,7 +92,7 @@ resource "azurerm_lb_rule" "internal_lb_rule_sint_v4" {
   protocol                       = "Tcp"
   backend_address_pool_id        = azurerm_lb_backend_address_pool.internal_lb_controlplane_pool_v4[0].id
   loadbalancer_id                = azurerm_lb.internal.id
-  frontend_port                  = 22623
+  frontend_port                  = var.mcs_port
   backend_port                   = 22623
   frontend_ip_configuration_name = local.internal_lb_frontend_ip_v4_configuration_name
   enable_floating_ip             = false
@@ -109,7 +109,7 @@ resource "azurerm_lb_rule" "internal_lb_rule_sint_v6" {
   protocol                       = "Tcp"
   backend_address_pool_id        = azurerm_lb_backend_address_pool.internal_lb_controlplane_pool_v6[0].id
   loadbalancer_id                = azurerm_lb.internal.id
-  frontend_port                  = 22623
+  frontend_port                  = var.mcs_port
   backend_port                   = 22623
   frontend_ip_configuration_name = local.internal_lb_frontend_ip_v6_configuration_name
   enable_floating_ip             = false
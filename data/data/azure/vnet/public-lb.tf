@@ -13,6 +13,7 @@ resource "azurerm_public_ip" "cluster_public_ip_v4" {
   resource_group_name = var.resource_group_name
   allocation_method   = "Static"
   domain_name_label   = var.dns_label
+  public_ip_prefix_id = var.api_public_ip_prefix_id == "" ? null : var.api_public_ip_prefix_id
 }
 
 data "azurerm_public_ip" "cluster_public_ip_v4" {
@@ -94,7 +95,7 @@ resource "azurerm_lb_rule" "public_lb_rule_api_internal_v4" {
   protocol                       = "Tcp"
   backend_address_pool_id        = azurerm_lb_backend_address_pool.master_public_lb_pool_v4[0].id
   loadbalancer_id                = azurerm_lb.public.id
-  frontend_port                  = 6443
+  frontend_port                  = var.api_external_port
   backend_port                   = 6443
   frontend_ip_configuration_name = local.public_lb_frontend_ip_v4_configuration_name
   enable_floating_ip             = false
@@ -111,7 +112,7 @@ resource "azurerm_lb_rule" "public_lb_rule_api_internal_v6" {
   protocol                       = "Tcp"
   backend_address_pool_id        = azurerm_lb_backend_address_pool.master_public_lb_pool_v6[0].id
   loadbalancer_id                = azurerm_lb.public.id
-  frontend_port                  = 6443
+  frontend_port                  = var.api_external_port
   backend_port                   = 6443
   frontend_ip_configuration_name = local.public_lb_frontend_ip_v6_configuration_name
   enable_floating_ip             = false
@@ -76,3 +76,21 @@ variable "emulate_single_stack_ipv6" {
   type        = bool
   description = "This determines whether a dual-stack cluster is configured to emulate single-stack IPv6."
 }
+
+variable "api_public_ip_prefix_id" {
+  type        = string
+  default     = ""
+  description = "The ID of an existing public IP prefix from which the API load balancer's public IP is allocated."
+}
+
+variable "mcs_port" {
+  type        = string
+  default     = "22623"
+  description = "The port the machine config server listens on."
+}
+
+variable "api_external_port" {
+  type        = string
+  default     = "6443"
+  description = "The port the Kubernetes API is published on at the external load balancer."
+}
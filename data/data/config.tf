@@ -100,3 +100,25 @@ Should the cluster be created with ipv6 networking.
 EOF
 
 }
+
+variable "mcs_port" {
+  type    = string
+  default = "22623"
+
+  description = <<EOF
+The port the machine config server listens on. Ignition configs are fetched
+from this port, so it must match the port in the pointer ignition configs.
+EOF
+
+}
+
+variable "api_external_port" {
+  type    = string
+  default = "6443"
+
+  description = <<EOF
+The port the Kubernetes API is published on at the external load balancer.
+The nodes keep listening on 6443; the load balancer maps this port to it.
+EOF
+
+}
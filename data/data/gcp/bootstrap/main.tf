@@ -1,5 +1,6 @@
 resource "google_storage_bucket" "ignition" {
-  name = "${var.cluster_id}-bootstrap-ignition"
+  name   = "${var.cluster_id}-bootstrap-ignition"
+  labels = var.labels
 }
 
 resource "google_storage_bucket_object" "ignition" {
@@ -76,6 +77,15 @@ resource "google_compute_instance" "bootstrap" {
 
   labels = var.labels
 
+  dynamic "shielded_instance_config" {
+    for_each = var.secure_boot || var.vtpm || var.integrity_monitoring ? [1] : []
+    content {
+      enable_secure_boot          = var.secure_boot
+      enable_vtpm                 = var.vtpm
+      enable_integrity_monitoring = var.integrity_monitoring
+    }
+  }
+
   lifecycle {
     # In GCP TF apply is run a second time to remove bootstrap node from LB.
     # If machine_type = n2-standard series, install will error as TF tries to
@@ -92,7 +102,7 @@ resource "google_compute_instance_group" "bootstrap" {
 
   named_port {
     name = "ignition"
-    port = "22623"
+    port = var.mcs_port
   }
 
   named_port {
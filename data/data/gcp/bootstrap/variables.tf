@@ -63,3 +63,28 @@ variable "zone" {
   type        = string
   description = "The zone for the bootstrap node."
 }
+
+variable "mcs_port" {
+  type        = string
+  default     = "22623"
+  description = "The port the machine config server listens on."
+}
+
+variable "secure_boot" {
+  type        = bool
+  default     = false
+  description = "Enable Shielded VM secure boot."
+}
+
+variable "vtpm" {
+  type        = bool
+  default     = false
+  description = "Enable the Shielded VM virtual trusted platform module."
+}
+
+variable "integrity_monitoring" {
+  type        = bool
+  default     = false
+  description = "Enable Shielded VM integrity monitoring."
+}
+
@@ -1,5 +1,10 @@
 locals {
-  labels = var.gcp_extra_labels
+  labels = merge(
+    {
+      "kubernetes-io-cluster-${var.cluster_id}" = "owned"
+    },
+    var.gcp_extra_labels,
+  )
 
   master_subnet_cidr = cidrsubnet(var.machine_cidr, 3, 0) #master subnet is a smaller subnet within the vnet. i.e from /21 to /24
   worker_subnet_cidr = cidrsubnet(var.machine_cidr, 3, 1) #worker subnet is a smaller subnet within the vnet. i.e from /21 to /24
@@ -15,6 +20,12 @@ provider "google" {
 module "bootstrap" {
   source = "./bootstrap"
 
+  mcs_port = var.mcs_port
+
+  secure_boot          = var.gcp_secure_boot
+  vtpm                 = var.gcp_vtpm
+  integrity_monitoring = var.gcp_integrity_monitoring
+
   bootstrap_enabled = var.gcp_bootstrap_enabled
 
   image            = google_compute_image.cluster.self_link
@@ -36,6 +47,14 @@ module "bootstrap" {
 module "master" {
   source = "./master"
 
+  mcs_port = var.mcs_port
+
+  secure_boot          = var.gcp_secure_boot
+  vtpm                 = var.gcp_vtpm
+  integrity_monitoring = var.gcp_integrity_monitoring
+
+  node_group = var.gcp_master_node_group
+
   image          = google_compute_image.cluster.self_link
   instance_count = var.master_count
   machine_type   = var.gcp_master_instance_type
@@ -59,6 +78,8 @@ module "iam" {
 module "network" {
   source = "./network"
 
+  mcs_port = var.mcs_port
+
   cluster_id         = var.cluster_id
   master_subnet_cidr = local.master_subnet_cidr
   worker_subnet_cidr = local.worker_subnet_cidr
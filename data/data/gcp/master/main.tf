@@ -55,6 +55,28 @@ resource "google_compute_instance" "master" {
 
   labels = var.labels
 
+  dynamic "shielded_instance_config" {
+    for_each = var.secure_boot || var.vtpm || var.integrity_monitoring ? [1] : []
+    content {
+      enable_secure_boot          = var.secure_boot
+      enable_vtpm                 = var.vtpm
+      enable_integrity_monitoring = var.integrity_monitoring
+    }
+  }
+
+
+  dynamic "scheduling" {
+    for_each = var.node_group == "" ? [] : [1]
+    content {
+      on_host_maintenance = "TERMINATE"
+      node_affinities {
+        key      = "compute.googleapis.com/node-group-name"
+        operator = "IN"
+        values   = [var.node_group]
+      }
+    }
+  }
+
   service_account {
     email  = google_service_account.master-node-sa.email
     scopes = ["https://www.googleapis.com/auth/cloud-platform"]
@@ -77,7 +99,7 @@ resource "google_compute_instance_group" "master" {
 
   named_port {
     name = "ignition"
-    port = "22623"
+    port = var.mcs_port
   }
 
   named_port {
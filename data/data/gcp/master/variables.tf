@@ -47,3 +47,34 @@ variable "root_volume_type" {
 variable "zones" {
   type = list
 }
+
+variable "mcs_port" {
+  type        = string
+  default     = "22623"
+  description = "The port the machine config server listens on."
+}
+
+variable "node_group" {
+  type        = string
+  default     = ""
+  description = "Name of the sole-tenant node group to pin the control plane to."
+}
+
+variable "secure_boot" {
+  type        = bool
+  default     = false
+  description = "Enable Shielded VM secure boot."
+}
+
+variable "vtpm" {
+  type        = bool
+  default     = false
+  description = "Enable the Shielded VM virtual trusted platform module."
+}
+
+variable "integrity_monitoring" {
+  type        = bool
+  default     = false
+  description = "Enable Shielded VM integrity monitoring."
+}
+
@@ -59,7 +59,7 @@ resource "google_compute_firewall" "control_plane" {
   # MCS
   allow {
     protocol = "tcp"
-    ports    = ["22623"]
+    ports    = [var.mcs_port]
   }
 
   source_tags = [
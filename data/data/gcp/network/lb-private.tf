@@ -36,7 +36,7 @@ resource "google_compute_forwarding_rule" "api_internal" {
 
   ip_address      = google_compute_address.cluster_ip.address
   backend_service = google_compute_region_backend_service.api_internal.self_link
-  ports           = ["6443", "22623"]
+  ports           = ["6443", var.mcs_port]
   subnetwork      = local.master_subnet
   network         = local.cluster_network
 
@@ -29,5 +29,8 @@ resource "google_compute_forwarding_rule" "api" {
 
   ip_address = google_compute_address.cluster_public_ip[0].address
   target     = google_compute_target_pool.api[0].self_link
+  # Forwarding rules pass the port straight through to the instances,
+  # so the external API cannot move off the port the apiserver
+  # listens on; a custom apiExternalPort is rejected at validation.
   port_range = "6443"
 }
@@ -12,6 +12,10 @@ resource "google_compute_subnetwork" "worker_subnet" {
   name          = var.worker_subnet
   network       = google_compute_network.cluster_network[0].self_link
   ip_cidr_range = var.worker_subnet_cidr
+
+  # Needed so private clusters can reach Google APIs (e.g. the image
+  # registry mirrors on GCS) without external addresses.
+  private_ip_google_access = true
 }
 
 resource "google_compute_subnetwork" "master_subnet" {
@@ -20,6 +24,8 @@ resource "google_compute_subnetwork" "master_subnet" {
   name          = var.master_subnet
   network       = google_compute_network.cluster_network[0].self_link
   ip_cidr_range = var.master_subnet_cidr
+
+  private_ip_google_access = true
 }
 
 resource "google_compute_router" "router" {
@@ -62,3 +62,10 @@ variable "public_endpoints" {
   type        = bool
   description = "If the bootstrap instance should have externally accessible resources."
 }
+
+variable "mcs_port" {
+  type        = string
+  default     = "22623"
+  description = "The port the machine config server listens on."
+}
+
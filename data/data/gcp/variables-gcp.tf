@@ -97,3 +97,28 @@ variable "gcp_publish_strategy" {
   type = string
   description = "The cluster publishing strategy, either Internal or External"
 }
+
+variable "gcp_master_node_group" {
+  type        = string
+  default     = ""
+  description = "Name of the sole-tenant node group to pin the control plane to."
+}
+
+variable "gcp_secure_boot" {
+  type        = bool
+  default     = false
+  description = "Enable Shielded VM secure boot on the control plane and bootstrap instances."
+}
+
+variable "gcp_vtpm" {
+  type        = bool
+  default     = false
+  description = "Enable the Shielded VM virtual trusted platform module on the control plane and bootstrap instances."
+}
+
+variable "gcp_integrity_monitoring" {
+  type        = bool
+  default     = false
+  description = "Enable Shielded VM integrity monitoring on the control plane and bootstrap instances."
+}
+
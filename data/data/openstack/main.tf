@@ -43,6 +43,8 @@ module "bootstrap" {
 module "masters" {
   source = "./masters"
 
+  config_drive = var.openstack_master_config_drive
+
   base_image_id   = data.openstack_images_image_v2.base_image.id
   cluster_id      = var.cluster_id
   flavor_name     = var.openstack_master_flavor_name
@@ -60,6 +62,8 @@ module "masters" {
 module "topology" {
   source = "./topology"
 
+  mcs_port            = var.mcs_port
+  machines_subnet     = var.openstack_machines_subnet
   cidr_block          = var.machine_cidr
   cluster_id          = var.cluster_id
   cluster_domain      = var.cluster_domain
@@ -47,6 +47,7 @@ resource "openstack_compute_instance_v2" "master_conf" {
     data.ignition_config.master_ignition_config.*.rendered,
     count.index,
   )
+  config_drive = var.config_drive
 
   dynamic block_device {
     for_each = var.root_volume_size == null ? [] : [openstack_blockstorage_volume_v3.master_volume[count.index].id]
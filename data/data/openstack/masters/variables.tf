@@ -40,3 +40,9 @@ variable "root_volume_type" {
   type        = string
   description = "The type of volume for the root block device."
 }
+
+variable "config_drive" {
+  type        = bool
+  default     = false
+  description = "Deliver the ignition user data on a config drive; required for bare-metal flavors without a metadata service."
+}
@@ -3,6 +3,8 @@ locals {
 }
 
 data "openstack_networking_network_v2" "external_network" {
+  count = length(var.external_network) == 0 ? 0 : 1
+
   name       = var.external_network
   network_id = var.external_network_id
   external   = true
@@ -120,20 +122,26 @@ resource "openstack_networking_trunk_v2" "masters" {
 // bootstrapping to complete, but the OpenShift cluster itself should come up
 // as expected.
 resource "openstack_networking_floatingip_associate_v2" "api_fip" {
-  count       = length(var.lb_floating_ip) == 0 ? 0 : 1
+  count       = length(var.lb_floating_ip) == 0 || length(var.external_network) == 0 ? 0 : 1
   port_id     = openstack_networking_port_v2.api_port.id
   floating_ip = var.lb_floating_ip
 }
 
+// Provider-network installs (no external network) have no router and
+// no floating IPs: the machine subnet is directly routable.
 resource "openstack_networking_router_v2" "openshift-external-router" {
+  count = length(var.external_network) == 0 ? 0 : 1
+
   name                = "${var.cluster_id}-external-router"
   admin_state_up      = true
-  external_network_id = data.openstack_networking_network_v2.external_network.id
+  external_network_id = data.openstack_networking_network_v2.external_network[0].id
   tags                = ["openshiftClusterID=${var.cluster_id}"]
 }
 
 resource "openstack_networking_router_interface_v2" "nodes_router_interface" {
-  router_id = openstack_networking_router_v2.openshift-external-router.id
+  count = length(var.external_network) == 0 ? 0 : 1
+
+  router_id = openstack_networking_router_v2.openshift-external-router[0].id
   subnet_id = openstack_networking_subnet_v2.nodes.id
 }
 
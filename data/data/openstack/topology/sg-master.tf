@@ -17,8 +17,8 @@ resource "openstack_networking_secgroup_rule_v2" "master_mcs" {
   direction         = "ingress"
   ethertype         = "IPv4"
   protocol          = "tcp"
-  port_range_min    = 22623
-  port_range_max    = 22623
+  port_range_min    = var.mcs_port
+  port_range_max    = var.mcs_port
   remote_ip_prefix  = var.cidr_block
   security_group_id = openstack_networking_secgroup_v2.master.id
 }
@@ -56,3 +56,15 @@ variable "trunk_support" {
 variable "octavia_support" {
   type = string
 }
+
+variable "mcs_port" {
+  type        = string
+  default     = "22623"
+  description = "The port the machine config server listens on."
+}
+
+variable "machines_subnet" {
+  type        = string
+  default     = ""
+  description = "ID of an existing (provider network) subnet the machines attach to instead of an installer-created network."
+}
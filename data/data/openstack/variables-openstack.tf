@@ -329,3 +329,21 @@ Contains 0 if the OpenStack Octavia endpoint is missing and 1 if it exists.
 EOF
 
 }
+
+variable "openstack_master_config_drive" {
+  type        = bool
+  default     = false
+  description = "Deliver the control-plane ignition on a config drive; required for bare-metal flavors without a metadata service."
+}
+
+variable "openstack_base_image_sha256" {
+  type        = string
+  default     = ""
+  description = "The sha256 digest of the uncompressed base image, for verifying terraform-side transfers."
+}
+
+variable "openstack_machines_subnet" {
+  type        = string
+  default     = ""
+  description = "ID of an existing (provider network) subnet the machines attach to instead of an installer-created network."
+}
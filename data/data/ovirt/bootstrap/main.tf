@@ -1,3 +1,12 @@
+// The bootstrap VM carries its own tag so the by-tag destroyer can
+// find it if the cluster is destroyed before bootstrap teardown; the
+// tag lives in this module so destroy bootstrap removes it together
+// with the VM without touching the cluster-wide tag.
+resource "ovirt_tag" "bootstrap_tag" {
+  name   = "${var.cluster_id}-bootstrap"
+  vm_ids = [ovirt_vm.bootstrap.id]
+}
+
 resource "ovirt_vm" "bootstrap" {
   name        = "${var.cluster_id}-bootstrap"
   memory      = "8192"
@@ -12,6 +12,13 @@ resource "ovirt_vm" "master" {
   }
 }
 
+// Everything destroy needs to find again is tagged with the cluster
+// id; the destroyer enumerates by this tag instead of guessing at
+// name prefixes. The provider can only attach tags to VMs, so disks
+// are cleaned up through their VMs and the template by name. The tag
+// must not depend on the bootstrap module: terraform destroy
+// -target=module.bootstrap would otherwise take the tag (and every
+// master's membership) down with it.
 resource "ovirt_tag" "cluster_tag" {
   name   = var.cluster_id
   vm_ids = [for instance in ovirt_vm.master.* : instance.id]
@@ -70,3 +70,9 @@ variable "ovirt_template_disk_size_gib" {
   type    = number
   default = 25
 }
+
+variable "ovirt_base_image_sha256" {
+  type        = string
+  default     = ""
+  description = "The sha256 digest of the uncompressed base image, for verifying terraform-side transfers."
+}
@@ -47,6 +47,24 @@ resource "vsphere_tag" "tag" {
   description = "Added by openshift-install do not remove"
 }
 
+data "vsphere_resource_pool" "custom" {
+  count = var.vsphere_resource_pool == "" ? 0 : 1
+
+  name          = var.vsphere_resource_pool
+  datacenter_id = data.vsphere_datacenter.datacenter.id
+}
+
+locals {
+  resource_pool_id = var.vsphere_resource_pool == "" ? data.vsphere_compute_cluster.cluster.resource_pool_id : data.vsphere_resource_pool.custom[0].id
+}
+
+data "vsphere_network" "additional" {
+  count = length(var.vsphere_additional_networks)
+
+  name          = var.vsphere_additional_networks[count.index]
+  datacenter_id = data.vsphere_datacenter.datacenter.id
+}
+
 resource "vsphere_folder" "folder" {
   path          = var.vsphere_folder
   type          = "vm"
@@ -59,7 +77,7 @@ module "bootstrap" {
   source = "./bootstrap"
 
   ignition      = var.ignition_bootstrap
-  resource_pool = data.vsphere_compute_cluster.cluster.resource_pool_id
+  resource_pool = local.resource_pool_id
   datastore     = data.vsphere_datastore.datastore.id
   folder        = vsphere_folder.folder.path
   network       = data.vsphere_network.network.id
@@ -76,12 +94,14 @@ module "bootstrap" {
 module "master" {
   source = "./master"
 
+  additional_network_ids = data.vsphere_network.additional.*.id
+
   // limitation of baremetal-runtimecfg.  The hostname must be master
   name           = "master"
   instance_count = var.master_count
   ignition       = var.ignition_master
 
-  resource_pool = data.vsphere_compute_cluster.cluster.resource_pool_id
+  resource_pool = local.resource_pool_id
   datastore     = data.vsphere_datastore.datastore.id
   folder        = vsphere_folder.folder.path
   network       = data.vsphere_network.network.id
@@ -18,6 +18,13 @@ resource "vsphere_virtual_machine" "vm" {
     network_id = var.network
   }
 
+  dynamic "network_interface" {
+    for_each = var.additional_network_ids
+    content {
+      network_id = network_interface.value
+    }
+  }
+
   disk {
     label            = "disk0"
     size             = 120
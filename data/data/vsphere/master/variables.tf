@@ -74,3 +74,9 @@ variable "thin_disk" {
 variable "scrub_disk" {
   type = bool
 }
+
+variable "additional_network_ids" {
+  type        = list(string)
+  default     = []
+  description = "IDs of additional portgroups attached as extra NICs on the control plane VMs."
+}
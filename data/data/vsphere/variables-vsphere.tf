@@ -65,3 +65,15 @@ variable "vsphere_control_plane_num_cpus" {
 variable "vsphere_control_plane_cores_per_socket" {
   type = number
 }
+
+variable "vsphere_resource_pool" {
+  type        = string
+  default     = ""
+  description = "Absolute path of the resource pool for the control plane VMs; the compute cluster's root pool when empty."
+}
+
+variable "vsphere_additional_networks" {
+  type        = list(string)
+  default     = []
+  description = "Names of additional portgroups attached as extra NICs on the control plane VMs."
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
 	"github.com/sirupsen/logrus"
 
@@ -23,6 +24,7 @@ func Metadata(clusterID, infraID string, config *types.InstallConfig) *awstypes.
 		}, {
 			"openshiftClusterID": clusterID,
 		}},
+		UserProvidedNetwork: len(config.Platform.AWS.Subnets) > 0,
 	}
 }
 
@@ -74,3 +76,49 @@ func PreTerraform(ctx context.Context, clusterID string, installConfig *installc
 
 	return nil
 }
+
+// ApplyControlPlaneIMDS applies the control plane pool's IMDS hop
+// limit to the freshly created bootstrap and master instances. The
+// bundled terraform provider predates metadata_options, so the
+// tuning happens through the EC2 API once the instances exist.
+func ApplyControlPlaneIMDS(ctx context.Context, infraID string, installConfig *installconfig.InstallConfig, hopLimit int64) error {
+	session, err := installConfig.AWS.Session(ctx)
+	if err != nil {
+		return err
+	}
+	client := ec2.New(session, aws.NewConfig().WithRegion(installConfig.Config.Platform.AWS.Region))
+
+	var instanceIDs []*string
+	if err := client.DescribeInstancesPagesWithContext(ctx, &ec2.DescribeInstancesInput{
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String("tag-key"),
+				Values: []*string{aws.String(fmt.Sprintf("kubernetes.io/cluster/%s", infraID))},
+			},
+			{
+				Name:   aws.String("instance-state-name"),
+				Values: []*string{aws.String("pending"), aws.String("running")},
+			},
+		},
+	}, func(page *ec2.DescribeInstancesOutput, lastPage bool) bool {
+		for _, reservation := range page.Reservations {
+			for _, instance := range reservation.Instances {
+				instanceIDs = append(instanceIDs, instance.InstanceId)
+			}
+		}
+		return !lastPage
+	}); err != nil {
+		return err
+	}
+
+	for _, instanceID := range instanceIDs {
+		if _, err := client.ModifyInstanceMetadataOptionsWithContext(ctx, &ec2.ModifyInstanceMetadataOptionsInput{
+			InstanceId:              instanceID,
+			HttpPutResponseHopLimit: aws.Int64(hopLimit),
+		}); err != nil {
+			return err
+		}
+	}
+	logrus.Infof("Set the IMDS hop limit to %d on %d instance(s)", hopLimit, len(instanceIDs))
+	return nil
+}
@@ -0,0 +1,25 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/types"
+	awstypes "github.com/openshift/installer/pkg/types/aws"
+)
+
+func TestMetadataRecordsUserProvidedNetwork(t *testing.T) {
+	installerCreated := Metadata("uuid", "infra-id", &types.InstallConfig{
+		Platform: types.Platform{AWS: &awstypes.Platform{Region: "us-east-1"}},
+	})
+	assert.False(t, installerCreated.UserProvidedNetwork)
+
+	userProvided := Metadata("uuid", "infra-id", &types.InstallConfig{
+		Platform: types.Platform{AWS: &awstypes.Platform{
+			Region:  "us-east-1",
+			Subnets: []string{"subnet-1"},
+		}},
+	})
+	assert.True(t, userProvided.UserProvidedNetwork)
+}
@@ -9,6 +9,7 @@ import (
 // Metadata converts an install configuration to Azure metadata.
 func Metadata(config *types.InstallConfig) *azure.Metadata {
 	return &azure.Metadata{
+		ResourceGroupName: config.Platform.Azure.ResourceGroupName,
 		Region: config.Platform.Azure.Region,
 	}
 }
@@ -2,10 +2,12 @@ package cluster
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
@@ -13,10 +15,24 @@ import (
 	"github.com/openshift/installer/pkg/asset"
 	"github.com/openshift/installer/pkg/asset/cluster/aws"
 	"github.com/openshift/installer/pkg/asset/installconfig"
+	ovirtconfig "github.com/openshift/installer/pkg/asset/installconfig/ovirt"
 	"github.com/openshift/installer/pkg/asset/password"
+	installererrors "github.com/openshift/installer/pkg/errors"
 	"github.com/openshift/installer/pkg/terraform"
+	gatheraws "github.com/openshift/installer/pkg/terraform/gather/aws"
+	"github.com/openshift/installer/pkg/types"
 )
 
+// ResumeStatePath points at a terraform state from an interrupted
+// apply. When set, the cluster asset seeds terraform with it and
+// re-applies idempotently instead of refusing to run; set from the
+// create cluster command only when --resume is given, since
+// re-applying against a healthy cluster would recreate resources -
+// like the bootstrap module - that were removed from the state on
+// purpose. This tree drives a single terraform stage, so resuming
+// means re-applying that stage against the recovered state.
+var ResumeStatePath string
+
 // Cluster uses the terraform executable to launch a cluster
 // with the given terraform tfvar and generated templates.
 type Cluster struct {
@@ -44,6 +60,7 @@ func (c *Cluster) Dependencies() []asset.Asset {
 		&installconfig.PlatformPermsCheck{},
 		&TerraformVariables{},
 		&password.KubeadminPassword{},
+		&Metadata{},
 	}
 }
 
@@ -52,7 +69,8 @@ func (c *Cluster) Generate(parents asset.Parents) (err error) {
 	clusterID := &installconfig.ClusterID{}
 	installConfig := &installconfig.InstallConfig{}
 	terraformVariables := &TerraformVariables{}
-	parents.Get(clusterID, installConfig, terraformVariables)
+	metadata := &Metadata{}
+	parents.Get(clusterID, installConfig, terraformVariables, metadata)
 
 	if installConfig.Config.Platform.None != nil {
 		return errors.New("cluster cannot be created with platform set to 'none'")
@@ -73,6 +91,17 @@ func (c *Cluster) Generate(parents asset.Parents) (err error) {
 		extraArgs = append(extraArgs, fmt.Sprintf("-var-file=%s", filepath.Join(tmpDir, file.Filename)))
 	}
 
+	if ResumeStatePath != "" {
+		stateData, err := ioutil.ReadFile(ResumeStatePath)
+		if err != nil {
+			return errors.Wrap(err, "failed to read the terraform state to resume from")
+		}
+		if err := ioutil.WriteFile(filepath.Join(tmpDir, terraform.StateFileName), stateData, 0600); err != nil {
+			return errors.Wrap(err, "failed to seed the terraform state")
+		}
+		logrus.Infof("Resuming from the existing terraform state; terraform will reconcile the partially-created infrastructure")
+	}
+
 	logrus.Infof("Creating infrastructure resources...")
 	if installConfig.Config.Platform.AWS != nil {
 		if err := aws.PreTerraform(context.TODO(), clusterID.InfraID, installConfig); err != nil {
@@ -82,7 +111,7 @@ func (c *Cluster) Generate(parents asset.Parents) (err error) {
 
 	stateFile, err := terraform.Apply(tmpDir, installConfig.Config.Platform.Name(), extraArgs...)
 	if err != nil {
-		err = errors.Wrap(err, "failed to create cluster")
+		err = installererrors.ClassifyFromMessage(errors.Wrap(err, "failed to create cluster"))
 		if stateFile == "" {
 			return err
 		}
@@ -91,12 +120,51 @@ func (c *Cluster) Generate(parents asset.Parents) (err error) {
 		// the temporary directory.
 	}
 
+	if err == nil && installConfig.Config.Platform.AWS != nil && installConfig.Config.Bastion != nil {
+		// Record the bastion's address in metadata.json so gather and
+		// destroy flows have it without digging through the terraform
+		// state.
+		if tfstate, err2 := terraform.ReadState(stateFile); err2 == nil {
+			if ip := gatheraws.BastionIP(tfstate); ip != "" {
+				if updated, err2 := metadataWithBastion(metadata, ip); err2 != nil {
+					logrus.Warnf("Failed to record the bastion address in metadata.json: %v", err2)
+				} else {
+					c.FileList = append(c.FileList, updated)
+				}
+			}
+		}
+	}
+
+	if err == nil && installConfig.Config.Platform.AWS != nil {
+		if metadataService := awsMasterMetadataService(installConfig.Config); metadataService != nil && metadataService.HopLimit != 0 {
+			// The bundled terraform provider cannot express
+			// metadata_options, so the hop limit is applied through
+			// the EC2 API once the instances exist.
+			if err := aws.ApplyControlPlaneIMDS(context.TODO(), clusterID.InfraID, installConfig, metadataService.HopLimit); err != nil {
+				return errors.Wrap(err, "failed to apply the IMDS hop limit")
+			}
+		}
+	}
+
+	if err == nil && installConfig.Config.Platform.Ovirt != nil {
+		// Affinity group membership can only be set once the VMs exist.
+		if err := ovirtconfig.CreateAffinityGroups(installConfig.Config, clusterID.InfraID); err != nil {
+			return err
+		}
+	}
+
 	data, err2 := ioutil.ReadFile(stateFile)
 	if err2 == nil {
 		c.FileList = append(c.FileList, &asset.File{
 			Filename: terraform.StateFileName,
 			Data:     data,
 		})
+		// Snapshot the state so an interrupted later stage leaves
+		// something consistent to recover from.
+		c.FileList = append(c.FileList, &asset.File{
+			Filename: filepath.Join(terraform.BackupsDir, fmt.Sprintf("apply-%s.tfstate", time.Now().UTC().Format("20060102T150405Z"))),
+			Data:     data,
+		})
 	} else if err == nil {
 		err = err2
 	} else {
@@ -112,7 +180,9 @@ func (c *Cluster) Files() []*asset.File {
 }
 
 // Load returns error if the tfstate file is already on-disk, because we want to
-// prevent user from accidentally re-launching the cluster.
+// prevent user from accidentally re-launching the cluster. When a
+// resume was requested the existing state is expected and Generate
+// runs again against it.
 func (c *Cluster) Load(f asset.FileFetcher) (found bool, err error) {
 	_, err = f.FetchByName(terraform.StateFileName)
 	if err != nil {
@@ -122,5 +192,30 @@ func (c *Cluster) Load(f asset.FileFetcher) (found bool, err error) {
 		return false, err
 	}
 
-	return true, errors.Errorf("%q already exists.  There may already be a running cluster", terraform.StateFileName)
+	if ResumeStatePath != "" {
+		return false, nil
+	}
+
+	return true, errors.Errorf("%q already exists.  There may already be a running cluster; if an earlier create cluster was interrupted, re-run with --resume to re-apply against the leftover state", terraform.StateFileName)
+}
+
+// metadataWithBastion returns a metadata.json asset file with the
+// bastion's public address recorded in the AWS section.
+func metadataWithBastion(metadata *Metadata, bastionIP string) (*asset.File, error) {
+	if metadata.File == nil {
+		return nil, errors.New("no metadata file to update")
+	}
+	clusterMetadata := &types.ClusterMetadata{}
+	if err := json.Unmarshal(metadata.File.Data, clusterMetadata); err != nil {
+		return nil, errors.Wrap(err, "failed to parse metadata.json")
+	}
+	if clusterMetadata.AWS == nil {
+		return nil, errors.New("metadata.json has no aws section")
+	}
+	clusterMetadata.AWS.BastionIP = bastionIP
+	data, err := json.Marshal(clusterMetadata)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal metadata.json")
+	}
+	return &asset.File{Filename: metadataFileName, Data: data}, nil
 }
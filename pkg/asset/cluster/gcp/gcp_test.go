@@ -0,0 +1,25 @@
+package gcp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/types"
+	gcptypes "github.com/openshift/installer/pkg/types/gcp"
+)
+
+func TestMetadataRecordsUserProvidedNetwork(t *testing.T) {
+	installerCreated := Metadata(&types.InstallConfig{
+		Platform: types.Platform{GCP: &gcptypes.Platform{Region: "us-east1"}},
+	})
+	assert.False(t, installerCreated.UserProvidedNetwork)
+
+	userProvided := Metadata(&types.InstallConfig{
+		Platform: types.Platform{GCP: &gcptypes.Platform{
+			Region:  "us-east1",
+			Network: "shared-vpc",
+		}},
+	})
+	assert.True(t, userProvided.UserProvidedNetwork)
+}
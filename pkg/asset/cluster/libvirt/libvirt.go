@@ -9,6 +9,7 @@ import (
 // Metadata converts an install configuration to libvirt metadata.
 func Metadata(config *types.InstallConfig) *libvirt.Metadata {
 	return &libvirt.Metadata{
+		AdditionalURIs: config.Platform.Libvirt.AdditionalURIs,
 		URI: config.Platform.Libvirt.URI,
 	}
 }
@@ -17,6 +17,8 @@ import (
 	"github.com/openshift/installer/pkg/asset/cluster/ovirt"
 	"github.com/openshift/installer/pkg/asset/cluster/vsphere"
 	"github.com/openshift/installer/pkg/asset/installconfig"
+	"github.com/openshift/installer/pkg/asset/releaseimage"
+	"github.com/openshift/installer/pkg/asset/rhcos"
 	"github.com/openshift/installer/pkg/types"
 	awstypes "github.com/openshift/installer/pkg/types/aws"
 	azuretypes "github.com/openshift/installer/pkg/types/azure"
@@ -51,6 +53,9 @@ func (m *Metadata) Dependencies() []asset.Asset {
 	return []asset.Asset{
 		&installconfig.ClusterID{},
 		&installconfig.InstallConfig{},
+		&releaseimage.Image{},
+		new(rhcos.Image),
+		new(rhcos.BootstrapImage),
 	}
 }
 
@@ -58,12 +63,29 @@ func (m *Metadata) Dependencies() []asset.Asset {
 func (m *Metadata) Generate(parents asset.Parents) (err error) {
 	clusterID := &installconfig.ClusterID{}
 	installConfig := &installconfig.InstallConfig{}
-	parents.Get(clusterID, installConfig)
+	releaseImage := &releaseimage.Image{}
+	rhcosImage := new(rhcos.Image)
+	rhcosBootstrapImage := new(rhcos.BootstrapImage)
+	parents.Get(clusterID, installConfig, releaseImage, rhcosImage, rhcosBootstrapImage)
+
+	provenance, err := clusterProvenance(installConfig, releaseImage, string(*rhcosImage), string(*rhcosBootstrapImage))
+	if err != nil {
+		return err
+	}
 
 	metadata := &types.ClusterMetadata{
 		ClusterName: installConfig.Config.ObjectMeta.Name,
 		ClusterID:   clusterID.UUID,
 		InfraID:     clusterID.InfraID,
+		Provenance:  provenance,
+		Publish:     installConfig.Config.Publish,
+	}
+
+	if installConfig.Config.IPAM != nil {
+		metadata.IPAM = &types.IPAMMetadata{
+			Config:        installConfig.Config.IPAM,
+			AllocationIDs: installConfig.IPAMAllocationIDs,
+		}
 	}
 
 	switch installConfig.Config.Platform.Name() {
@@ -80,7 +102,7 @@ func (m *Metadata) Generate(parents asset.Parents) (err error) {
 	case baremetaltypes.Name:
 		metadata.ClusterPlatformMetadata.BareMetal = baremetal.Metadata(installConfig.Config)
 	case ovirttypes.Name:
-		metadata.ClusterPlatformMetadata.Ovirt = ovirt.Metadata(installConfig.Config)
+		metadata.ClusterPlatformMetadata.Ovirt = ovirt.Metadata(installConfig.Config, string(*rhcosImage))
 	case vspheretypes.Name:
 		metadata.ClusterPlatformMetadata.VSphere = vsphere.Metadata(installConfig.Config)
 	case nonetypes.Name:
@@ -9,13 +9,22 @@ import (
 )
 
 // Metadata converts an install configuration to ovirt metadata.
-func Metadata(config *types.InstallConfig) *ovirt.Metadata {
+// baseImage is the resolved template source; it names the template
+// and its uploaded disk image when the installer created them.
+func Metadata(config *types.InstallConfig, baseImage string) *ovirt.Metadata {
 	customImage, ok := os.LookupEnv("OPENSHIFT_INSTALL_OS_IMAGE_OVERRIDE")
 	m := ovirt.Metadata{
 		ClusterID: config.Ovirt.ClusterID,
 		// if we have a custom image, don't remove the template, otherwise its
 		// a per deployment template, destroy it
 		RemoveTemplate: ok && customImage != "",
+		BaseImageName:  baseImage,
+	}
+	if config.Ovirt.TemplateName != "" {
+		m.BaseImageName = config.Ovirt.TemplateName
+	}
+	for _, group := range config.Ovirt.AffinityGroups {
+		m.AffinityGroupNames = append(m.AffinityGroupNames, group.Name)
 	}
 	return &m
 }
@@ -0,0 +1,131 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	"github.com/openshift/installer/pkg/asset/releaseimage"
+	"github.com/openshift/installer/pkg/asset/rhcos"
+	rhcosutils "github.com/openshift/installer/pkg/rhcos"
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/version"
+)
+
+const (
+	provenanceFileName = "provenance.json"
+)
+
+// Provenance generates the provenance.json file, an audit record of the
+// exact release image, RHCOS artifacts and installer binary that
+// produced the cluster.
+type Provenance struct {
+	File *asset.File
+}
+
+var _ asset.WritableAsset = (*Provenance)(nil)
+
+// Name returns the human-friendly name of the asset.
+func (p *Provenance) Name() string {
+	return "Provenance"
+}
+
+// Dependencies returns the direct dependencies for the provenance
+// asset.
+func (p *Provenance) Dependencies() []asset.Asset {
+	return []asset.Asset{
+		&installconfig.InstallConfig{},
+		&releaseimage.Image{},
+		new(rhcos.Image),
+		new(rhcos.BootstrapImage),
+	}
+}
+
+// Generate generates the provenance asset.
+func (p *Provenance) Generate(parents asset.Parents) error {
+	installConfig := &installconfig.InstallConfig{}
+	releaseImage := &releaseimage.Image{}
+	rhcosImage := new(rhcos.Image)
+	rhcosBootstrapImage := new(rhcos.BootstrapImage)
+	parents.Get(installConfig, releaseImage, rhcosImage, rhcosBootstrapImage)
+
+	provenance, err := clusterProvenance(installConfig, releaseImage, string(*rhcosImage), string(*rhcosBootstrapImage))
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(provenance, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to Marshal ClusterProvenance")
+	}
+
+	p.File = &asset.File{
+		Filename: provenanceFileName,
+		Data:     data,
+	}
+
+	return nil
+}
+
+// Files returns the provenance file generated by the asset.
+func (p *Provenance) Files() []*asset.File {
+	if p.File != nil {
+		return []*asset.File{p.File}
+	}
+	return []*asset.File{}
+}
+
+// Load is a no-op, because the provenance is always regenerated from
+// the resolved assets.
+func (p *Provenance) Load(f asset.FileFetcher) (found bool, err error) {
+	return false, nil
+}
+
+// clusterProvenance assembles the provenance record from the resolved
+// release image, the RHCOS image assets and the installer's own build
+// information.
+func clusterProvenance(installConfig *installconfig.InstallConfig, releaseImage *releaseimage.Image, osImage string, bootstrapOSImage string) (*types.ClusterProvenance, error) {
+	installerVersion, err := version.Version()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to determine installer version")
+	}
+
+	// Record the digest the pull spec actually resolved to, so an
+	// OPENSHIFT_INSTALL_RELEASE_IMAGE_OVERRIDE pointing at a movable
+	// tag still yields a reproducible record. Without oc on the host
+	// the record documents the unresolved spec as given.
+	resolvedImage, err := releaseimage.Digest(releaseImage.PullSpec)
+	if err != nil {
+		logrus.Warnf("Provenance records the release image as given; the digest could not be resolved: %v", err)
+		resolvedImage = releaseImage.PullSpec
+	}
+
+	provenance := &types.ClusterProvenance{
+		InstallerVersion: installerVersion,
+		InstallerCommit:  version.Commit,
+		ReleaseImage:     resolvedImage,
+		OSImage:          osImage,
+	}
+	if bootstrapOSImage != osImage {
+		provenance.BootstrapOSImage = bootstrapOSImage
+	}
+
+	ctx, cancel := context.WithTimeout(context.TODO(), 30*time.Second)
+	defer cancel()
+	rhcosVersion, artifacts, err := rhcosutils.Artifacts(ctx, installConfig.Config.ControlPlane.Architecture)
+	if err != nil {
+		// The embedded build metadata does not cover every architecture;
+		// the record is still useful without the RHCOS artifact digests.
+		logrus.Debugf("No RHCOS artifacts recorded in provenance: %v", err)
+		return provenance, nil
+	}
+	provenance.RHCOSVersion = rhcosVersion
+	provenance.RHCOSArtifacts = artifacts
+
+	return provenance, nil
+}
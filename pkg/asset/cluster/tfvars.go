@@ -137,6 +137,8 @@ func (t *TerraformVariables) Generate(parents asset.Parents) error {
 		bootstrapIgn,
 		masterIgn,
 		masterCount,
+		installConfig.Config.MCSPort(),
+		installConfig.Config.APIPort(),
 	)
 	if err != nil {
 		return errors.Wrap(err, "failed to get Terraform variables")
@@ -199,7 +201,18 @@ func (t *TerraformVariables) Generate(parents asset.Parents) error {
 		for i, m := range workers {
 			workerConfigs[i] = m.Spec.Template.Spec.ProviderSpec.Value.Object.(*awsprovider.AWSMachineProviderConfig)
 		}
-		data, err := awstfvars.TFVars(vpc, privateSubnets, publicSubnets, installConfig.Config.Publish, masterConfigs, workerConfigs)
+		var extraMachineCIDRs []string
+		for _, pool := range installConfig.Config.Compute {
+			if pool.Platform.AWS != nil {
+				extraMachineCIDRs = append(extraMachineCIDRs, pool.Platform.AWS.Networks...)
+			}
+		}
+		var placementGroup, placementStrategy string
+		if cp := installConfig.Config.ControlPlane; cp != nil && cp.Platform.AWS != nil && cp.Platform.AWS.Placement != nil {
+			placementGroup = cp.Platform.AWS.Placement.GroupName
+			placementStrategy = cp.Platform.AWS.Placement.Strategy
+		}
+		data, err := awstfvars.TFVars(vpc, privateSubnets, publicSubnets, installConfig.Config.Publish, masterConfigs, workerConfigs, extraMachineCIDRs, placementGroup, placementStrategy, installConfig.Config.Platform.AWS.IAMPermissionsBoundary, installConfig.Config.Platform.AWS.IAMTags, installConfig.Config.Bastion, installConfig.Config.SSHKey, installConfig.Config.Platform.AWS.UserProvisionedDNS == "Enabled")
 		if err != nil {
 			return errors.Wrapf(err, "failed to get %s Terraform variables", platform)
 		}
@@ -257,6 +270,11 @@ func (t *TerraformVariables) Generate(parents asset.Parents) error {
 				ImageURL:                    string(*rhcosImage),
 				PreexistingNetwork:          preexistingnetwork,
 				Publish:                     installConfig.Config.Publish,
+				APIPublicIPPrefixID:         installConfig.Config.Azure.APIPublicIPPrefixID,
+				ImagePlanName:               azureImagePlanName(installConfig.Config.Azure),
+				CloudName:                   installConfig.Config.Azure.CloudName,
+				UserAssignedIdentityID:      azureUserAssignedIdentityID(installConfig.Config.Azure),
+				ResourceGroupName:           installConfig.Config.Azure.ResourceGroupName,
 				MachineV4CIDRs:              machineV4CIDRs,
 				MachineV6CIDRs:              machineV6CIDRs,
 			},
@@ -309,6 +327,9 @@ func (t *TerraformVariables) Generate(parents asset.Parents) error {
 				PublicZoneName:     publicZoneName,
 				PublishStrategy:    installConfig.Config.Publish,
 				PreexistingNetwork: preexistingnetwork,
+				MasterNodeGroup:    gcpMasterNodeGroup(installConfig.Config),
+				ControlPlanePool:   gcpControlPlanePool(installConfig.Config),
+				UserLabels:         installConfig.Config.Platform.GCP.UserLabels,
 			},
 		)
 		if err != nil {
@@ -368,9 +389,23 @@ func (t *TerraformVariables) Generate(parents asset.Parents) error {
 		if err != nil {
 			return err
 		}
+		masterSpec := masters[0].Spec.ProviderSpec.Value.Object.(*openstackprovider.OpenstackProviderSpec)
+		baremetalFlavor, err := openstackconfig.IsBareMetalFlavor(installConfig.Config.Platform.OpenStack.Cloud, masterSpec.Flavor)
+		if err != nil {
+			logrus.Warnf("Unable to inspect the extra specs of flavor %q: %v", masterSpec.Flavor, err)
+		}
+		if baremetalFlavor {
+			// Ironic nodes have no metadata service; the ignition has to
+			// travel on a config drive.
+			logrus.Infof("Flavor %q is a bare-metal flavor: enabling config-drive for the control plane", masterSpec.Flavor)
+			if masterSpec.RootVolume != nil {
+				return errors.Errorf("the bare-metal flavor %q boots from local disk; remove the control plane rootVolume", masterSpec.Flavor)
+			}
+		}
 		data, err = openstacktfvars.TFVars(
-			masters[0].Spec.ProviderSpec.Value.Object.(*openstackprovider.OpenstackProviderSpec),
+			masterSpec,
 			installConfig.Config.Platform.OpenStack.Cloud,
+			openstackMasterExtraSGIDs(installConfig.Config),
 			installConfig.Config.Platform.OpenStack.ExternalNetwork,
 			installConfig.Config.Platform.OpenStack.ExternalDNS,
 			installConfig.Config.Platform.OpenStack.LbFloatingIP,
@@ -383,6 +418,8 @@ func (t *TerraformVariables) Generate(parents asset.Parents) error {
 			clusterID.InfraID,
 			caCert,
 			bootstrapIgn,
+			baremetalFlavor,
+			installConfig.Config.Platform.OpenStack.MachinesSubnet,
 		)
 		if err != nil {
 			return errors.Wrapf(err, "failed to get %s Terraform variables", platform)
@@ -422,7 +459,7 @@ func (t *TerraformVariables) Generate(parents asset.Parents) error {
 			installConfig.Config.Platform.Ovirt.ClusterID,
 			installConfig.Config.Platform.Ovirt.StorageDomainID,
 			installConfig.Config.Platform.Ovirt.NetworkName,
-			string(*rhcosImage),
+			ovirtBaseImage(installConfig.Config.Platform.Ovirt, string(*rhcosImage)),
 			clusterID.InfraID,
 		)
 		if err != nil {
@@ -488,6 +525,74 @@ func (t *TerraformVariables) Load(f asset.FileFetcher) (found bool, err error) {
 	return true, nil
 }
 
+// openstackMasterExtraSGIDs returns the additional security groups
+// the control plane pool pins, for the terraform ports.
+func openstackMasterExtraSGIDs(config *types.InstallConfig) []string {
+	if config.ControlPlane != nil && config.ControlPlane.Platform.OpenStack != nil {
+		return config.ControlPlane.Platform.OpenStack.AdditionalSecurityGroupIDs
+	}
+	return nil
+}
+
+// ovirtBaseImage picks the template source: a user-named existing
+// template wins over the resolved RHCOS image URL.
+func ovirtBaseImage(platform *ovirt.Platform, resolvedImage string) string {
+	if platform.TemplateName != "" {
+		return platform.TemplateName
+	}
+	return resolvedImage
+}
+
+// gcpControlPlanePool returns the control plane's GCP machine pool
+// settings, if any.
+func gcpControlPlanePool(config *types.InstallConfig) *gcp.MachinePool {
+	if config.ControlPlane != nil {
+		return config.ControlPlane.Platform.GCP
+	}
+	return nil
+}
+
+// gcpMasterNodeGroup returns the sole-tenant node group the control
+// plane is pinned to, if any.
+func gcpMasterNodeGroup(config *types.InstallConfig) string {
+	if config.ControlPlane != nil && config.ControlPlane.Platform.GCP != nil && config.ControlPlane.Platform.GCP.SoleTenancy != nil {
+		return config.ControlPlane.Platform.GCP.SoleTenancy.NodeGroup
+	}
+	return ""
+}
+
+// awsMasterMetadataService returns the control plane pool's IMDS
+// tuning with the same precedence the machine assets use: the pool's
+// own section overrides platform.aws.defaultMachinePlatform.
+func awsMasterMetadataService(config *types.InstallConfig) *aws.MetadataService {
+	var metadataService *aws.MetadataService
+	if config.Platform.AWS.DefaultMachinePlatform != nil {
+		metadataService = config.Platform.AWS.DefaultMachinePlatform.MetadataService
+	}
+	if config.ControlPlane != nil && config.ControlPlane.Platform.AWS != nil && config.ControlPlane.Platform.AWS.MetadataService != nil {
+		metadataService = config.ControlPlane.Platform.AWS.MetadataService
+	}
+	return metadataService
+}
+
+// azureUserAssignedIdentityID returns the resource ID of the
+// user-provided managed identity, if any.
+func azureUserAssignedIdentityID(platform *azure.Platform) string {
+	if platform.Identity != nil {
+		return platform.Identity.UserAssignedIdentityID
+	}
+	return ""
+}
+
+// azureImagePlanName returns the purchase plan name when a third-party
+// marketplace image with a plan is configured.
+func azureImagePlanName(platform *azure.Platform) string {
+	if platform.DefaultMachinePlatform != nil && platform.DefaultMachinePlatform.OSImage != nil {
+		return platform.DefaultMachinePlatform.OSImage.Plan
+	}
+	return ""
+}
+
 // injectInstallInfo adds information about the installer and its invoker as a
 // ConfigMap to the provided bootstrap Ignition config.
 func injectInstallInfo(bootstrap []byte) (string, error) {
@@ -65,6 +65,11 @@ type platformTemplateData struct {
 type Bootstrap struct {
 	Config *igntypes.Config
 	File   *asset.File
+	// Inventory and DNSRecordsNeeded are exported so they survive the
+	// state-file round trip; the store would otherwise consider a
+	// reloaded Bootstrap dirty.
+	Inventory        []byte
+	DNSRecordsNeeded []byte
 }
 
 var _ asset.WritableAsset = (*Bootstrap)(nil)
@@ -72,6 +77,7 @@ var _ asset.WritableAsset = (*Bootstrap)(nil)
 // Dependencies returns the assets on which the Bootstrap asset depends.
 func (a *Bootstrap) Dependencies() []asset.Asset {
 	return []asset.Asset{
+		&installconfig.ClusterID{},
 		&installconfig.InstallConfig{},
 		&kubeconfig.AdminInternalClient{},
 		&kubeconfig.Kubelet{},
@@ -128,11 +134,12 @@ func (a *Bootstrap) Dependencies() []asset.Asset {
 
 // Generate generates the ignition config for the Bootstrap asset.
 func (a *Bootstrap) Generate(dependencies asset.Parents) error {
+	clusterID := &installconfig.ClusterID{}
 	installConfig := &installconfig.InstallConfig{}
 	proxy := &manifests.Proxy{}
 	releaseImage := &releaseimage.Image{}
 	rhcosImage := new(rhcos.Image)
-	dependencies.Get(installConfig, proxy, releaseImage, rhcosImage)
+	dependencies.Get(clusterID, installConfig, proxy, releaseImage, rhcosImage)
 
 	templateData, err := a.getTemplateData(installConfig.Config, releaseImage.PullSpec, installConfig.Config.ImageContentSources, proxy.Config, rhcosImage)
 
@@ -150,6 +157,41 @@ func (a *Bootstrap) Generate(dependencies asset.Parents) error {
 	if err != nil {
 		return err
 	}
+
+	if forwarders := dnsForwarders(installConfig.Config); len(forwarders) > 0 {
+		// The node-hosted CoreDNS and runtimecfg read the upstreams
+		// from this file instead of resolv.conf.
+		a.Config.Storage.Files = append(a.Config.Storage.Files,
+			ignition.FileFromString("/etc/kubernetes/coredns-forwarders", "root", 0644, strings.Join(forwarders, "\n")+"\n"))
+	}
+
+	if timeouts := installConfig.Config.ExperimentalBootstrapTimeouts; timeouts != nil {
+		// bootkube.sh sources this file when present; unset values keep
+		// the script's built-in defaults.
+		var builder strings.Builder
+		if timeouts.EtcdBootstrapSeconds != 0 {
+			fmt.Fprintf(&builder, "ETCD_BOOTSTRAP_TIMEOUT=%d\n", timeouts.EtcdBootstrapSeconds)
+		}
+		if timeouts.APIServerReadySeconds != 0 {
+			fmt.Fprintf(&builder, "API_SERVER_READY_TIMEOUT=%d\n", timeouts.APIServerReadySeconds)
+		}
+		if timeouts.CVOBootstrapSeconds != 0 {
+			fmt.Fprintf(&builder, "CVO_BOOTSTRAP_TIMEOUT=%d\n", timeouts.CVOBootstrapSeconds)
+		}
+		a.Config.Storage.Files = append(a.Config.Storage.Files,
+			ignition.FileFromString("/etc/kubernetes/bootstrap-timeouts.env", "root", 0644, builder.String()))
+	}
+
+	if proxyConfig := installConfig.Config.Proxy; proxyConfig != nil && proxyConfig.ClientCertificate != "" {
+		// Distribution point only: nothing in the release reads this
+		// path yet (validation warns about it); it is a stable,
+		// documented location for user-managed services that need to
+		// present the certificate to an mTLS egress proxy.
+		a.Config.Storage.Files = append(a.Config.Storage.Files,
+			ignition.FileFromString("/etc/pki/proxy-client/tls.crt", "root", 0600, proxyConfig.ClientCertificate),
+			ignition.FileFromString("/etc/pki/proxy-client/tls.key", "root", 0600, proxyConfig.ClientKey),
+		)
+	}
 	err = a.addSystemdUnits("bootstrap/systemd/units", templateData)
 	if err != nil {
 		return err
@@ -179,6 +221,42 @@ func (a *Bootstrap) Generate(dependencies asset.Parents) error {
 
 	a.addParentFiles(dependencies)
 
+	if aws := installConfig.Config.Platform.AWS; aws != nil && aws.UserProvisionedDNS == "Enabled" {
+		// The report lists the records a user-managed resolver must
+		// serve in place of the skipped private hosted zone.
+		report, err := json.MarshalIndent(map[string]interface{}{
+			"records": []map[string]string{
+				{"name": fmt.Sprintf("api-int.%s", installConfig.Config.ClusterDomain()), "type": "A", "target": "the internal API load balancer"},
+				{"name": fmt.Sprintf("api.%s", installConfig.Config.ClusterDomain()), "type": "A", "target": "the internal API load balancer (internal zone view)"},
+				{"name": fmt.Sprintf("etcd-<index>.%s", installConfig.Config.ClusterDomain()), "type": "A", "target": "each control plane machine"},
+				{"name": fmt.Sprintf("_etcd-server-ssl._tcp.%s", installConfig.Config.ClusterDomain()), "type": "SRV", "target": "0 10 2380 etcd-<index>"},
+			},
+		}, "", "  ")
+		if err != nil {
+			return err
+		}
+		a.DNSRecordsNeeded = report
+	}
+
+	// Record exactly which manifests made it into this ignition, so
+	// support can tell whether a user's extra manifest landed in the
+	// cluster. The inventory travels inside the ignition and next to
+	// it in the asset directory.
+	manifestsAsset := &manifests.Manifests{}
+	openshiftAsset := &manifests.Openshift{}
+	dependencies.Get(manifestsAsset, openshiftAsset)
+	allManifests := append(manifestsAsset.Files(), openshiftAsset.Files()...)
+	if err := manifests.ValidateUserManifests(allManifests, clusterID.InfraID, installConfig.Config.ClusterDomain()); err != nil {
+		return err
+	}
+	inventory, err := manifests.Inventory(allManifests)
+	if err != nil {
+		return errors.Wrap(err, "failed to build the manifest inventory")
+	}
+	a.Config.Storage.Files = replaceOrAppend(a.Config.Storage.Files,
+		ignition.FileFromBytes("/opt/openshift/manifest-inventory.json", "root", 0644, inventory))
+	a.Inventory = inventory
+
 	a.Config.Passwd.Users = append(
 		a.Config.Passwd.Users,
 		igntypes.PasswdUser{Name: "core", SSHAuthorizedKeys: []igntypes.SSHAuthorizedKey{igntypes.SSHAuthorizedKey(installConfig.Config.SSHKey)}},
@@ -196,6 +274,18 @@ func (a *Bootstrap) Generate(dependencies asset.Parents) error {
 	return nil
 }
 
+// inventoryFile returns the manifest inventory as an asset file, when
+// one was generated.
+func (a *Bootstrap) inventoryFile() *asset.File {
+	if len(a.Inventory) == 0 {
+		return nil
+	}
+	return &asset.File{
+		Filename: "manifest-inventory.json",
+		Data:     a.Inventory,
+	}
+}
+
 // Name returns the human-friendly name of the asset.
 func (a *Bootstrap) Name() string {
 	return "Bootstrap Ignition Config"
@@ -204,7 +294,14 @@ func (a *Bootstrap) Name() string {
 // Files returns the files generated by the asset.
 func (a *Bootstrap) Files() []*asset.File {
 	if a.File != nil {
-		return []*asset.File{a.File}
+		files := []*asset.File{a.File}
+		if inventory := a.inventoryFile(); inventory != nil {
+			files = append(files, inventory)
+		}
+		if a.DNSRecordsNeeded != nil {
+			files = append(files, &asset.File{Filename: "dns-records-needed.json", Data: a.DNSRecordsNeeded})
+		}
+		return files
 	}
 	return []*asset.File{}
 }
@@ -217,6 +314,25 @@ func (a *Bootstrap) getTemplateData(installConfig *types.InstallConfig, releaseI
 		etcdEndpoints[i] = fmt.Sprintf("https://etcd-%d.%s:2379", i, installConfig.ClusterDomain())
 	}
 
+	if installConfig.AdditionalTrustBundle != "" && len(imageSources) > 0 {
+		// A trust bundle plus mirrors usually means a disconnected
+		// install; the release image's registry has to be mirrored or
+		// the bootstrap cannot pull the payload.
+		releaseRegistry := releaseImage
+		if idx := strings.IndexAny(releaseRegistry, "/"); idx > 0 {
+			releaseRegistry = releaseRegistry[:idx]
+		}
+		mirrored := false
+		for _, group := range mergedMirrorSets(imageSources) {
+			if strings.HasPrefix(group.Source, releaseRegistry) {
+				mirrored = true
+			}
+		}
+		if !mirrored {
+			logrus.Warnf("The release image registry %s does not appear in any imageContentSources mirror; a disconnected bootstrap will not be able to pull the payload", releaseRegistry)
+		}
+	}
+
 	registries := []sysregistriesv2.Registry{}
 	for _, group := range mergedMirrorSets(imageSources) {
 		if len(group.Mirrors) == 0 {
@@ -225,7 +341,7 @@ func (a *Bootstrap) getTemplateData(installConfig *types.InstallConfig, releaseI
 
 		registry := sysregistriesv2.Registry{}
 		registry.Endpoint.Location = group.Source
-		registry.MirrorByDigestOnly = true
+		registry.MirrorByDigestOnly = !installConfig.AllowMirrorByTags
 		for _, mirror := range group.Mirrors {
 			registry.Mirrors = append(registry.Mirrors, sysregistriesv2.Endpoint{Location: mirror})
 		}
@@ -545,5 +661,33 @@ func (a *Bootstrap) Load(f asset.FileFetcher) (found bool, err error) {
 	}
 
 	a.File, a.Config = file, config
+
+	// The sidecar reports are optional; reload them when present so a
+	// reloaded Bootstrap compares equal to a generated one.
+	if inventory, err := f.FetchByName("manifest-inventory.json"); err == nil {
+		a.Inventory = inventory.Data
+	} else if !os.IsNotExist(err) {
+		return false, err
+	}
+	if records, err := f.FetchByName("dns-records-needed.json"); err == nil {
+		a.DNSRecordsNeeded = records.Data
+	} else if !os.IsNotExist(err) {
+		return false, err
+	}
+
 	return true, nil
 }
+
+// dnsForwarders returns the on-prem platform's CoreDNS upstreams, if
+// any.
+func dnsForwarders(config *types.InstallConfig) []string {
+	switch {
+	case config.Platform.BareMetal != nil:
+		return config.Platform.BareMetal.DNSForwarders
+	case config.Platform.VSphere != nil:
+		return config.Platform.VSphere.DNSForwarders
+	case config.Platform.OpenStack != nil:
+		return config.Platform.OpenStack.DNSForwarders
+	}
+	return nil
+}
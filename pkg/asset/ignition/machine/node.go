@@ -20,24 +20,25 @@ import (
 // served by the machine config server.
 func pointerIgnitionConfig(installConfig *types.InstallConfig, rootCA []byte, role string) *ignition.Config {
 	var ignitionHost string
+	mcsPort := fmt.Sprint(installConfig.MCSPort())
 	// Default platform independent ignitionHost
-	ignitionHost = fmt.Sprintf("api-int.%s:22623", installConfig.ClusterDomain())
+	ignitionHost = fmt.Sprintf("api-int.%s:%s", installConfig.ClusterDomain(), mcsPort)
 	// Update ignitionHost as necessary for platform
 	switch installConfig.Platform.Name() {
 	case baremetaltypes.Name:
 		// Baremetal needs to point directly at the VIP because we don't have a
 		// way to configure DNS before Ignition runs.
-		ignitionHost = net.JoinHostPort(installConfig.BareMetal.APIVIP, "22623")
+		ignitionHost = net.JoinHostPort(installConfig.BareMetal.APIVIP, mcsPort)
 	case openstacktypes.Name:
 		apiVIP, err := openstackdefaults.APIVIP(installConfig.Networking)
 		if err == nil {
-			ignitionHost = net.JoinHostPort(apiVIP.String(), "22623")
+			ignitionHost = net.JoinHostPort(apiVIP.String(), mcsPort)
 		}
 	case ovirttypes.Name:
-		ignitionHost = net.JoinHostPort(installConfig.Ovirt.APIVIP, "22623")
+		ignitionHost = net.JoinHostPort(installConfig.Ovirt.APIVIP, mcsPort)
 	case vspheretypes.Name:
 		if installConfig.VSphere.APIVIP != "" {
-			ignitionHost = net.JoinHostPort(installConfig.VSphere.APIVIP, "22623")
+			ignitionHost = net.JoinHostPort(installConfig.VSphere.APIVIP, mcsPort)
 		}
 	}
 	return &ignition.Config{
@@ -29,7 +29,9 @@ func TestWorkerGenerate(t *testing.T) {
 	}
 
 	rootCA := &tls.RootCA{}
-	err := rootCA.Generate(nil)
+	caParents := asset.Parents{}
+	caParents.Add(installConfig)
+	err := rootCA.Generate(caParents)
 	assert.NoError(t, err, "unexpected error generating root CA")
 
 	parents := asset.Parents{}
@@ -0,0 +1,50 @@
+package aws
+
+import (
+	"os"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// defaultExpectedDuration is how long an install or destroy is
+	// assumed to take when checking credential lifetime.
+	defaultExpectedDuration = 1 * time.Hour
+
+	// expectedDurationEnv overrides the expected duration, e.g. "90m".
+	expectedDurationEnv = "OPENSHIFT_INSTALL_EXPECTED_DURATION"
+)
+
+// CheckCredentialLifetime verifies that temporary credentials will not
+// expire while terraform is still applying or destroying. Static
+// credentials (no expiry) and refreshable credentials pass through;
+// only credentials whose remaining lifetime is shorter than the
+// expected duration produce an error, so the run fails before
+// terraform is left half-applied with auth errors.
+func CheckCredentialLifetime(ssn *session.Session) error {
+	expiry, err := ssn.Config.Credentials.ExpiresAt()
+	if err != nil {
+		// The provider does not expose an expiry: static keys or a
+		// profile the SDK refreshes automatically.
+		return nil
+	}
+
+	expected := defaultExpectedDuration
+	if value := os.Getenv(expectedDurationEnv); value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			return errors.Wrapf(err, "invalid %s", expectedDurationEnv)
+		}
+		expected = parsed
+	}
+
+	remaining := time.Until(expiry)
+	if remaining < expected {
+		return errors.Errorf("the AWS credentials expire in %s, which is less than the expected duration of %s; refresh the credentials or lower %s", remaining.Round(time.Minute), expected, expectedDurationEnv)
+	}
+	logrus.Debugf("AWS credentials expire at %s (%s remaining)", expiry.Format(time.RFC3339), remaining.Round(time.Minute))
+	return nil
+}
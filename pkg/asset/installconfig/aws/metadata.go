@@ -98,6 +98,35 @@ func (m *Metadata) PublicSubnets(ctx context.Context) (map[string]Subnet, error)
 	return m.publicSubnets, nil
 }
 
+// SubnetZones retrieves a zone-to-subnet-ID map for the given subnet
+// IDs, for machine pools pinned to specific subnets. The subnets do
+// not have to be part of the cluster-wide subnet selection.
+func (m *Metadata) SubnetZones(ctx context.Context, ids []string) (map[string]string, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	session, err := m.unlockedSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	_, private, public, err := subnets(ctx, session, m.Region, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	zones := map[string]string{}
+	for id, subnet := range private {
+		zones[subnet.Zone] = id
+	}
+	for id, subnet := range public {
+		if _, ok := zones[subnet.Zone]; !ok {
+			zones[subnet.Zone] = id
+		}
+	}
+	return zones, nil
+}
+
 func (m *Metadata) populateSubnets(ctx context.Context) error {
 	if len(m.publicSubnets) > 0 || len(m.privateSubnets) > 0 {
 		return nil
@@ -3,6 +3,7 @@ package aws
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/pkg/errors"
@@ -216,21 +217,28 @@ var permissions = map[PermissionGroup][]string{
 	},
 }
 
-// ValidateCreds will try to create an AWS session, and also verify that the current credentials
-// are sufficient to perform an installation, and that they can be used for cluster runtime
-// as either capable of creating new credentials for components that interact with the cloud or
-// being able to be passed through as-is to the components that need cloud credentials
-func ValidateCreds(ssn *session.Session, groups []PermissionGroup, region string) error {
-	// Compile a list of permissions based on the permission groups provided
-	requiredPermissions := []string{}
+// Permissions returns the deduplicated, sorted list of IAM actions for
+// the given permission groups.
+func Permissions(groups []PermissionGroup) []string {
+	set := map[string]bool{}
 	for _, group := range groups {
-		groupPerms, ok := permissions[group]
-		if !ok {
-			return errors.Errorf("unable to access permissions group %s", group)
+		for _, permission := range permissions[group] {
+			set[permission] = true
 		}
-		requiredPermissions = append(requiredPermissions, groupPerms...)
 	}
+	list := make([]string, 0, len(set))
+	for permission := range set {
+		list = append(list, permission)
+	}
+	sort.Strings(list)
+	return list
+}
 
+// ValidateCreds will try to create an AWS session, and also verify that the current credentials
+// are sufficient to perform an installation, and that they can be used for cluster runtime
+// as either capable of creating new credentials for components that interact with the cloud or
+// being able to be passed through as-is to the components that need cloud credentials
+func ValidateCreds(ssn *session.Session, requiredPermissions []string, region string) error {
 	creds, err := ssn.Config.Credentials.Get()
 	if err != nil {
 		return errors.Wrap(err, "getting creds from session")
@@ -1,6 +1,7 @@
 package aws
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"sync"
@@ -10,11 +11,14 @@ import (
 	"github.com/aws/aws-sdk-go/aws/defaults"
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sts"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+	"golang.org/x/crypto/ssh/terminal"
 	survey "gopkg.in/AlecAivazis/survey.v1"
 	ini "gopkg.in/ini.v1"
 
+	"github.com/openshift/installer/pkg/inputtrack"
 	"github.com/openshift/installer/pkg/version"
 )
 
@@ -50,6 +54,7 @@ func GetSession() (*session.Session, error) {
 			onceLoggers[sharedCredentialsProviderName].Do(func() {
 				logrus.Infof("Credentials loaded from the %q profile in file %q", sharedCredentialsProvider.Profile, sharedCredentialsProvider.Filename)
 			})
+			inputtrack.File(sharedCredentialsProvider.Filename, true)
 		case envProviderName:
 			onceLoggers[envProviderName].Do(func() {
 				logrus.Info("Credentials loaded from default AWS environment variables")
@@ -67,9 +72,47 @@ func GetSession() (*session.Session, error) {
 		Name: "openshiftInstaller.OpenshiftInstallerUserAgentHandler",
 		Fn:   request.MakeAddToUserAgentHandler("OpenShift/4.x Installer", version.Raw),
 	})
+
+	if err := smokeTestCredentials(ssn); err != nil {
+		source := credentialSource(creds.ProviderName, sharedCredentialsProvider)
+		if !terminal.IsTerminal(int(os.Stdin.Fd())) {
+			return nil, errors.Wrapf(err, "the AWS credentials from %s are expired or invalid; refresh them and re-run", source)
+		}
+		// Interactive runs re-prompt rather than dying deep inside
+		// asset generation with an opaque SDK error.
+		logrus.Warnf("The AWS credentials from %s are expired or invalid (%v); please provide new credentials", source, err)
+		if err := getCredentials(); err != nil {
+			return nil, err
+		}
+		return GetSession()
+	}
+
 	return ssn, nil
 }
 
+// smokeTestCredentials verifies the credentials actually work before
+// asset generation starts, using the cheapest possible call.
+func smokeTestCredentials(ssn *session.Session) error {
+	_, err := sts.New(ssn).GetCallerIdentity(&sts.GetCallerIdentityInput{})
+	return err
+}
+
+// credentialSource names where the active credentials came from, for
+// actionable error messages.
+func credentialSource(providerName string, shared *credentials.SharedCredentialsProvider) string {
+	switch providerName {
+	case sharedCredentialsProviderName:
+		profile := shared.Profile
+		if profile == "" {
+			profile = "default"
+		}
+		return fmt.Sprintf("profile %q in %s", profile, shared.Filename)
+	case envProviderName:
+		return "the AWS environment variables"
+	}
+	return "the configured AWS credential chain"
+}
+
 func getCredentials() error {
 	var keyID string
 	err := survey.Ask([]*survey.Question{
@@ -4,9 +4,16 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"net/url"
 	"sort"
+	"strings"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/kms"
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
@@ -22,6 +29,29 @@ func Validate(ctx context.Context, meta *Metadata, config *types.InstallConfig)
 		return errors.New(field.Required(field.NewPath("platform", "aws"), "AWS validation requires an AWS platform configuration").Error())
 	}
 	allErrs = append(allErrs, validatePlatform(ctx, meta, field.NewPath("platform", "aws"), config.Platform.AWS, config.Networking, config.Publish)...)
+	if config.Platform.AWS.UserProvisionedDNS == "Enabled" {
+		// Bootstrap needs the user-managed resolver to serve api-int,
+		// but the record usually targets the internal NLB terraform
+		// has not created yet and resolver rules live inside the VPC,
+		// not on the installer host - so an unresolvable name here is
+		// expected, and only worth a heads-up.
+		apiInt := fmt.Sprintf("api-int.%s", config.ClusterDomain())
+		if _, err := net.LookupHost(apiInt); err != nil {
+			logrus.Warnf("%s does not resolve from this host (%v); make sure the record exists on the VPC's resolver before the nodes boot", apiInt, err)
+		}
+	}
+
+	if config.Bastion != nil && len(config.Platform.AWS.Subnets) > 0 {
+		// The bastion lands in a public subnet; a private-only set of
+		// user-provided subnets would fail with an opaque
+		// invalid-index error at terraform plan time.
+		publicSubnets, err := meta.PublicSubnets(ctx)
+		if err != nil {
+			allErrs = append(allErrs, field.InternalError(field.NewPath("bastion"), err))
+		} else if len(publicSubnets) == 0 {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("bastion"), "<bastion>", "the bastion needs a public subnet, but the provided subnets are all private; add a public subnet or drop the bastion section"))
+		}
+	}
 
 	if config.ControlPlane != nil && config.ControlPlane.Platform.AWS != nil {
 		allErrs = append(allErrs, validateMachinePool(ctx, meta, field.NewPath("controlPlane", "platform", "aws"), config.Platform.AWS, config.ControlPlane.Platform.AWS)...)
@@ -43,6 +73,45 @@ func validatePlatform(ctx context.Context, meta *Metadata, fldPath *field.Path,
 	if platform.DefaultMachinePlatform != nil {
 		allErrs = append(allErrs, validateMachinePool(ctx, meta, fldPath.Child("defaultMachinePlatform"), platform, platform.DefaultMachinePlatform)...)
 	}
+	if platform.IAMPermissionsBoundary != "" {
+		allErrs = append(allErrs, validatePermissionsBoundary(ctx, meta, fldPath.Child("iamPermissionsBoundary"), platform)...)
+	}
+	if platform.AMIEncryption != nil {
+		allErrs = append(allErrs, validateAMIEncryption(ctx, meta, fldPath.Child("amiEncryption", "kmsKeyID"), platform)...)
+	}
+	return allErrs
+}
+
+// validateAMIEncryption checks that the customer-managed KMS key for
+// the encrypted AMI copy exists, is enabled, and that its policy does
+// not obviously exclude use by the account's EC2 service.
+func validateAMIEncryption(ctx context.Context, meta *Metadata, fldPath *field.Path, platform *awstypes.Platform) field.ErrorList {
+	allErrs := field.ErrorList{}
+	keyID := platform.AMIEncryption.KMSKeyID
+
+	session, err := meta.Session(ctx)
+	if err != nil {
+		return append(allErrs, field.InternalError(fldPath, err))
+	}
+	client := kms.New(session, aws.NewConfig().WithRegion(platform.Region))
+
+	key, err := client.DescribeKeyWithContext(ctx, &kms.DescribeKeyInput{KeyId: aws.String(keyID)})
+	if err != nil {
+		return append(allErrs, field.Invalid(fldPath, keyID, fmt.Sprintf("failed to describe KMS key: %v", err)))
+	}
+	if !aws.BoolValue(key.KeyMetadata.Enabled) {
+		allErrs = append(allErrs, field.Invalid(fldPath, keyID, "KMS key is not enabled"))
+	}
+
+	policy, err := client.GetKeyPolicyWithContext(ctx, &kms.GetKeyPolicyInput{
+		KeyId:      aws.String(keyID),
+		PolicyName: aws.String("default"),
+	})
+	if err != nil {
+		logrus.Debugf("Unable to fetch KMS key policy for %s: %v", keyID, err)
+	} else if !strings.Contains(aws.StringValue(policy.Policy), "ec2.") && !strings.Contains(aws.StringValue(policy.Policy), "kms:CreateGrant") {
+		logrus.Warnf("The policy of KMS key %s may not allow the account's EC2 service to use the key; the encrypted AMI copy can fail at launch time", keyID)
+	}
 	return allErrs
 }
 
@@ -119,6 +188,120 @@ func validateMachinePool(ctx context.Context, meta *Metadata, fldPath *field.Pat
 			allErrs = append(allErrs, field.Invalid(fldPath.Child("zones"), pool.Zones, errMsg))
 		}
 	}
+	if len(pool.Subnets) > 0 {
+		allErrs = append(allErrs, validatePoolSubnets(ctx, meta, fldPath.Child("subnets"), platform, pool)...)
+	}
+	if gpuInstanceType(pool.InstanceType) {
+		allErrs = append(allErrs, validateGPUZoneOfferings(ctx, meta, fldPath.Child("type"), platform, pool)...)
+	}
+	return allErrs
+}
+
+// gpuInstanceType reports whether the named EC2 instance type belongs
+// to one of the GPU families.
+func gpuInstanceType(instanceType string) bool {
+	for _, prefix := range []string{"p2.", "p3.", "p3dn.", "p4d.", "g3.", "g3s.", "g4dn.", "g5."} {
+		if strings.HasPrefix(instanceType, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// validateGPUZoneOfferings checks that a GPU instance type is offered
+// in each zone the pool uses; the GPU families are only present in a
+// subset of most regions' zones and the machine API would otherwise
+// fail asynchronously after the install.
+func validateGPUZoneOfferings(ctx context.Context, meta *Metadata, fldPath *field.Path, platform *awstypes.Platform, pool *awstypes.MachinePool) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	zones := pool.Zones
+	if len(zones) == 0 {
+		// Without explicit zones the machinesets spread over every
+		// zone; all of them have to offer the type.
+		allzones, err := meta.AvailabilityZones(ctx)
+		if err != nil {
+			return append(allErrs, field.InternalError(fldPath, err))
+		}
+		zones = allzones
+	}
+
+	session, err := meta.Session(ctx)
+	if err != nil {
+		return append(allErrs, field.InternalError(fldPath, err))
+	}
+	client := ec2.New(session, aws.NewConfig().WithRegion(platform.Region))
+
+	offered := sets.String{}
+	input := &ec2.DescribeInstanceTypeOfferingsInput{
+		LocationType: aws.String("availability-zone"),
+		Filters: []*ec2.Filter{{
+			Name:   aws.String("instance-type"),
+			Values: []*string{aws.String(pool.InstanceType)},
+		}},
+	}
+	for {
+		page, err := client.DescribeInstanceTypeOfferingsWithContext(ctx, input)
+		if err != nil {
+			return append(allErrs, field.InternalError(fldPath, err))
+		}
+		for _, offering := range page.InstanceTypeOfferings {
+			offered.Insert(aws.StringValue(offering.Location))
+		}
+		if aws.StringValue(page.NextToken) == "" {
+			break
+		}
+		input.NextToken = page.NextToken
+	}
+
+	if diff := sets.NewString(zones...).Difference(offered); diff.Len() > 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath, pool.InstanceType,
+			fmt.Sprintf("instance type is not offered in zones %s; pin the pool to zones %s or choose another type", diff.List(), offered.List())))
+	}
+	return allErrs
+}
+
+// validatePoolSubnets checks that the subnets a machine pool is pinned
+// to exist, belong to the cluster's VPC and cover the pool's zones.
+func validatePoolSubnets(ctx context.Context, meta *Metadata, fldPath *field.Path, platform *awstypes.Platform, pool *awstypes.MachinePool) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if len(platform.Subnets) == 0 {
+		// With an installer-created VPC, pre-existing subnets would
+		// live in a foreign VPC and the machines could never join the
+		// cluster - same rule as the Azure pool subnets, which require
+		// a pre-existing virtual network.
+		return append(allErrs, field.Invalid(fldPath, pool.Subnets, "per-pool subnets require installing into a pre-existing VPC (platform.aws.subnets)"))
+	}
+
+	session, err := meta.Session(ctx)
+	if err != nil {
+		return append(allErrs, field.InternalError(fldPath, err))
+	}
+	vpc, private, public, err := subnets(ctx, session, platform.Region, pool.Subnets)
+	if err != nil {
+		return append(allErrs, field.Invalid(fldPath, pool.Subnets, err.Error()))
+	}
+
+	zones := sets.String{}
+	for _, subnet := range private {
+		zones.Insert(subnet.Zone)
+	}
+	for _, subnet := range public {
+		zones.Insert(subnet.Zone)
+	}
+
+	clusterVPC, err := meta.VPC(ctx)
+	if err != nil {
+		return append(allErrs, field.InternalError(fldPath, err))
+	}
+	if vpc != clusterVPC {
+		allErrs = append(allErrs, field.Invalid(fldPath, pool.Subnets, fmt.Sprintf("subnets are in VPC %s, but the cluster subnets are in VPC %s", vpc, clusterVPC)))
+	}
+
+	if diff := sets.NewString(pool.Zones...).Difference(zones); diff.Len() > 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath, pool.Subnets, fmt.Sprintf("no subnets provided for the pool's zones %s", diff.List())))
+	}
 	return allErrs
 }
 
@@ -165,3 +348,63 @@ func validateDuplicateSubnetZones(fldPath *field.Path, subnets map[string]Subnet
 	}
 	return allErrs
 }
+
+// nodeRoleActions are representative actions the node roles need; the
+// permissions boundary must not block them.
+var nodeRoleActions = []string{
+	"ec2:DescribeInstances",
+	"ec2:DescribeRegions",
+	"ecr:GetDownloadUrlForLayer",
+	"ecr:BatchGetImage",
+}
+
+// validatePermissionsBoundary checks that the configured boundary
+// policy exists and simulates the node role actions against it so a
+// boundary that would break the nodes fails before the install.
+func validatePermissionsBoundary(ctx context.Context, meta *Metadata, fldPath *field.Path, platform *awstypes.Platform) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	session, err := meta.Session(ctx)
+	if err != nil {
+		return append(allErrs, field.InternalError(fldPath, err))
+	}
+	client := iam.New(session)
+
+	policy, err := client.GetPolicyWithContext(ctx, &iam.GetPolicyInput{PolicyArn: aws.String(platform.IAMPermissionsBoundary)})
+	if err != nil {
+		return append(allErrs, field.Invalid(fldPath, platform.IAMPermissionsBoundary, fmt.Sprintf("failed to find the permissions boundary policy: %v", err)))
+	}
+
+	version, err := client.GetPolicyVersionWithContext(ctx, &iam.GetPolicyVersionInput{
+		PolicyArn: aws.String(platform.IAMPermissionsBoundary),
+		VersionId: policy.Policy.DefaultVersionId,
+	})
+	if err != nil {
+		logrus.Warnf("Unable to read the permissions boundary document for simulation: %v", err)
+		return allErrs
+	}
+	boundaryDocument, err := url.QueryUnescape(aws.StringValue(version.PolicyVersion.Document))
+	if err != nil {
+		logrus.Warnf("Unable to decode the permissions boundary document for simulation: %v", err)
+		return allErrs
+	}
+
+	simulation, err := client.SimulateCustomPolicyWithContext(ctx, &iam.SimulateCustomPolicyInput{
+		ActionNames: aws.StringSlice(nodeRoleActions),
+		// Simulate an allow-all identity policy bounded by the
+		// configured boundary: only the boundary can deny.
+		PolicyInputList:                    aws.StringSlice([]string{`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"*","Resource":"*"}]}`}),
+		PermissionsBoundaryPolicyInputList: aws.StringSlice([]string{boundaryDocument}),
+	})
+	if err != nil {
+		logrus.Warnf("Unable to simulate the node role actions against the permissions boundary: %v", err)
+		return allErrs
+	}
+	for _, result := range simulation.EvaluationResults {
+		if aws.StringValue(result.EvalDecision) != "allowed" {
+			allErrs = append(allErrs, field.Invalid(fldPath, platform.IAMPermissionsBoundary,
+				fmt.Sprintf("the permissions boundary blocks %s, which the node roles need", aws.StringValue(result.EvalActionName))))
+		}
+	}
+	return allErrs
+}
@@ -6,7 +6,12 @@ import (
 
 	"github.com/pkg/errors"
 
+	"github.com/openshift/installer/pkg/asset/installconfig/internal/memoize"
+
+	azcompute "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2019-07-01/compute"
 	aznetwork "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-12-01/network"
+	azlocks "github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2016-09-01/locks"
+	azresources "github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2018-05-01/resources"
 )
 
 //go:generate mockgen -source=./client.go -destination=mock/azureclient_generated.go -package=mock
@@ -16,11 +21,26 @@ type API interface {
 	GetVirtualNetwork(ctx context.Context, resourceGroupName, virtualNetwork string) (*aznetwork.VirtualNetwork, error)
 	GetComputeSubnet(ctx context.Context, resourceGroupName, virtualNetwork, subnet string) (*aznetwork.Subnet, error)
 	GetControlPlaneSubnet(ctx context.Context, resourceGroupName, virtualNetwork, subnet string) (*aznetwork.Subnet, error)
+	GetPublicIPPrefix(ctx context.Context, resourceGroupName, prefixName string) (*aznetwork.PublicIPPrefix, error)
+	GetVMImage(ctx context.Context, location, publisher, offer, sku, version string) (*azcompute.VirtualMachineImage, error)
+	GetComputeUsages(ctx context.Context, region string) ([]azcompute.Usage, error)
+	GetNetworkUsages(ctx context.Context, region string) ([]aznetwork.Usage, error)
+	GetVirtualMachineSizes(ctx context.Context, region string) (map[string]int32, error)
+	GetManagementLocks(ctx context.Context, resourceGroup string) ([]azlocks.ManagementLockObject, error)
+	GetResourceGroup(ctx context.Context, name string) (*azresources.Group, error)
 }
 
 // Client makes calls to the Azure API.
 type Client struct {
 	ssn *Session
+
+	cache memoize.Cache
+}
+
+// memoize returns the cached result for key, computing and caching it
+// with fetch on the first call. Errors are not cached.
+func (c *Client) memoize(key string, fetch func() (interface{}, error)) (interface{}, error) {
+	return c.cache.Do(key, fetch)
 }
 
 // NewClient initializes a client with a session.
@@ -41,6 +61,16 @@ func NewClient(ctx context.Context) (*Client, error) {
 
 // GetVirtualNetwork gets an Azure virtual network by name
 func (c *Client) GetVirtualNetwork(ctx context.Context, resourceGroupName, virtualNetwork string) (*aznetwork.VirtualNetwork, error) {
+	cached, err := c.memoize("vnet/"+resourceGroupName+"/"+virtualNetwork, func() (interface{}, error) {
+		return c.getVirtualNetwork(ctx, resourceGroupName, virtualNetwork)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cached.(*aznetwork.VirtualNetwork), nil
+}
+
+func (c *Client) getVirtualNetwork(ctx context.Context, resourceGroupName, virtualNetwork string) (*aznetwork.VirtualNetwork, error) {
 	ctx, cancel := context.WithTimeout(ctx, 1*time.Minute)
 	defer cancel()
 
@@ -59,6 +89,16 @@ func (c *Client) GetVirtualNetwork(ctx context.Context, resourceGroupName, virtu
 
 // getSubnet gets an Azure subnet by name
 func (c *Client) getSubnet(ctx context.Context, resourceGroupName, virtualNetwork, subNetwork string) (*aznetwork.Subnet, error) {
+	cached, err := c.memoize("subnet/"+resourceGroupName+"/"+virtualNetwork+"/"+subNetwork, func() (interface{}, error) {
+		return c.getSubnetUncached(ctx, resourceGroupName, virtualNetwork, subNetwork)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cached.(*aznetwork.Subnet), nil
+}
+
+func (c *Client) getSubnetUncached(ctx context.Context, resourceGroupName, virtualNetwork, subNetwork string) (*aznetwork.Subnet, error) {
 	ctx, cancel := context.WithTimeout(ctx, 1*time.Minute)
 	defer cancel()
 
@@ -98,3 +138,90 @@ func (c *Client) getSubnetsClient(ctx context.Context) (*aznetwork.SubnetsClient
 	subnetClient.Authorizer = c.ssn.Authorizer
 	return &subnetClient, nil
 }
+
+// GetVMImage gets a marketplace VM image by its
+// publisher/offer/sku/version reference in the given location
+func (c *Client) GetVMImage(ctx context.Context, location, publisher, offer, sku, version string) (*azcompute.VirtualMachineImage, error) {
+	ctx, cancel := context.WithTimeout(ctx, 1*time.Minute)
+	defer cancel()
+
+	imagesClient := azcompute.NewVirtualMachineImagesClient(c.ssn.Credentials.SubscriptionID)
+	imagesClient.Authorizer = c.ssn.Authorizer
+
+	image, err := imagesClient.Get(ctx, location, publisher, offer, sku, version)
+	if err != nil {
+		return nil, err
+	}
+
+	return &image, nil
+}
+
+// GetPublicIPPrefix gets an Azure public IP prefix by name
+func (c *Client) GetPublicIPPrefix(ctx context.Context, resourceGroupName, prefixName string) (*aznetwork.PublicIPPrefix, error) {
+	ctx, cancel := context.WithTimeout(ctx, 1*time.Minute)
+	defer cancel()
+
+	prefixesClient := aznetwork.NewPublicIPPrefixesClient(c.ssn.Credentials.SubscriptionID)
+	prefixesClient.Authorizer = c.ssn.Authorizer
+
+	prefix, err := prefixesClient.Get(ctx, resourceGroupName, prefixName, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return &prefix, nil
+}
+
+// GetManagementLocks lists the management locks visible on the
+// subscription, or on the given resource group when non-empty.
+func (c *Client) GetManagementLocks(ctx context.Context, resourceGroup string) ([]azlocks.ManagementLockObject, error) {
+	cached, err := c.memoize("locks/"+resourceGroup, func() (interface{}, error) {
+		ctx, cancel := context.WithTimeout(ctx, 1*time.Minute)
+		defer cancel()
+
+		locksClient := azlocks.NewManagementLocksClient(c.ssn.Credentials.SubscriptionID)
+		locksClient.Authorizer = c.ssn.Authorizer
+
+		var result []azlocks.ManagementLockObject
+		if resourceGroup != "" {
+			for page, err := locksClient.ListAtResourceGroupLevel(ctx, resourceGroup, ""); page.NotDone(); err = page.NextWithContext(ctx) {
+				if err != nil {
+					return nil, err
+				}
+				result = append(result, page.Values()...)
+			}
+			return result, nil
+		}
+		for page, err := locksClient.ListAtSubscriptionLevel(ctx, ""); page.NotDone(); err = page.NextWithContext(ctx) {
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, page.Values()...)
+		}
+		return result, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cached.([]azlocks.ManagementLockObject), nil
+}
+
+// GetResourceGroup gets a resource group by name.
+func (c *Client) GetResourceGroup(ctx context.Context, name string) (*azresources.Group, error) {
+	cached, err := c.memoize("resourceGroup/"+name, func() (interface{}, error) {
+		ctx, cancel := context.WithTimeout(ctx, 1*time.Minute)
+		defer cancel()
+
+		groupsClient := azresources.NewGroupsClient(c.ssn.Credentials.SubscriptionID)
+		groupsClient.Authorizer = c.ssn.Authorizer
+		group, err := groupsClient.Get(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		return &group, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cached.(*azresources.Group), nil
+}
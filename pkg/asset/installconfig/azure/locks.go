@@ -0,0 +1,96 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/openshift/installer/pkg/types"
+)
+
+// skipLockValidationEnv skips the management lock pre-flight, e.g. in
+// strict environments that deny reading locks.
+const skipLockValidationEnv = "OPENSHIFT_INSTALL_SKIP_LOCK_VALIDATION"
+
+// validateManagementLocks checks for ReadOnly or CanNotDelete locks on
+// the scopes the installer writes to, so installs fail up front naming
+// the lock instead of at a random point mid-terraform. Policy
+// DenyAction evaluation has no what-if API in this SDK; listing the
+// locks catches the common cases and lock-read failures degrade to a
+// warning.
+func validateManagementLocks(client API, ic *types.InstallConfig) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if os.Getenv(skipLockValidationEnv) == "true" {
+		logrus.Debug("Skipping Azure management lock validation")
+		return allErrs
+	}
+
+	// Only resource groups the installer writes to matter; a
+	// CanNotDelete lock on an unrelated group elsewhere in the
+	// subscription - routine in enterprise subscriptions - must not
+	// block the install.
+	targetGroups := map[string]bool{}
+	if ic.Azure.NetworkResourceGroupName != "" {
+		targetGroups[strings.ToLower(ic.Azure.NetworkResourceGroupName)] = true
+	}
+	if ic.Azure.ResourceGroupName != "" {
+		targetGroups[strings.ToLower(ic.Azure.ResourceGroupName)] = true
+	}
+
+	locks, err := client.GetManagementLocks(context.TODO(), "")
+	if err != nil {
+		logrus.Warnf("Unable to enumerate management locks: %v", err)
+		return allErrs
+	}
+	for _, lock := range locks {
+		scopeName, relevant := lockScope(to.String(lock.ID), targetGroups)
+		if !relevant {
+			continue
+		}
+		level := ""
+		if lock.ManagementLockProperties != nil {
+			level = string(lock.ManagementLockProperties.Level)
+		}
+		allErrs = append(allErrs, field.Invalid(field.NewPath("platform", "azure"), scopeName,
+			fmt.Sprintf("management lock %q (%s) on the %s scope will block the installer; remove the lock or set %s=true", to.String(lock.Name), level, scopeName, skipLockValidationEnv)))
+	}
+	return allErrs
+}
+
+// lockScope parses a management lock's ID and reports whether its
+// scope covers something the installer writes: the subscription as a
+// whole, or one of the target resource groups at group level. Locks
+// on individual resources in unrelated groups are ignored.
+func lockScope(lockID string, targetGroups map[string]bool) (string, bool) {
+	segments := strings.Split(strings.Trim(strings.ToLower(lockID), "/"), "/")
+	groupIdx := -1
+	for i, segment := range segments {
+		if segment == "resourcegroups" && i+1 < len(segments) {
+			groupIdx = i
+			break
+		}
+	}
+	if groupIdx == -1 {
+		// No resource group segment: a subscription-wide lock.
+		return "subscription", true
+	}
+	group := segments[groupIdx+1]
+	if !targetGroups[group] {
+		return group, false
+	}
+	// Only group-level locks block the installer's writes; a lock on
+	// one specific pre-existing resource inside the group does not. A
+	// group-level lock ID continues directly with the lock provider:
+	// .../resourceGroups/<rg>/providers/Microsoft.Authorization/locks/<name>
+	rest := segments[groupIdx+2:]
+	if len(rest) == 4 && rest[0] == "providers" && rest[1] == "microsoft.authorization" && rest[2] == "locks" {
+		return group, true
+	}
+	return group, false
+}
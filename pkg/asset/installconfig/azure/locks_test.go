@@ -0,0 +1,50 @@
+package azure
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLockScope(t *testing.T) {
+	targetGroups := map[string]bool{"cluster-vnet-rg": true}
+
+	cases := []struct {
+		name     string
+		lockID   string
+		scope    string
+		relevant bool
+	}{
+		{
+			name:     "subscription-wide lock",
+			lockID:   "/subscriptions/sub/providers/Microsoft.Authorization/locks/freeze",
+			scope:    "subscription",
+			relevant: true,
+		},
+		{
+			name:     "group-level lock on a target group",
+			lockID:   "/subscriptions/sub/resourceGroups/Cluster-VNet-RG/providers/Microsoft.Authorization/locks/keep",
+			scope:    "cluster-vnet-rg",
+			relevant: true,
+		},
+		{
+			name:     "group-level lock on an unrelated group",
+			lockID:   "/subscriptions/sub/resourceGroups/billing-rg/providers/Microsoft.Authorization/locks/keep",
+			scope:    "billing-rg",
+			relevant: false,
+		},
+		{
+			name:     "lock on one resource inside a target group",
+			lockID:   "/subscriptions/sub/resourceGroups/cluster-vnet-rg/providers/Microsoft.Compute/virtualMachines/jumpbox/providers/Microsoft.Authorization/locks/keep",
+			scope:    "cluster-vnet-rg",
+			relevant: false,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			scope, relevant := lockScope(tc.lockID, targetGroups)
+			assert.Equal(t, tc.scope, scope)
+			assert.Equal(t, tc.relevant, relevant)
+		})
+	}
+}
@@ -6,7 +6,10 @@ package mock
 
 import (
 	context "context"
+	compute "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2019-07-01/compute"
 	network "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-12-01/network"
+	locks "github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2016-09-01/locks"
+	resources "github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2018-05-01/resources"
 	gomock "github.com/golang/mock/gomock"
 	reflect "reflect"
 )
@@ -78,3 +81,108 @@ func (mr *MockAPIMockRecorder) GetControlPlaneSubnet(ctx, resourceGroupName, vir
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetControlPlaneSubnet", reflect.TypeOf((*MockAPI)(nil).GetControlPlaneSubnet), ctx, resourceGroupName, virtualNetwork, subnet)
 }
+
+// GetPublicIPPrefix mocks base method
+func (m *MockAPI) GetPublicIPPrefix(ctx context.Context, resourceGroupName, prefixName string) (*network.PublicIPPrefix, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPublicIPPrefix", ctx, resourceGroupName, prefixName)
+	ret0, _ := ret[0].(*network.PublicIPPrefix)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPublicIPPrefix indicates an expected call of GetPublicIPPrefix
+func (mr *MockAPIMockRecorder) GetPublicIPPrefix(ctx, resourceGroupName, prefixName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublicIPPrefix", reflect.TypeOf((*MockAPI)(nil).GetPublicIPPrefix), ctx, resourceGroupName, prefixName)
+}
+
+// GetVMImage mocks base method
+func (m *MockAPI) GetVMImage(ctx context.Context, location, publisher, offer, sku, version string) (*compute.VirtualMachineImage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVMImage", ctx, location, publisher, offer, sku, version)
+	ret0, _ := ret[0].(*compute.VirtualMachineImage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVMImage indicates an expected call of GetVMImage
+func (mr *MockAPIMockRecorder) GetVMImage(ctx, location, publisher, offer, sku, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVMImage", reflect.TypeOf((*MockAPI)(nil).GetVMImage), ctx, location, publisher, offer, sku, version)
+}
+
+// GetComputeUsages mocks base method
+func (m *MockAPI) GetComputeUsages(ctx context.Context, region string) ([]compute.Usage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetComputeUsages", ctx, region)
+	ret0, _ := ret[0].([]compute.Usage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetComputeUsages indicates an expected call of GetComputeUsages
+func (mr *MockAPIMockRecorder) GetComputeUsages(ctx, region interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComputeUsages", reflect.TypeOf((*MockAPI)(nil).GetComputeUsages), ctx, region)
+}
+
+// GetNetworkUsages mocks base method
+func (m *MockAPI) GetNetworkUsages(ctx context.Context, region string) ([]network.Usage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNetworkUsages", ctx, region)
+	ret0, _ := ret[0].([]network.Usage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNetworkUsages indicates an expected call of GetNetworkUsages
+func (mr *MockAPIMockRecorder) GetNetworkUsages(ctx, region interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNetworkUsages", reflect.TypeOf((*MockAPI)(nil).GetNetworkUsages), ctx, region)
+}
+
+// GetVirtualMachineSizes mocks base method
+func (m *MockAPI) GetVirtualMachineSizes(ctx context.Context, region string) (map[string]int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVirtualMachineSizes", ctx, region)
+	ret0, _ := ret[0].(map[string]int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVirtualMachineSizes indicates an expected call of GetVirtualMachineSizes
+func (mr *MockAPIMockRecorder) GetVirtualMachineSizes(ctx, region interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVirtualMachineSizes", reflect.TypeOf((*MockAPI)(nil).GetVirtualMachineSizes), ctx, region)
+}
+
+// GetManagementLocks mocks base method
+func (m *MockAPI) GetManagementLocks(ctx context.Context, resourceGroup string) ([]locks.ManagementLockObject, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetManagementLocks", ctx, resourceGroup)
+	ret0, _ := ret[0].([]locks.ManagementLockObject)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetManagementLocks indicates an expected call of GetManagementLocks
+func (mr *MockAPIMockRecorder) GetManagementLocks(ctx, resourceGroup interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetManagementLocks", reflect.TypeOf((*MockAPI)(nil).GetManagementLocks), ctx, resourceGroup)
+}
+
+// GetResourceGroup mocks base method
+func (m *MockAPI) GetResourceGroup(ctx context.Context, name string) (*resources.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetResourceGroup", ctx, name)
+	ret0, _ := ret[0].(*resources.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetResourceGroup indicates an expected call of GetResourceGroup
+func (mr *MockAPIMockRecorder) GetResourceGroup(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResourceGroup", reflect.TypeOf((*MockAPI)(nil).GetResourceGroup), ctx, name)
+}
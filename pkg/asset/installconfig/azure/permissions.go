@@ -0,0 +1,77 @@
+package azure
+
+// Role definition actions the installer credentials need. The catalog
+// lives next to the code that makes the API calls; extend it when a
+// new resource type is provisioned.
+var (
+	// createActions are needed in all IPI installs.
+	createActions = []string{
+		"Microsoft.Compute/availabilitySets/write",
+		"Microsoft.Compute/disks/read",
+		"Microsoft.Compute/disks/write",
+		"Microsoft.Compute/images/read",
+		"Microsoft.Compute/images/write",
+		"Microsoft.Compute/virtualMachines/read",
+		"Microsoft.Compute/virtualMachines/write",
+		"Microsoft.ManagedIdentity/userAssignedIdentities/read",
+		"Microsoft.ManagedIdentity/userAssignedIdentities/write",
+		"Microsoft.ManagedIdentity/userAssignedIdentities/assign/action",
+		"Microsoft.Network/dnsZones/read",
+		"Microsoft.Network/dnsZones/A/write",
+		"Microsoft.Network/dnsZones/CNAME/write",
+		"Microsoft.Network/loadBalancers/read",
+		"Microsoft.Network/loadBalancers/write",
+		"Microsoft.Network/loadBalancers/backendAddressPools/join/action",
+		"Microsoft.Network/networkInterfaces/read",
+		"Microsoft.Network/networkInterfaces/write",
+		"Microsoft.Network/networkInterfaces/join/action",
+		"Microsoft.Network/networkSecurityGroups/read",
+		"Microsoft.Network/networkSecurityGroups/write",
+		"Microsoft.Network/networkSecurityGroups/join/action",
+		"Microsoft.Network/privateDnsZones/read",
+		"Microsoft.Network/privateDnsZones/write",
+		"Microsoft.Network/privateDnsZones/virtualNetworkLinks/write",
+		"Microsoft.Network/privateDnsZones/A/write",
+		"Microsoft.Network/privateDnsZones/SRV/write",
+		"Microsoft.Network/publicIPAddresses/read",
+		"Microsoft.Network/publicIPAddresses/write",
+		"Microsoft.Network/publicIPAddresses/join/action",
+		"Microsoft.Resources/subscriptions/resourceGroups/read",
+		"Microsoft.Resources/subscriptions/resourceGroups/write",
+		"Microsoft.Storage/storageAccounts/listKeys/action",
+		"Microsoft.Storage/storageAccounts/read",
+		"Microsoft.Storage/storageAccounts/write",
+		"Microsoft.Storage/storageAccounts/blobServices/containers/write",
+	}
+
+	// networkingActions are additionally needed when the installer
+	// creates the virtual network instead of using a pre-existing one.
+	networkingActions = []string{
+		"Microsoft.Network/virtualNetworks/read",
+		"Microsoft.Network/virtualNetworks/write",
+		"Microsoft.Network/virtualNetworks/subnets/read",
+		"Microsoft.Network/virtualNetworks/subnets/write",
+		"Microsoft.Network/virtualNetworks/subnets/join/action",
+	}
+
+	// deleteActions are needed to destroy the cluster.
+	deleteActions = []string{
+		"Microsoft.Authorization/roleAssignments/read",
+		"Microsoft.Network/dnsZones/A/delete",
+		"Microsoft.Network/dnsZones/CNAME/delete",
+		"Microsoft.Network/privateDnsZones/delete",
+		"Microsoft.Resources/subscriptions/resourceGroups/delete",
+	}
+)
+
+// RoleDefinitionActions returns the role definition actions the
+// install-config requires: the base catalog, the delete catalog, and
+// the networking catalog when the installer will create the network.
+func RoleDefinitionActions(preexistingNetwork bool) []string {
+	actions := append([]string{}, createActions...)
+	if !preexistingNetwork {
+		actions = append(actions, networkingActions...)
+	}
+	actions = append(actions, deleteActions...)
+	return actions
+}
@@ -0,0 +1,219 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+
+	azcompute "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2019-07-01/compute"
+	aznetwork "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-12-01/network"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/openshift/installer/pkg/preflight"
+	"github.com/openshift/installer/pkg/types"
+	azdefaults "github.com/openshift/installer/pkg/types/azure/defaults"
+)
+
+// skipQuotaValidationEnv bypasses the pre-flight quota checks, e.g.
+// when the usages API is unavailable to the credentials.
+const skipQuotaValidationEnv = "OPENSHIFT_INSTALL_SKIP_QUOTA_VALIDATION"
+
+// machines the installer itself adds on top of the configured pools.
+const bootstrapVMs = 1
+
+// familyForInstanceType derives the usage-API family name for a VM
+// size, e.g. Standard_D8s_v3 -> standardDSv3Family, so the demand can
+// be compared against the per-family vCPU quota. The mapping is
+// heuristic; sizes whose derived family has no usage entry are only
+// checked against the regional total.
+func familyForInstanceType(instanceType string) string {
+	name := strings.TrimPrefix(instanceType, "Standard_")
+	tokens := strings.Split(name, "_")
+	letters := make([]rune, 0, len(tokens[0]))
+	for _, r := range tokens[0] {
+		if !unicode.IsDigit(r) {
+			letters = append(letters, unicode.ToUpper(r))
+		}
+	}
+	return "standard" + string(letters) + strings.Join(tokens[1:], "") + "Family"
+}
+
+// validateQuota checks the subscription's regional compute and network
+// quotas against what the install will consume, so the install fails
+// up front with the exact shortfall instead of mid-terraform.
+func validateQuota(client API, ic *types.InstallConfig) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if os.Getenv(skipQuotaValidationEnv) == "true" {
+		logrus.Debug("Skipping Azure quota validation")
+		return allErrs
+	}
+	fldPath := field.NewPath("platform", "azure")
+	region := ic.Azure.Region
+
+	sizes, err := client.GetVirtualMachineSizes(context.TODO(), region)
+	if err != nil {
+		logrus.Warnf("Unable to list VM sizes for quota validation: %v", err)
+		return allErrs
+	}
+
+	demandVCPUs := int64(0)
+	demandVMs := int64(bootstrapVMs)
+	demandVCPUsByFamily := map[string]int64{}
+	countPool := func(pool *types.MachinePool, roleDefault string) {
+		if pool == nil {
+			return
+		}
+		replicas := int64(0)
+		if pool.Replicas != nil {
+			replicas = *pool.Replicas
+		}
+		// Same precedence as the machine asset: the pool's own type,
+		// then the default machine platform, then the role default -
+		// the pools usually carry no type at validation time, since
+		// defaulting happens in pkg/asset/machines.
+		instanceType := roleDefault
+		if ic.Azure.DefaultMachinePlatform != nil && ic.Azure.DefaultMachinePlatform.InstanceType != "" {
+			instanceType = ic.Azure.DefaultMachinePlatform.InstanceType
+		}
+		if pool.Platform.Azure != nil && pool.Platform.Azure.InstanceType != "" {
+			instanceType = pool.Platform.Azure.InstanceType
+		}
+		demandVMs += replicas
+		demandVCPUs += replicas * int64(sizes[instanceType])
+		demandVCPUsByFamily[familyForInstanceType(instanceType)] += replicas * int64(sizes[instanceType])
+	}
+	countPool(ic.ControlPlane, azdefaults.ControlPlaneInstanceType(region))
+	for idx := range ic.Compute {
+		countPool(&ic.Compute[idx], azdefaults.ComputeInstanceType(region))
+	}
+
+	computeUsages, err := client.GetComputeUsages(context.TODO(), region)
+	if err != nil {
+		logrus.Warnf("Unable to read compute usages for quota validation: %v", err)
+	} else {
+		for _, usage := range computeUsages {
+			available := int64(to.Int64(usage.Limit)) - int64(to.Int32(usage.CurrentValue))
+			if demand, ok := demandVCPUsByFamily[to.String(usage.Name.Value)]; ok && demand > available {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("region"), region,
+					fmt.Sprintf("the install needs %d vCPUs of the %s family in region %s but only %d are available within the per-family quota", demand, to.String(usage.Name.Value), region, available)))
+			}
+			switch to.String(usage.Name.Value) {
+			case "cores":
+				if demandVCPUs > available {
+					allErrs = append(allErrs, field.Invalid(fldPath.Child("region"), region,
+						fmt.Sprintf("the install needs %d vCPUs in region %s but only %d are available within the regional quota", demandVCPUs, region, available)))
+				}
+			case "virtualMachines":
+				if demandVMs > available {
+					allErrs = append(allErrs, field.Invalid(fldPath.Child("region"), region,
+						fmt.Sprintf("the install needs %d VMs in region %s but only %d are available within the regional quota", demandVMs, region, available)))
+				}
+			}
+		}
+	}
+
+	demandPublicIPs := int64(1) // bootstrap
+	demandLBs := int64(1)       // internal LB
+	if ic.Publish != types.InternalPublishingStrategy {
+		demandPublicIPs += 2 // API and ingress
+		demandLBs++          // public LB
+	}
+	networkUsages, err := client.GetNetworkUsages(context.TODO(), region)
+	if err != nil {
+		logrus.Warnf("Unable to read network usages for quota validation: %v", err)
+	} else {
+		for _, usage := range networkUsages {
+			available := to.Int64(usage.Limit) - to.Int64(usage.CurrentValue)
+			switch to.String(usage.Name.Value) {
+			case "PublicIPAddresses":
+				if demandPublicIPs > available {
+					allErrs = append(allErrs, field.Invalid(fldPath.Child("region"), region,
+						fmt.Sprintf("the install needs %d public IP addresses in region %s but only %d are available within the quota", demandPublicIPs, region, available)))
+				}
+			case "LoadBalancers":
+				if demandLBs > available {
+					allErrs = append(allErrs, field.Invalid(fldPath.Child("region"), region,
+						fmt.Sprintf("the install needs %d load balancers in region %s but only %d are available within the quota", demandLBs, region, available)))
+				}
+			}
+		}
+	}
+
+	preflight.Record("quota-validation", len(allErrs) == 0, map[string]string{
+		"platform": "azure",
+		"region":   region,
+	})
+	if len(allErrs) > 0 {
+		logrus.Warnf("Set %s=true to bypass the quota validation", skipQuotaValidationEnv)
+	}
+	return allErrs
+}
+
+// GetComputeUsages lists the subscription's compute usages in a region.
+func (c *Client) GetComputeUsages(ctx context.Context, region string) ([]azcompute.Usage, error) {
+	cached, err := c.memoize("computeUsages/"+region, func() (interface{}, error) {
+		usageClient := azcompute.NewUsageClient(c.ssn.Credentials.SubscriptionID)
+		usageClient.Authorizer = c.ssn.Authorizer
+		var usages []azcompute.Usage
+		for page, err := usageClient.List(ctx, region); page.NotDone(); err = page.NextWithContext(ctx) {
+			if err != nil {
+				return nil, err
+			}
+			usages = append(usages, page.Values()...)
+		}
+		return usages, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cached.([]azcompute.Usage), nil
+}
+
+// GetNetworkUsages lists the subscription's network usages in a region.
+func (c *Client) GetNetworkUsages(ctx context.Context, region string) ([]aznetwork.Usage, error) {
+	cached, err := c.memoize("networkUsages/"+region, func() (interface{}, error) {
+		usagesClient := aznetwork.NewUsagesClient(c.ssn.Credentials.SubscriptionID)
+		usagesClient.Authorizer = c.ssn.Authorizer
+		var usages []aznetwork.Usage
+		for page, err := usagesClient.List(ctx, region); page.NotDone(); err = page.NextWithContext(ctx) {
+			if err != nil {
+				return nil, err
+			}
+			usages = append(usages, page.Values()...)
+		}
+		return usages, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cached.([]aznetwork.Usage), nil
+}
+
+// GetVirtualMachineSizes returns the vCPU count per VM size name in a
+// region.
+func (c *Client) GetVirtualMachineSizes(ctx context.Context, region string) (map[string]int32, error) {
+	cached, err := c.memoize("vmSizes/"+region, func() (interface{}, error) {
+		sizesClient := azcompute.NewVirtualMachineSizesClient(c.ssn.Credentials.SubscriptionID)
+		sizesClient.Authorizer = c.ssn.Authorizer
+		result, err := sizesClient.List(ctx, region)
+		if err != nil {
+			return nil, err
+		}
+		sizes := map[string]int32{}
+		if result.Value != nil {
+			for _, size := range *result.Value {
+				sizes[to.String(size.Name)] = to.Int32(size.NumberOfCores)
+			}
+		}
+		return sizes, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cached.(map[string]int32), nil
+}
@@ -0,0 +1,19 @@
+package azure
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFamilyForInstanceType(t *testing.T) {
+	cases := map[string]string{
+		"Standard_D8s_v3":  "standardDSv3Family",
+		"Standard_D4_v3":   "standardDv3Family",
+		"Standard_F4s":     "standardFSFamily",
+		"Standard_E16s_v3": "standardESv3Family",
+	}
+	for instanceType, family := range cases {
+		assert.Equal(t, family, familyForInstanceType(instanceType), instanceType)
+	}
+}
@@ -1,17 +1,22 @@
 package azure
 
 import (
+	"github.com/openshift/installer/pkg/inputtrack"
+	"context"
 	"encoding/json"
 	"io/ioutil"
 	"os"
 	"path/filepath"
 	"sync"
+	"time"
 
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-06-01/subscriptions"
 	"github.com/Azure/go-autorest/autorest"
 	azureenv "github.com/Azure/go-autorest/autorest/azure"
 	"github.com/Azure/go-autorest/autorest/azure/auth"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+	"golang.org/x/crypto/ssh/terminal"
 	"gopkg.in/AlecAivazis/survey.v1"
 )
 
@@ -22,14 +27,14 @@ var (
 	onceLoggers         = map[string]*sync.Once{}
 )
 
-//Session is an object representing session for subscription
+// Session is an object representing session for subscription
 type Session struct {
 	GraphAuthorizer autorest.Authorizer
 	Authorizer      autorest.Authorizer
 	Credentials     Credentials
 }
 
-//Credentials is the data type for credentials as understood by the azure sdk
+// Credentials is the data type for credentials as understood by the azure sdk
 type Credentials struct {
 	SubscriptionID string `json:"subscriptionId,omitempty"`
 	ClientID       string `json:"clientId,omitempty"`
@@ -81,6 +86,7 @@ func newSessionFromFile(authFilePath string) (*Session, error) {
 	onceLoggers[authFilePath].Do(func() {
 		logrus.Infof("Credentials loaded from file %q", authFilePath)
 	})
+	inputtrack.File(authFilePath, true)
 
 	authorizer, err := authSettings.ClientCredentialsAuthorizerWithResource(azureenv.PublicCloud.ResourceManagerEndpoint)
 	if err != nil {
@@ -92,11 +98,41 @@ func newSessionFromFile(authFilePath string) (*Session, error) {
 		return nil, errors.Wrap(err, "failed to get GraphEndpoint authorizer from saved azure auth settings")
 	}
 
-	return &Session{
+	session := &Session{
 		GraphAuthorizer: graphAuthorizer,
 		Authorizer:      authorizer,
 		Credentials:     *credentials,
-	}, nil
+	}
+
+	if err := smokeTestSession(session); err != nil {
+		if !terminal.IsTerminal(int(os.Stdin.Fd())) {
+			return nil, errors.Wrapf(err, "the Azure service principal from %q is expired or invalid; refresh it and re-run", authFilePath)
+		}
+		// Interactive runs re-prompt rather than dying deep inside
+		// asset generation with an opaque autorest error.
+		logrus.Warnf("The Azure service principal from %q is expired or invalid (%v); please provide new credentials", authFilePath, err)
+		newCredentials, err := askForCredentials()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to retrieve credentials from user")
+		}
+		if err = saveCredentials(*newCredentials, authFilePath); err != nil {
+			return nil, errors.Wrap(err, "failed to save credentials")
+		}
+		return newSessionFromFile(authFilePath)
+	}
+
+	return session, nil
+}
+
+// smokeTestSession verifies the credentials actually work before asset
+// generation starts, using the cheapest possible ARM call.
+func smokeTestSession(session *Session) error {
+	client := subscriptions.NewClient()
+	client.Authorizer = session.Authorizer
+	ctx, cancel := context.WithTimeout(context.TODO(), 30*time.Second)
+	defer cancel()
+	_, err := client.Get(ctx, session.Credentials.SubscriptionID)
+	return err
 }
 
 func getCredentials(fs auth.FileSettings) (*Credentials, error) {
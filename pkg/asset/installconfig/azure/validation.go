@@ -4,8 +4,11 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"strings"
 
 	aznetwork "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-12-01/network"
+	"github.com/sirupsen/logrus"
+
 	aztypes "github.com/openshift/installer/pkg/types/azure"
 
 	"github.com/openshift/installer/pkg/types"
@@ -17,9 +20,118 @@ func Validate(client API, ic *types.InstallConfig) error {
 	allErrs := field.ErrorList{}
 
 	allErrs = append(allErrs, validateNetworks(client, ic.Azure, ic.Networking.MachineNetwork, field.NewPath("platform").Child("azure"))...)
+	allErrs = append(allErrs, validatePublicIPPrefix(client, ic.Azure, field.NewPath("platform").Child("azure"))...)
+	allErrs = append(allErrs, validatePoolSubnets(client, ic, field.NewPath("compute"))...)
+	allErrs = append(allErrs, validateMarketplaceImage(client, ic)...)
+	allErrs = append(allErrs, validateQuota(client, ic)...)
+	allErrs = append(allErrs, validateManagementLocks(client, ic)...)
+	allErrs = append(allErrs, validateResourceGroup(client, ic)...)
 	return allErrs.ToAggregate()
 }
 
+// validateMarketplaceImage confirms a configured marketplace image
+// exists in the cluster's region. The installer cannot verify the
+// image actually boots RHCOS, so that responsibility stays with the
+// user, as does accepting the marketplace terms of third-party plans.
+func validateMarketplaceImage(client API, ic *types.InstallConfig) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if ic.Azure.DefaultMachinePlatform == nil || ic.Azure.DefaultMachinePlatform.OSImage == nil {
+		return allErrs
+	}
+	osImage := ic.Azure.DefaultMachinePlatform.OSImage
+	fldPath := field.NewPath("platform", "azure", "defaultMachinePlatform", "osImage")
+
+	_, err := client.GetVMImage(context.TODO(), ic.Azure.Region, osImage.Publisher, osImage.Offer, osImage.SKU, osImage.Version)
+	if err != nil {
+		return append(allErrs, field.Invalid(fldPath, fmt.Sprintf("%s:%s:%s:%s", osImage.Publisher, osImage.Offer, osImage.SKU, osImage.Version),
+			fmt.Sprintf("failed to find the marketplace image in region %s: %v", ic.Azure.Region, err)))
+	}
+	logrus.Warn("Booting from a marketplace image; ensure the image is RHCOS-compatible")
+	if osImage.Plan != "" {
+		logrus.Warnf("The marketplace terms for plan %q must be accepted on the subscription before machines can boot", osImage.Plan)
+	}
+	return allErrs
+}
+
+// validatePoolSubnets checks the subnets that compute pools are pinned
+// to: they require a pre-existing virtual network, must exist there and
+// must be within the machine networks.
+func validatePoolSubnets(client API, ic *types.InstallConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for idx, pool := range ic.Compute {
+		if pool.Platform.Azure == nil || pool.Platform.Azure.Subnet == "" {
+			continue
+		}
+		poolPath := fldPath.Index(idx).Child("platform", "azure", "subnet")
+		name := pool.Platform.Azure.Subnet
+		if ic.Azure.VirtualNetwork == "" {
+			allErrs = append(allErrs, field.Invalid(poolPath, name, "pinning a pool to a subnet requires a pre-existing virtual network"))
+			continue
+		}
+		subnet, err := client.GetComputeSubnet(context.TODO(), ic.Azure.NetworkResourceGroupName, ic.Azure.VirtualNetwork, name)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(poolPath, name, "failed to retrieve subnet"))
+			continue
+		}
+		allErrs = append(allErrs, validateSubnet(client, poolPath, subnet, name, ic.Networking.MachineNetwork)...)
+	}
+	return allErrs
+}
+
+// validatePublicIPPrefix checks that the user-provided public IP prefix
+// exists, lives in the cluster's region, is SKU Standard and still has
+// an address available for the API load balancer.
+func validatePublicIPPrefix(client API, p *aztypes.Platform, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if p.APIPublicIPPrefixID == "" {
+		return allErrs
+	}
+	fldPath := fieldPath.Child("apiPublicIPPrefixID")
+
+	resourceGroup, prefixName, err := splitPublicIPPrefixID(p.APIPublicIPPrefixID)
+	if err != nil {
+		return append(allErrs, field.Invalid(fldPath, p.APIPublicIPPrefixID, err.Error()))
+	}
+
+	prefix, err := client.GetPublicIPPrefix(context.TODO(), resourceGroup, prefixName)
+	if err != nil {
+		return append(allErrs, field.Invalid(fldPath, p.APIPublicIPPrefixID, fmt.Sprintf("failed to retrieve public IP prefix: %v", err)))
+	}
+
+	if prefix.Location != nil && !strings.EqualFold(*prefix.Location, p.Region) {
+		allErrs = append(allErrs, field.Invalid(fldPath, p.APIPublicIPPrefixID, fmt.Sprintf("public IP prefix is in region %s, expected %s", *prefix.Location, p.Region)))
+	}
+	if prefix.Sku == nil || prefix.Sku.Name != aznetwork.Standard {
+		allErrs = append(allErrs, field.Invalid(fldPath, p.APIPublicIPPrefixID, "public IP prefix must use the Standard SKU"))
+	}
+	if props := prefix.PublicIPPrefixPropertiesFormat; props != nil && props.PrefixLength != nil {
+		capacity := 1 << uint(32-*props.PrefixLength)
+		used := 0
+		if props.PublicIPAddresses != nil {
+			used = len(*props.PublicIPAddresses)
+		}
+		if used >= capacity {
+			allErrs = append(allErrs, field.Invalid(fldPath, p.APIPublicIPPrefixID, fmt.Sprintf("public IP prefix has no addresses available (%d of %d allocated)", used, capacity)))
+		}
+	}
+
+	return allErrs
+}
+
+// splitPublicIPPrefixID extracts the resource group and prefix name
+// from a public IP prefix resource ID.
+func splitPublicIPPrefixID(id string) (resourceGroup string, name string, err error) {
+	parts := strings.Split(strings.Trim(id, "/"), "/")
+	// subscriptions/<id>/resourceGroups/<rg>/providers/Microsoft.Network/publicIPPrefixes/<name>
+	if len(parts) != 8 || !strings.EqualFold(parts[2], "resourceGroups") || !strings.EqualFold(parts[6], "publicIPPrefixes") {
+		return "", "", fmt.Errorf("invalid public IP prefix resource ID %q", id)
+	}
+	return parts[3], parts[7], nil
+}
+
 // validateNetworks checks that the user-provided VNet and subnets are valid.
 func validateNetworks(client API, p *aztypes.Platform, machineNetworks []types.MachineNetworkEntry, fieldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
@@ -69,3 +181,25 @@ func validateMachineNetworksContainIP(fldPath *field.Path, networks []types.Mach
 	}
 	return field.ErrorList{field.Invalid(fldPath, subnetName, fmt.Sprintf("subnet %s address prefix is outside of the specified machine networks", ip))}
 }
+
+// validateResourceGroup checks that a pre-created resource group
+// exists and is in the install region. Emptiness is advisory: the
+// listing can race with policy-driven resources, so conflicts surface
+// as a warning.
+func validateResourceGroup(client API, ic *types.InstallConfig) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if ic.Azure.ResourceGroupName == "" {
+		return allErrs
+	}
+	fldPath := field.NewPath("platform", "azure", "resourceGroupName")
+
+	group, err := client.GetResourceGroup(context.TODO(), ic.Azure.ResourceGroupName)
+	if err != nil {
+		return append(allErrs, field.Invalid(fldPath, ic.Azure.ResourceGroupName, fmt.Sprintf("failed to get the resource group: %v", err)))
+	}
+	if group.Location != nil && !strings.EqualFold(*group.Location, ic.Azure.Region) {
+		allErrs = append(allErrs, field.Invalid(fldPath, ic.Azure.ResourceGroupName, fmt.Sprintf("the resource group is in %s, expected %s", *group.Location, ic.Azure.Region)))
+	}
+	logrus.Warnf("Installing into the pre-created resource group %s: make sure it contains no conflicting resources; destroy will delete only the cluster's resources inside it", ic.Azure.ResourceGroupName)
+	return allErrs
+}
@@ -144,6 +144,12 @@ func TestAzureInstallConfigValidation(t *testing.T) {
 	azureClient.EXPECT().GetControlPlaneSubnet(gomock.Any(), validNetworkResourceGroup, gomock.Not(validVirtualNetwork), validControlPlaneSubnet).Return(&aznetwork.Subnet{}, fmt.Errorf("invalid virtual network")).AnyTimes()
 	azureClient.EXPECT().GetControlPlaneSubnet(gomock.Any(), validNetworkResourceGroup, validVirtualNetwork, gomock.Not(validControlPlaneSubnet)).Return(&aznetwork.Subnet{}, fmt.Errorf("invalid control plane subnet")).AnyTimes()
 
+	// Quota
+	azureClient.EXPECT().GetVirtualMachineSizes(gomock.Any(), gomock.Any()).Return(map[string]int32{}, nil).AnyTimes()
+	azureClient.EXPECT().GetComputeUsages(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	azureClient.EXPECT().GetNetworkUsages(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	azureClient.EXPECT().GetManagementLocks(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			editedInstallConfig := validInstallConfig()
@@ -0,0 +1,178 @@
+// Package baremetal contains pre-flight validation for the baremetal
+// platform.
+package baremetal
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	"github.com/openshift/installer/pkg/types"
+	baremetaltypes "github.com/openshift/installer/pkg/types/baremetal"
+)
+
+// skipHostValidationEnv skips the BMC pre-flight entirely.
+const skipHostValidationEnv = "OPENSHIFT_INSTALL_SKIP_HOST_VALIDATION"
+
+const bmcDialTimeout = 10 * time.Second
+
+// defaultBMCPorts maps BMC URL schemes to their management ports.
+var defaultBMCPorts = map[string]string{
+	"ipmi":                 "623",
+	"redfish":              "443",
+	"redfish-virtualmedia": "443",
+	"idrac":                "443",
+	"idrac-virtualmedia":   "443",
+	"idrac-redfish":        "443",
+	"ilo4":                 "443",
+	"ilo5":                 "443",
+	"irmc":                 "443",
+}
+
+// ValidateBMCs checks every host's BMC before any ignition is
+// generated: the address parses with a known driver scheme, the
+// management port accepts TCP connections, and redfish-family drivers
+// answer an authenticated GET of the Systems collection. Bad
+// addresses or credentials therefore surface now instead of after the
+// bootstrap VM is up and ironic inspects the hosts.
+func ValidateBMCs(config *types.InstallConfig) error {
+	if os.Getenv(skipHostValidationEnv) == "true" {
+		logrus.Debug("Skipping BMC pre-flight validation")
+		return nil
+	}
+
+	// Probe the hosts in parallel; a rack of unreachable BMCs should
+	// fail in one dial timeout, not one per host.
+	hosts := config.Platform.BareMetal.Hosts
+	errsByHost := make([]error, len(hosts))
+	var wg sync.WaitGroup
+	wg.Add(len(hosts))
+	for i, host := range hosts {
+		go func(i int, host *baremetaltypes.Host) {
+			defer wg.Done()
+			if err := validateHostBMC(host); err != nil {
+				errsByHost[i] = errors.Wrapf(err, "host %s", host.Name)
+			}
+		}(i, host)
+	}
+	wg.Wait()
+	var errs []error
+	for _, err := range errsByHost {
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return errors.Wrapf(utilerrors.NewAggregate(errs), "BMC pre-flight failed (set %s=true to skip)", skipHostValidationEnv)
+	}
+	return nil
+}
+
+func validateHostBMC(host *baremetaltypes.Host) error {
+	parsed, err := url.Parse(host.BMC.Address)
+	if err != nil {
+		return errors.Wrapf(err, "failed to parse BMC address %q", host.BMC.Address)
+	}
+	scheme := strings.SplitN(parsed.Scheme, "+", 2)[0]
+	port, known := defaultBMCPorts[scheme]
+	if !known {
+		return errors.Errorf("unknown BMC driver scheme %q in address %q", parsed.Scheme, host.BMC.Address)
+	}
+	if parsed.Port() != "" {
+		port = parsed.Port()
+	}
+
+	address := net.JoinHostPort(parsed.Hostname(), port)
+	if scheme == "ipmi" {
+		// IPMI is RMCP over UDP; a TCP dial to 623 fails against
+		// perfectly healthy BMCs. Send an RMCP ASF ping instead and
+		// treat anything that is not an ICMP rejection as reachable.
+		return validateIPMIPing(address)
+	}
+	conn, err := net.DialTimeout("tcp", address, bmcDialTimeout)
+	if err != nil {
+		return errors.Wrapf(err, "driver %s: management address %s is unreachable", scheme, address)
+	}
+	conn.Close()
+
+	if strings.HasPrefix(scheme, "redfish") || strings.HasPrefix(scheme, "idrac") || strings.HasPrefix(scheme, "ilo") {
+		return validateRedfish(scheme, address, host)
+	}
+	return nil
+}
+
+// validateIPMIPing sends an RMCP ASF presence ping (the unauthenticated
+// probe ipmitool uses) and waits for any response. UDP gives no
+// connection-refused semantics on silent drops, so a timeout without a
+// response is reported as unreachable while an answered ping - or an
+// ICMP port rejection surfacing as a read error - proves something
+// listens at the address.
+func validateIPMIPing(address string) error {
+	conn, err := net.DialTimeout("udp", address, bmcDialTimeout)
+	if err != nil {
+		return errors.Wrapf(err, "driver ipmi: management address %s is unreachable", address)
+	}
+	defer conn.Close()
+
+	// RMCP header + ASF presence ping (IANA enterprise 4542).
+	ping := []byte{
+		0x06, 0x00, 0xff, 0x06, // RMCP version, reserved, seq, class ASF
+		0x00, 0x00, 0x11, 0xbe, // ASF IANA enterprise number
+		0x80, 0x00, 0x00, 0x00, // presence ping, tag, reserved, data length
+	}
+	if _, err := conn.Write(ping); err != nil {
+		return errors.Wrapf(err, "driver ipmi: failed to send the RMCP ping to %s", address)
+	}
+	if err := conn.SetReadDeadline(time.Now().Add(bmcDialTimeout)); err != nil {
+		return err
+	}
+	response := make([]byte, 64)
+	if _, err := conn.Read(response); err != nil {
+		if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+			return errors.Errorf("driver ipmi: no RMCP pong from %s within %s", address, bmcDialTimeout)
+		}
+		// An ICMP port-unreachable still proves a host is there, but
+		// nothing answers RMCP on it.
+		return errors.Wrapf(err, "driver ipmi: RMCP ping to %s was rejected", address)
+	}
+	return nil
+}
+
+// validateRedfish performs an authenticated GET of the Systems
+// collection so bad credentials surface per host.
+func validateRedfish(scheme, address string, host *baremetaltypes.Host) error {
+	client := &http.Client{
+		Timeout: bmcDialTimeout,
+		Transport: &http.Transport{
+			// BMCs almost universally serve self-signed certificates.
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		},
+	}
+	request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("https://%s/redfish/v1/Systems", address), nil)
+	if err != nil {
+		return err
+	}
+	request.SetBasicAuth(host.BMC.Username, host.BMC.Password)
+	response, err := client.Do(request)
+	if err != nil {
+		return errors.Wrapf(err, "driver %s: redfish request failed", scheme)
+	}
+	defer response.Body.Close()
+	if response.StatusCode == http.StatusUnauthorized || response.StatusCode == http.StatusForbidden {
+		return errors.Errorf("driver %s: the BMC rejected the credentials (%s)", scheme, response.Status)
+	}
+	if response.StatusCode >= 400 {
+		return errors.Errorf("driver %s: the Systems collection returned %s", scheme, response.Status)
+	}
+	return nil
+}
@@ -33,6 +33,16 @@ func (a *baseDomain) Generate(parents asset.Parents) error {
 	platform := &platform{}
 	parents.Get(platform)
 
+	if value, answered, err := surveyValue(baseDomainEnv, "Base Domain"); err != nil {
+		return err
+	} else if answered {
+		if err := validate.DomainName(value, true); err != nil {
+			return errors.Wrapf(err, "invalid %s", baseDomainEnv)
+		}
+		a.BaseDomain = value
+		return nil
+	}
+
 	switch platform.CurrentName() {
 	case aws.Name:
 		var err error
@@ -1,6 +1,7 @@
 package installconfig
 
 import (
+	"github.com/pkg/errors"
 	survey "gopkg.in/AlecAivazis/survey.v1"
 
 	"github.com/openshift/installer/pkg/asset"
@@ -39,6 +40,16 @@ func (a *clusterName) Generate(parents asset.Parents) error {
 		return validate.DomainName(validation.ClusterDomain(bd.BaseDomain, ans.(string)), false)
 	})
 
+	if value, answered, err := surveyValue(clusterNameEnv, "Cluster Name"); err != nil {
+		return err
+	} else if answered {
+		if err := validator(value); err != nil {
+			return errors.Wrapf(err, "invalid %s", clusterNameEnv)
+		}
+		a.ClusterName = value
+		return nil
+	}
+
 	return survey.Ask([]*survey.Question{
 		{
 			Prompt: &survey.Input{
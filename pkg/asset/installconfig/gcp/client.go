@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/asset/installconfig/internal/memoize"
 	compute "google.golang.org/api/compute/v1"
 	dns "google.golang.org/api/dns/v1"
 	"google.golang.org/api/option"
@@ -20,11 +22,21 @@ type API interface {
 	GetPublicDomains(ctx context.Context, project string) ([]string, error)
 	GetPublicDNSZone(ctx context.Context, baseDomain, project string) (*dns.ManagedZone, error)
 	GetSubnetworks(ctx context.Context, network, project, region string) ([]*compute.Subnetwork, error)
+	GetNodeGroup(ctx context.Context, project, zone, name string) (*compute.NodeGroup, error)
+	GetAcceleratorTypes(ctx context.Context, project, zone string) ([]string, error)
 }
 
 // Client makes calls to the GCP API.
 type Client struct {
 	ssn *Session
+
+	cache memoize.Cache
+}
+
+// memoize returns the cached result for key, computing and caching it
+// with fetch on the first call. Errors are not cached.
+func (c *Client) memoize(key string, fetch func() (interface{}, error)) (interface{}, error) {
+	return c.cache.Do(key, fetch)
 }
 
 // NewClient initializes a client with a session.
@@ -45,18 +57,24 @@ func NewClient(ctx context.Context) (*Client, error) {
 
 // GetNetwork uses the GCP Compute Service API to get a network by name from a project.
 func (c *Client) GetNetwork(ctx context.Context, network, project string) (*compute.Network, error) {
-	ctx, cancel := context.WithTimeout(ctx, 1*time.Minute)
-	defer cancel()
+	res, err := c.memoize(fmt.Sprintf("network/%s/%s", project, network), func() (interface{}, error) {
+		ctx, cancel := context.WithTimeout(ctx, 1*time.Minute)
+		defer cancel()
 
-	svc, err := c.getComputeService(ctx)
+		svc, err := c.getComputeService(ctx)
+		if err != nil {
+			return nil, err
+		}
+		res, err := svc.Networks.Get(project, network).Context(ctx).Do()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get network %s", network)
+		}
+		return res, nil
+	})
 	if err != nil {
 		return nil, err
 	}
-	res, err := svc.Networks.Get(project, network).Context(ctx).Do()
-	if err != nil {
-		return nil, errors.Wrapf(err, "failed to get network %s", network)
-	}
-	return res, nil
+	return res.(*compute.Network), nil
 }
 
 // GetPublicDomains returns all of the domains from among the project's public DNS zones.
@@ -114,26 +132,32 @@ func (c *Client) GetPublicDNSZone(ctx context.Context, project, baseDomain strin
 
 // GetSubnetworks uses the GCP Compute Service API to retrieve all subnetworks in a given network.
 func (c *Client) GetSubnetworks(ctx context.Context, network, project, region string) ([]*compute.Subnetwork, error) {
-	ctx, cancel := context.WithTimeout(ctx, 1*time.Minute)
-	defer cancel()
+	cached, err := c.memoize(fmt.Sprintf("subnetworks/%s/%s/%s", project, region, network), func() (interface{}, error) {
+		ctx, cancel := context.WithTimeout(ctx, 1*time.Minute)
+		defer cancel()
 
-	svc, err := c.getComputeService(ctx)
-	if err != nil {
-		return nil, err
-	}
+		svc, err := c.getComputeService(ctx)
+		if err != nil {
+			return nil, err
+		}
 
-	filter := fmt.Sprintf("network eq .*%s", network)
-	req := svc.Subnetworks.List(project, region).Filter(filter)
-	var res []*compute.Subnetwork
-	if err := req.Pages(ctx, func(page *compute.SubnetworkList) error {
-		for _, subnet := range page.Items {
-			res = append(res, subnet)
+		filter := fmt.Sprintf("network eq .*%s", network)
+		req := svc.Subnetworks.List(project, region).Filter(filter)
+		var res []*compute.Subnetwork
+		if err := req.Pages(ctx, func(page *compute.SubnetworkList) error {
+			for _, subnet := range page.Items {
+				res = append(res, subnet)
+			}
+			return nil
+		}); err != nil {
+			return nil, err
 		}
-		return nil
-	}); err != nil {
+		return res, nil
+	})
+	if err != nil {
 		return nil, err
 	}
-	return res, nil
+	return cached.([]*compute.Subnetwork), nil
 }
 
 func (c *Client) getComputeService(ctx context.Context) (*compute.Service, error) {
@@ -151,3 +175,55 @@ func (c *Client) getDNSService(ctx context.Context) (*dns.Service, error) {
 	}
 	return svc, nil
 }
+
+// GetNodeGroup uses the GCP Compute Service API to get a sole-tenant
+// node group by name in a zone.
+func (c *Client) GetNodeGroup(ctx context.Context, project, zone, name string) (*compute.NodeGroup, error) {
+	cached, err := c.memoize(fmt.Sprintf("nodegroup/%s/%s/%s", project, zone, name), func() (interface{}, error) {
+		ctx, cancel := context.WithTimeout(ctx, 1*time.Minute)
+		defer cancel()
+
+		svc, err := c.getComputeService(ctx)
+		if err != nil {
+			return nil, err
+		}
+		res, err := svc.NodeGroups.Get(project, zone, name).Context(ctx).Do()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get node group %s in zone %s", name, zone)
+		}
+		return res, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cached.(*compute.NodeGroup), nil
+}
+
+// GetAcceleratorTypes uses the GCP Compute Service API to list the
+// accelerator types available in a zone.
+func (c *Client) GetAcceleratorTypes(ctx context.Context, project, zone string) ([]string, error) {
+	cached, err := c.memoize(fmt.Sprintf("acceleratortypes/%s/%s", project, zone), func() (interface{}, error) {
+		ctx, cancel := context.WithTimeout(ctx, 1*time.Minute)
+		defer cancel()
+
+		svc, err := c.getComputeService(ctx)
+		if err != nil {
+			return nil, err
+		}
+		types := []string{}
+		req := svc.AcceleratorTypes.List(project, zone)
+		if err := req.Pages(ctx, func(page *compute.AcceleratorTypeList) error {
+			for _, acceleratorType := range page.Items {
+				types = append(types, acceleratorType.Name)
+			}
+			return nil
+		}); err != nil {
+			return nil, errors.Wrapf(err, "failed to list accelerator types in zone %s", zone)
+		}
+		return types, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cached.([]string), nil
+}
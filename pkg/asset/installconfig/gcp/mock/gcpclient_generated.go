@@ -94,3 +94,33 @@ func (mr *MockAPIMockRecorder) GetSubnetworks(ctx, network, project, region inte
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubnetworks", reflect.TypeOf((*MockAPI)(nil).GetSubnetworks), ctx, network, project, region)
 }
+
+// GetNodeGroup mocks base method
+func (m *MockAPI) GetNodeGroup(ctx context.Context, project, zone, name string) (*v1.NodeGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNodeGroup", ctx, project, zone, name)
+	ret0, _ := ret[0].(*v1.NodeGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNodeGroup indicates an expected call of GetNodeGroup
+func (mr *MockAPIMockRecorder) GetNodeGroup(ctx, project, zone, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNodeGroup", reflect.TypeOf((*MockAPI)(nil).GetNodeGroup), ctx, project, zone, name)
+}
+
+// GetAcceleratorTypes mocks base method
+func (m *MockAPI) GetAcceleratorTypes(ctx context.Context, project, zone string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAcceleratorTypes", ctx, project, zone)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAcceleratorTypes indicates an expected call of GetAcceleratorTypes
+func (mr *MockAPIMockRecorder) GetAcceleratorTypes(ctx, project, zone interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAcceleratorTypes", reflect.TypeOf((*MockAPI)(nil).GetAcceleratorTypes), ctx, project, zone)
+}
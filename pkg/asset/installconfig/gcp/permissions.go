@@ -0,0 +1,83 @@
+package gcp
+
+// IAM permissions the installer credentials need. The catalog lives
+// next to the code that makes the API calls; extend it when a new
+// resource type is provisioned.
+var (
+	// createPermissions are needed in all IPI installs.
+	createPermissions = []string{
+		"compute.addresses.create",
+		"compute.addresses.use",
+		"compute.backendServices.create",
+		"compute.backendServices.use",
+		"compute.disks.create",
+		"compute.firewalls.create",
+		"compute.forwardingRules.create",
+		"compute.healthChecks.create",
+		"compute.httpHealthChecks.create",
+		"compute.images.create",
+		"compute.instanceGroups.create",
+		"compute.instanceGroups.update",
+		"compute.instances.create",
+		"compute.instances.setMetadata",
+		"compute.instances.setServiceAccount",
+		"compute.instances.setTags",
+		"compute.targetPools.addInstance",
+		"compute.targetPools.create",
+		"dns.changes.create",
+		"dns.managedZones.create",
+		"dns.resourceRecordSets.create",
+		"iam.serviceAccountKeys.create",
+		"iam.serviceAccounts.actAs",
+		"iam.serviceAccounts.create",
+		"resourcemanager.projects.getIamPolicy",
+		"resourcemanager.projects.setIamPolicy",
+		"storage.buckets.create",
+		"storage.objects.create",
+	}
+
+	// networkingPermissions are additionally needed when the installer
+	// creates the network instead of using a pre-existing one.
+	networkingPermissions = []string{
+		"compute.networks.create",
+		"compute.networks.updatePolicy",
+		"compute.routers.create",
+		"compute.subnetworks.create",
+		"compute.subnetworks.use",
+	}
+
+	// deletePermissions are needed to destroy the cluster.
+	deletePermissions = []string{
+		"compute.addresses.delete",
+		"compute.backendServices.delete",
+		"compute.disks.delete",
+		"compute.firewalls.delete",
+		"compute.forwardingRules.delete",
+		"compute.healthChecks.delete",
+		"compute.httpHealthChecks.delete",
+		"compute.images.delete",
+		"compute.instanceGroups.delete",
+		"compute.instances.delete",
+		"compute.networks.delete",
+		"compute.routers.delete",
+		"compute.subnetworks.delete",
+		"compute.targetPools.delete",
+		"dns.managedZones.delete",
+		"dns.resourceRecordSets.delete",
+		"iam.serviceAccounts.delete",
+		"storage.buckets.delete",
+		"storage.objects.delete",
+	}
+)
+
+// RequiredPermissions returns the IAM permissions the install-config
+// requires: the base and delete catalogs, plus the networking catalog
+// when the installer will create the network.
+func RequiredPermissions(preexistingNetwork bool) []string {
+	permissions := append([]string{}, createPermissions...)
+	if !preexistingNetwork {
+		permissions = append(permissions, networkingPermissions...)
+	}
+	permissions = append(permissions, deletePermissions...)
+	return permissions
+}
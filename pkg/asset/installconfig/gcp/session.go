@@ -11,6 +11,7 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+	"golang.org/x/crypto/ssh/terminal"
 	googleoauth "golang.org/x/oauth2/google"
 	compute "google.golang.org/api/compute/v1"
 	"gopkg.in/AlecAivazis/survey.v1"
@@ -41,6 +42,19 @@ func GetSession(ctx context.Context) (*Session, error) {
 		return nil, errors.Wrap(err, "failed to load credentials")
 	}
 
+	if _, err := creds.TokenSource.Token(); err != nil {
+		// A token fetch is the cheapest smoke test; failing here beats
+		// an opaque API error deep inside asset generation.
+		if !terminal.IsTerminal(int(os.Stdin.Fd())) {
+			return nil, errors.Wrap(err, "the GCP credentials are expired or invalid; refresh the service account and re-run")
+		}
+		logrus.Warnf("The GCP credentials are expired or invalid (%v); please provide new credentials", err)
+		creds, err = getCredentials(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &Session{
 		Credentials: creds,
 	}, nil
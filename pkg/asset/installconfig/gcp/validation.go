@@ -16,6 +16,8 @@ func Validate(client API, ic *types.InstallConfig) error {
 	allErrs := field.ErrorList{}
 
 	allErrs = append(allErrs, validateNetworks(client, ic, field.NewPath("platform").Child("gcp"))...)
+	allErrs = append(allErrs, validateSoleTenancy(client, ic)...)
+	allErrs = append(allErrs, validateGuestAccelerators(client, ic)...)
 
 	return allErrs.ToAggregate()
 }
@@ -37,11 +39,35 @@ func validateNetworks(client API, ic *types.InstallConfig, fieldPath *field.Path
 
 		allErrs = append(allErrs, validateSubnet(client, ic, fieldPath.Child("computeSubnet"), subnets, ic.GCP.ComputeSubnet)...)
 		allErrs = append(allErrs, validateSubnet(client, ic, fieldPath.Child("controlPlaneSubnet"), subnets, ic.GCP.ControlPlaneSubnet)...)
+
+		if ic.Publish == types.InternalPublishingStrategy {
+			allErrs = append(allErrs, validatePrivateEgress(ic, fieldPath.Child("computeSubnet"), subnets, ic.GCP.ComputeSubnet)...)
+			allErrs = append(allErrs, validatePrivateEgress(ic, fieldPath.Child("controlPlaneSubnet"), subnets, ic.GCP.ControlPlaneSubnet)...)
+		}
 	}
 
 	return allErrs
 }
 
+// validatePrivateEgress checks that an internal cluster still has an
+// egress path for pulling the release image: either the pre-existing
+// subnet has Private Google Access enabled or a proxy is configured.
+// Without one of those bootstrap would time out with no clear cause.
+func validatePrivateEgress(ic *types.InstallConfig, fieldPath *field.Path, subnets []*compute.Subnetwork, name string) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	subnet, _ := findSubnet(subnets, name, ic.GCP.Network, ic.GCP.Region)
+	if subnet == nil {
+		// The missing subnet is reported by validateSubnet.
+		return allErrs
+	}
+	if !subnet.PrivateIpGoogleAccess && ic.Proxy == nil {
+		allErrs = append(allErrs, field.Invalid(fieldPath, name,
+			"publish is Internal but the subnet does not have Private Google Access enabled and no proxy is configured; the cluster will have no egress path to pull the release image. Enable Private Google Access or Cloud NAT on the subnet, or configure a proxy"))
+	}
+	return allErrs
+}
+
 func validateSubnet(client API, ic *types.InstallConfig, fieldPath *field.Path, subnets []*compute.Subnetwork, name string) field.ErrorList {
 	allErrs := field.ErrorList{}
 
@@ -77,3 +103,96 @@ func validateMachineNetworksContainIP(fldPath *field.Path, networks []types.Mach
 	}
 	return field.ErrorList{field.Invalid(fldPath, subnetName, fmt.Sprintf("subnet CIDR range start %s is outside of the specified machine networks", ip))}
 }
+
+// validateSoleTenancy checks that every sole-tenant node group a pool
+// names exists in each of the pool's zones. The zones have to be
+// explicit so the pinning and the node groups can be reconciled.
+func validateSoleTenancy(client API, ic *types.InstallConfig) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	pools := []struct {
+		pool    *types.MachinePool
+		fldPath *field.Path
+	}{{ic.ControlPlane, field.NewPath("controlPlane")}}
+	for idx := range ic.Compute {
+		pools = append(pools, struct {
+			pool    *types.MachinePool
+			fldPath *field.Path
+		}{&ic.Compute[idx], field.NewPath("compute").Index(idx)})
+	}
+
+	for _, entry := range pools {
+		if entry.pool == nil || entry.pool.Platform.GCP == nil || entry.pool.Platform.GCP.SoleTenancy == nil {
+			continue
+		}
+		tenancy := entry.pool.Platform.GCP.SoleTenancy
+		if tenancy.NodeGroup == "" {
+			continue
+		}
+		tenancyPath := entry.fldPath.Child("platform", "gcp", "soleTenancy")
+		if len(entry.pool.Platform.GCP.Zones) == 0 {
+			allErrs = append(allErrs, field.Required(tenancyPath, "zones must be explicit so they can be reconciled with where the node group exists"))
+			continue
+		}
+		for _, zone := range entry.pool.Platform.GCP.Zones {
+			group, err := client.GetNodeGroup(context.TODO(), ic.GCP.ProjectID, zone, tenancy.NodeGroup)
+			if err != nil {
+				allErrs = append(allErrs, field.Invalid(tenancyPath.Child("nodeGroup"), tenancy.NodeGroup,
+					fmt.Sprintf("node group not found in zone %s: %v", zone, err)))
+				continue
+			}
+			if group.Size == 0 {
+				allErrs = append(allErrs, field.Invalid(tenancyPath.Child("nodeGroup"), tenancy.NodeGroup,
+					fmt.Sprintf("node group in zone %s has no nodes to host the requested machines", zone)))
+			}
+		}
+	}
+	return allErrs
+}
+
+// validateGuestAccelerators checks that every accelerator type a pool
+// requests is actually offered in each of the pool's zones, so the
+// machine API does not fail asynchronously after the install.
+func validateGuestAccelerators(client API, ic *types.InstallConfig) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	pools := []struct {
+		pool    *types.MachinePool
+		fldPath *field.Path
+	}{{ic.ControlPlane, field.NewPath("controlPlane")}}
+	for idx := range ic.Compute {
+		pools = append(pools, struct {
+			pool    *types.MachinePool
+			fldPath *field.Path
+		}{&ic.Compute[idx], field.NewPath("compute").Index(idx)})
+	}
+
+	for _, entry := range pools {
+		if entry.pool == nil || entry.pool.Platform.GCP == nil || len(entry.pool.Platform.GCP.GuestAccelerators) == 0 {
+			continue
+		}
+		acceleratorsPath := entry.fldPath.Child("platform", "gcp", "guestAccelerators")
+		if len(entry.pool.Platform.GCP.Zones) == 0 {
+			allErrs = append(allErrs, field.Required(acceleratorsPath, "zones must be explicit so accelerator availability can be checked per zone"))
+			continue
+		}
+		for _, zone := range entry.pool.Platform.GCP.Zones {
+			available, err := client.GetAcceleratorTypes(context.TODO(), ic.GCP.ProjectID, zone)
+			if err != nil {
+				allErrs = append(allErrs, field.InternalError(acceleratorsPath, err))
+				break
+			}
+			offered := map[string]bool{}
+			for _, acceleratorType := range available {
+				offered[acceleratorType] = true
+			}
+			for i, accelerator := range entry.pool.Platform.GCP.GuestAccelerators {
+				if !offered[accelerator.Type] {
+					allErrs = append(allErrs, field.Invalid(acceleratorsPath.Index(i).Child("type"), accelerator.Type,
+						fmt.Sprintf("accelerator type is not available in zone %s", zone)))
+				}
+			}
+		}
+	}
+	return allErrs
+}
@@ -33,6 +33,8 @@ var (
 	}
 
 	invalidateNetwork       = func(ic *types.InstallConfig) { ic.GCP.Network = "invalid-vpc" }
+	publishInternal         = func(ic *types.InstallConfig) { ic.Publish = types.InternalPublishingStrategy }
+	configureProxy          = func(ic *types.InstallConfig) { ic.Proxy = &types.Proxy{HTTPSProxy: "https://proxy.example.com:3128"} }
 	invalidateComputeSubnet = func(ic *types.InstallConfig) { ic.GCP.ComputeSubnet = "invalid-compute-subnet" }
 	invalidateCPSubnet      = func(ic *types.InstallConfig) { ic.GCP.ControlPlaneSubnet = "invalid-cp-subnet" }
 	invalidateRegion        = func(ic *types.InstallConfig) { ic.GCP.Region = "us-east4" }
@@ -96,6 +98,18 @@ func TestGCPInstallConfigValidation(t *testing.T) {
 			expectedError:  true,
 			expectedErrMsg: "computeSubnet: Invalid value.*subnet CIDR range start 10.0.0.0 is outside of the specified machine networks",
 		},
+		{
+			name:           "Internal publish without Private Google Access",
+			edits:          editFunctions{publishInternal},
+			expectedError:  true,
+			expectedErrMsg: "does not have Private Google Access enabled and no proxy is configured",
+		},
+		{
+			name:           "Internal publish with proxy",
+			edits:          editFunctions{publishInternal, configureProxy},
+			expectedError:  false,
+			expectedErrMsg: "",
+		},
 		{
 			name:           "Invalid network",
 			edits:          editFunctions{invalidateNetwork},
@@ -12,8 +12,14 @@ import (
 	"github.com/openshift/installer/pkg/asset"
 	"github.com/openshift/installer/pkg/asset/installconfig/aws"
 	icazure "github.com/openshift/installer/pkg/asset/installconfig/azure"
+	icbaremetal "github.com/openshift/installer/pkg/asset/installconfig/baremetal"
 	icgcp "github.com/openshift/installer/pkg/asset/installconfig/gcp"
+	icnone "github.com/openshift/installer/pkg/asset/installconfig/none"
 	icopenstack "github.com/openshift/installer/pkg/asset/installconfig/openstack"
+	icovirt "github.com/openshift/installer/pkg/asset/installconfig/ovirt"
+	installererrors "github.com/openshift/installer/pkg/errors"
+	"github.com/openshift/installer/pkg/metrics"
+	"github.com/openshift/installer/pkg/preflight"
 	"github.com/openshift/installer/pkg/types"
 	"github.com/openshift/installer/pkg/types/conversion"
 	"github.com/openshift/installer/pkg/types/defaults"
@@ -29,6 +35,10 @@ type InstallConfig struct {
 	Config *types.InstallConfig `json:"config"`
 	File   *asset.File          `json:"file"`
 	AWS    *aws.Metadata        `json:"aws,omitempty"`
+
+	// IPAMAllocationIDs are the IDs of the addresses allocated from an
+	// external IPAM service while completing the config.
+	IPAMAllocationIDs []string `json:"ipamAllocationIDs,omitempty"`
 }
 
 var _ asset.WritableAsset = (*InstallConfig)(nil)
@@ -108,9 +118,14 @@ func (a *InstallConfig) Load(f asset.FileFetcher) (found bool, err error) {
 		return false, err
 	}
 
+	data, err := normalizeYAML(file.Data)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to parse %s", installConfigFilename)
+	}
+
 	config := &types.InstallConfig{}
-	if err := yaml.Unmarshal(file.Data, config); err != nil {
-		return false, errors.Wrapf(err, "failed to unmarshal %s", installConfigFilename)
+	if err := yaml.Unmarshal(data, config); err != nil {
+		return false, errors.Wrapf(yamlParseError(err, data), "failed to unmarshal %s", installConfigFilename)
 	}
 	a.Config = config
 
@@ -129,19 +144,38 @@ func (a *InstallConfig) Load(f asset.FileFetcher) (found bool, err error) {
 func (a *InstallConfig) finish(filename string) error {
 	defaults.SetInstallConfigDefaults(a.Config)
 
+	if err := a.allocateIPAMAddresses(); err != nil {
+		return err
+	}
+
 	if a.Config.AWS != nil {
 		a.AWS = aws.NewMetadata(a.Config.Platform.AWS.Region, a.Config.Platform.AWS.Subnets)
 	}
 
-	if err := validation.ValidateInstallConfig(a.Config, icopenstack.NewValidValuesFetcher()).ToAggregate(); err != nil {
+	validationErrs := validation.ValidateInstallConfig(a.Config, icopenstack.NewValidValuesFetcher())
+	for _, validationError := range validationErrs {
+		metrics.Add("openshift_install_validation_errors_total", map[string]string{"field": validationError.Field}, 1, "Install-config validation errors by field.")
+	}
+	validationErr := validationErrs.ToAggregate()
+	preflight.Record("install-config-validation", validationErr == nil, map[string]string{
+		"platform": a.Config.Platform.Name(),
+		"publish":  string(a.Config.Publish),
+	})
+	if validationErr != nil {
+		preflight.WriteMetrics()
 		if filename == "" {
-			return errors.Wrap(err, "invalid install config")
+			return installererrors.Classify(errors.Wrap(validationErr, "invalid install config"), installererrors.ErrInvalidInstallConfig)
 		}
-		return errors.Wrapf(err, "invalid %q file", filename)
+		return installererrors.Classify(errors.Wrapf(validationErr, "invalid %q file", filename), installererrors.ErrInvalidInstallConfig)
 	}
 
-	if err := a.platformValidation(); err != nil {
-		return err
+	platformErr := a.platformValidation()
+	preflight.Record("platform-validation", platformErr == nil, map[string]string{
+		"platform": a.Config.Platform.Name(),
+	})
+	preflight.WriteMetrics()
+	if platformErr != nil {
+		return platformErr
 	}
 
 	data, err := yaml.Marshal(a.Config)
@@ -173,5 +207,14 @@ func (a *InstallConfig) platformValidation() error {
 	if a.Config.Platform.AWS != nil {
 		return aws.Validate(context.TODO(), a.AWS, a.Config)
 	}
+	if a.Config.Platform.None != nil {
+		return icnone.ValidateDNS(a.Config)
+	}
+	if a.Config.Platform.Ovirt != nil {
+		return icovirt.ValidateAffinityGroups(a.Config)
+	}
+	if a.Config.Platform.BareMetal != nil {
+		return icbaremetal.ValidateBMCs(a.Config)
+	}
 	return field.ErrorList{}.ToAggregate()
 }
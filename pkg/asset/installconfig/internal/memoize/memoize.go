@@ -0,0 +1,32 @@
+// Package memoize caches the answers the cloud clients fetch during a
+// run. The installer asks the same questions from several validators
+// and asset generators; one cache per client saves round-trips and
+// rate limit.
+package memoize
+
+import "sync"
+
+// Cache memoizes keyed lookups. The zero value is ready to use.
+type Cache struct {
+	mutex sync.Mutex
+	cache map[string]interface{}
+}
+
+// Do returns the cached result for key, computing and caching it with
+// fetch on the first call. Errors are not cached.
+func (c *Cache) Do(key string, fetch func() (interface{}, error)) (interface{}, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.cache == nil {
+		c.cache = map[string]interface{}{}
+	}
+	if cached, ok := c.cache[key]; ok {
+		return cached, nil
+	}
+	result, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+	c.cache[key] = result
+	return result, nil
+}
@@ -0,0 +1,47 @@
+package memoize
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDoExecutesEachQueryOnce(t *testing.T) {
+	cache := &Cache{}
+	calls := map[string]int{}
+	fetch := func(key string) func() (interface{}, error) {
+		return func() (interface{}, error) {
+			calls[key]++
+			return key + "-result", nil
+		}
+	}
+
+	for i := 0; i < 3; i++ {
+		result, err := cache.Do("a", fetch("a"))
+		assert.NoError(t, err)
+		assert.Equal(t, "a-result", result)
+		result, err = cache.Do("b", fetch("b"))
+		assert.NoError(t, err)
+		assert.Equal(t, "b-result", result)
+	}
+	assert.Equal(t, map[string]int{"a": 1, "b": 1}, calls)
+}
+
+func TestDoDoesNotCacheErrors(t *testing.T) {
+	cache := &Cache{}
+	calls := 0
+	fetch := func() (interface{}, error) {
+		calls++
+		if calls == 1 {
+			return nil, errors.New("transient")
+		}
+		return "ok", nil
+	}
+	_, err := cache.Do("key", fetch)
+	assert.Error(t, err)
+	result, err := cache.Do("key", fetch)
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", result)
+	assert.Equal(t, 2, calls)
+}
@@ -0,0 +1,67 @@
+package installconfig
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/installer/pkg/ipam"
+)
+
+// allocateIPAMAddresses fills in static addresses the user left unset
+// by allocating them from the configured external IPAM service.
+// Manually-specified addresses always take precedence and are never
+// overwritten. The returned allocation IDs are recorded in the
+// cluster metadata so destroy can release the addresses.
+func (a *InstallConfig) allocateIPAMAddresses() error {
+	config := a.Config.IPAM
+	if config == nil {
+		return nil
+	}
+
+	type target struct {
+		role    string
+		address *string
+	}
+	var targets []target
+	switch {
+	case a.Config.Platform.BareMetal != nil:
+		platform := a.Config.Platform.BareMetal
+		targets = []target{
+			{"api", &platform.APIVIP},
+			{"ingress", &platform.IngressVIP},
+			{"dns", &platform.DNSVIP},
+		}
+	case a.Config.Platform.VSphere != nil:
+		platform := a.Config.Platform.VSphere
+		targets = []target{
+			{"api", &platform.APIVIP},
+			{"ingress", &platform.IngressVIP},
+			{"dns", &platform.DNSVIP},
+		}
+	default:
+		logrus.Debug("IPAM is configured but the platform has no static addresses to allocate")
+		return nil
+	}
+
+	ctx := context.TODO()
+	for _, t := range targets {
+		if *t.address != "" {
+			logrus.Debugf("Keeping manually-specified %s address %s", t.role, *t.address)
+			continue
+		}
+		pool, ok := config.Pools[t.role]
+		if !ok {
+			continue
+		}
+		allocations, err := ipam.Allocate(ctx, config, pool, 1)
+		if err != nil {
+			return errors.Wrapf(err, "failed to allocate the %s address from IPAM", t.role)
+		}
+		logrus.Infof("Allocated %s address %s from IPAM pool %q", t.role, allocations[0].Address, pool)
+		*t.address = allocations[0].Address
+		a.IPAMAllocationIDs = append(a.IPAMAllocationIDs, allocations[0].ID)
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+// Package none contains pre-flight validation for the none platform.
+package none
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/openshift/installer/pkg/preflight"
+	"github.com/openshift/installer/pkg/types"
+)
+
+const (
+	// strictDNSValidationEnv turns the DNS pre-flight findings into
+	// validation errors instead of warnings.
+	strictDNSValidationEnv = "OPENSHIFT_INSTALL_STRICT_DNS_VALIDATION"
+	// skipDNSValidationEnv skips the DNS pre-flight entirely.
+	skipDNSValidationEnv = "OPENSHIFT_INSTALL_SKIP_DNS_VALIDATION"
+
+	dnsLookupTimeout = 10 * time.Second
+)
+
+// ValidateDNS performs a best-effort pre-flight check of the DNS
+// records a none-platform cluster needs before the install starts,
+// so that missing or misdirected api/api-int/*.apps records are
+// reported up front instead of failing late during bootstrap.  The
+// findings are warnings by default; setting
+// OPENSHIFT_INSTALL_STRICT_DNS_VALIDATION=true turns them into
+// errors.
+func ValidateDNS(config *types.InstallConfig) error {
+	if os.Getenv(skipDNSValidationEnv) == "true" {
+		logrus.Debug("Skipping DNS pre-flight validation")
+		return nil
+	}
+
+	domain := config.ClusterDomain()
+	problems := []*field.Error{}
+
+	for _, record := range []string{"api", "api-int"} {
+		host := fmt.Sprintf("%s.%s", record, domain)
+		addrs, err := lookup(host)
+		if err != nil {
+			problems = append(problems, field.Invalid(field.NewPath("metadata", "name"), host,
+				fmt.Sprintf("%s does not resolve against the system resolver: %v", host, err)))
+			continue
+		}
+		if !anyInMachineNetwork(addrs, config) {
+			problems = append(problems, field.Invalid(field.NewPath("networking", "machineNetwork"), host,
+				fmt.Sprintf("%s resolves to %v, which is not within the machine network", host, addrs)))
+		}
+	}
+
+	// Any name below apps should hit the wildcard ingress record.
+	appsProbe := fmt.Sprintf("test.apps.%s", domain)
+	if _, err := lookup(appsProbe); err != nil {
+		problems = append(problems, field.Invalid(field.NewPath("metadata", "name"), fmt.Sprintf("*.apps.%s", domain),
+			fmt.Sprintf("wildcard record probe %s does not resolve against the system resolver: %v", appsProbe, err)))
+	}
+
+	preflight.Record("dns-validation", len(problems) == 0, map[string]string{
+		"platform": "none",
+	})
+	if len(problems) == 0 {
+		return nil
+	}
+
+	if os.Getenv(strictDNSValidationEnv) == "true" {
+		return field.ErrorList(problems).ToAggregate()
+	}
+	for _, problem := range problems {
+		logrus.Warnf("DNS pre-flight: %s", problem.Detail)
+	}
+	logrus.Warnf("Set %s=true to fail the install on the DNS findings above", strictDNSValidationEnv)
+	return nil
+}
+
+// lookup resolves host against the system resolver with a bounded
+// timeout.
+func lookup(host string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.TODO(), dnsLookupTimeout)
+	defer cancel()
+	return net.DefaultResolver.LookupHost(ctx, host)
+}
+
+// anyInMachineNetwork reports whether at least one of the addresses
+// falls inside one of the configured machine networks.  Records that
+// resolve but live outside the machine network are suspicious for
+// api/api-int, which must reach the machines directly.
+func anyInMachineNetwork(addrs []string, config *types.InstallConfig) bool {
+	for _, addr := range addrs {
+		ip := net.ParseIP(addr)
+		if ip == nil {
+			continue
+		}
+		for _, network := range config.Networking.MachineNetwork {
+			if network.CIDR.Contains(ip) {
+				return true
+			}
+		}
+	}
+	return false
+}
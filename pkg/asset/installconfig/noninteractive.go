@@ -0,0 +1,34 @@
+package installconfig
+
+import (
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+// Environment equivalents for the survey questions, so automation can
+// produce an install-config entirely from parameters.
+const (
+	platformEnv    = "OPENSHIFT_INSTALL_PLATFORM"
+	baseDomainEnv  = "OPENSHIFT_INSTALL_BASE_DOMAIN"
+	clusterNameEnv = "OPENSHIFT_INSTALL_CLUSTER_NAME"
+	pullSecretEnv  = "OPENSHIFT_INSTALL_PULL_SECRET"
+	sshKeyPathEnv  = "OPENSHIFT_INSTALL_SSH_PUB_KEY_PATH"
+)
+
+// surveyValue answers a survey question from its environment
+// equivalent. When the environment carries no answer and no terminal
+// is attached, it errors naming the variable instead of letting the
+// survey hang.
+func surveyValue(env string, question string) (value string, answered bool, err error) {
+	if value, ok := os.LookupEnv(env); ok {
+		logrus.Debugf("Answering %q from %s", question, env)
+		return value, true, nil
+	}
+	if !terminal.IsTerminal(int(os.Stdin.Fd())) {
+		return "", false, errors.Errorf("no terminal to ask for %q; set %s", question, env)
+	}
+	return "", false, nil
+}
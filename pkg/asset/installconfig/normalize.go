@@ -0,0 +1,63 @@
+package installconfig
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+var utf8BOM = []byte{0xef, 0xbb, 0xbf}
+
+// normalizeYAML prepares user-written YAML for parsing: it strips a
+// UTF-8 byte order mark, converts CRLF line endings, and rejects tab
+// indentation with the offending line number, since the YAML parser's
+// own errors for these point at the wrong place or silently mis-parse
+// lists.
+func normalizeYAML(data []byte) ([]byte, error) {
+	data = bytes.TrimPrefix(data, utf8BOM)
+	data = bytes.ReplaceAll(data, []byte("\r\n"), []byte("\n"))
+
+	for number, line := range strings.Split(string(data), "\n") {
+		trimmed := strings.TrimLeft(line, " \t")
+		indent := line[:len(line)-len(trimmed)]
+		if strings.Contains(indent, "\t") {
+			return nil, errors.Errorf("line %d is indented with a tab; YAML requires spaces:\n%s", number+1, surroundingLines(data, number))
+		}
+	}
+	return data, nil
+}
+
+// yamlParseError decorates a parse error with the lines surrounding
+// the reported line so the user can see the problem in context.
+func yamlParseError(err error, data []byte) error {
+	message := err.Error()
+	var line int
+	if _, scanErr := fmt.Sscanf(message, "error converting YAML to JSON: yaml: line %d:", &line); scanErr == nil && line > 0 {
+		return errors.Errorf("%s\n%s", message, surroundingLines(data, line-1))
+	}
+	return err
+}
+
+// surroundingLines returns the numbered lines around index (0-based).
+func surroundingLines(data []byte, index int) string {
+	lines := strings.Split(string(data), "\n")
+	start := index - 2
+	if start < 0 {
+		start = 0
+	}
+	end := index + 3
+	if end > len(lines) {
+		end = len(lines)
+	}
+	var builder strings.Builder
+	for i := start; i < end; i++ {
+		marker := "   "
+		if i == index {
+			marker = ">> "
+		}
+		fmt.Fprintf(&builder, "%s%4d | %s\n", marker, i+1, lines[i])
+	}
+	return strings.TrimSuffix(builder.String(), "\n")
+}
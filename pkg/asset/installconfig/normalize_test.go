@@ -0,0 +1,80 @@
+package installconfig
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/types"
+)
+
+const wellFormedConfig = `apiVersion: v1
+baseDomain: example.com
+metadata:
+  name: test
+platform:
+  none: {}
+pullSecret: '{"auths":{"example.com":{"auth":"c3VwZXIgc2VjcmV0Cg=="}}}'
+`
+
+func TestNormalizeYAMLCorpus(t *testing.T) {
+	cases := []struct {
+		name        string
+		data        string
+		expectedErr string
+	}{
+		{
+			name: "plain",
+			data: wellFormedConfig,
+		},
+		{
+			name: "utf-8 BOM",
+			data: "\xef\xbb\xbf" + wellFormedConfig,
+		},
+		{
+			name: "CRLF line endings",
+			data: strings.ReplaceAll(wellFormedConfig, "\n", "\r\n"),
+		},
+		{
+			name: "BOM and CRLF",
+			data: "\xef\xbb\xbf" + strings.ReplaceAll(wellFormedConfig, "\n", "\r\n"),
+		},
+		{
+			name:        "tab indentation",
+			data:        strings.Replace(wellFormedConfig, "  name: test", "\tname: test", 1),
+			expectedErr: "line 4 is indented with a tab",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			normalized, err := normalizeYAML([]byte(tc.data))
+			if tc.expectedErr != "" {
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), tc.expectedErr)
+					// The error shows the offending line in context.
+					assert.Contains(t, err.Error(), ">> ")
+				}
+				return
+			}
+			if assert.NoError(t, err) {
+				config := &types.InstallConfig{}
+				if assert.NoError(t, yaml.Unmarshal(normalized, config)) {
+					assert.Equal(t, "test", config.ObjectMeta.Name)
+					assert.Equal(t, "example.com", config.BaseDomain)
+				}
+			}
+		})
+	}
+}
+
+func TestYAMLParseErrorIncludesContext(t *testing.T) {
+	broken := strings.Replace(wellFormedConfig, "platform:", "platform: [", 1)
+	config := &types.InstallConfig{}
+	err := yaml.Unmarshal([]byte(broken), config)
+	if assert.Error(t, err) {
+		decorated := yamlParseError(err, []byte(broken))
+		assert.Contains(t, decorated.Error(), "platform: [")
+	}
+}
@@ -0,0 +1,44 @@
+package openstack
+
+import (
+	"strings"
+
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/flavors"
+	"github.com/gophercloud/utils/openstack/clientconfig"
+	"github.com/pkg/errors"
+)
+
+// IsBareMetalFlavor reports whether the named flavor schedules onto
+// bare-metal (Ironic) nodes instead of Nova VMs, detected from the
+// flavor's extra specs.
+func IsBareMetalFlavor(cloud string, flavorName string) (bool, error) {
+	opts := &clientconfig.ClientOpts{Cloud: cloud}
+	conn, err := clientconfig.NewServiceClient("compute", opts)
+	if err != nil {
+		return false, err
+	}
+
+	flavorID, err := flavors.IDFromName(conn, flavorName)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to resolve flavor %q", flavorName)
+	}
+
+	specs, err := flavors.ListExtraSpecs(conn, flavorID).Extract()
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to read extra specs of flavor %q", flavorName)
+	}
+
+	// Ironic flavors either claim a custom bare-metal resource class or
+	// carry the legacy baremetal capability.
+	for key, value := range specs {
+		if strings.HasPrefix(key, "resources:CUSTOM_BAREMETAL") && value != "0" {
+			return true, nil
+		}
+		if key == "capabilities:boot_option" || key == "baremetal" {
+			if strings.EqualFold(value, "true") || key == "capabilities:boot_option" {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
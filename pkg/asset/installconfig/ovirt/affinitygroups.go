@@ -0,0 +1,136 @@
+package ovirt
+
+import (
+	"fmt"
+
+	ovirtsdk "github.com/ovirt/go-ovirt"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/installer/pkg/types"
+)
+
+// ValidateAffinityGroups checks that the oVirt cluster has enough
+// hosts to satisfy an enforcing anti-affinity group for the control
+// plane, failing before the install instead of leaving the scheduler
+// unable to place masters.
+func ValidateAffinityGroups(config *types.InstallConfig) error {
+	platform := config.Platform.Ovirt
+	enforcing := false
+	for _, group := range platform.AffinityGroups {
+		if group.Enforcing {
+			enforcing = true
+		}
+	}
+	if !enforcing {
+		return nil
+	}
+
+	masters := int64(3)
+	if config.ControlPlane != nil && config.ControlPlane.Replicas != nil {
+		masters = *config.ControlPlane.Replicas
+	}
+
+	ovirtConfig, err := NewConfig()
+	if err != nil {
+		return err
+	}
+	connection, err := GetConnection(ovirtConfig)
+	if err != nil {
+		return errors.Wrap(err, "failed to construct connection to oVirt platform")
+	}
+	defer connection.Close()
+
+	clusterResponse, err := connection.SystemService().ClustersService().ClusterService(platform.ClusterID).Get().Send()
+	if err != nil {
+		return errors.Wrapf(err, "failed to look up oVirt cluster %s", platform.ClusterID)
+	}
+	clusterName := clusterResponse.MustCluster().MustName()
+
+	hostsResponse, err := connection.SystemService().HostsService().List().
+		Search(fmt.Sprintf("cluster=%s", clusterName)).Send()
+	if err != nil {
+		return errors.Wrapf(err, "failed to list hosts of oVirt cluster %s", clusterName)
+	}
+	hosts := int64(len(hostsResponse.MustHosts().Slice()))
+	if hosts < masters {
+		return errors.Errorf(
+			"an enforcing anti-affinity group needs at least as many hosts as control plane replicas: cluster %s has %d host(s) but the control plane wants %d; add hosts, drop the enforcing flag or reduce the replicas",
+			clusterName, hosts, masters)
+	}
+	return nil
+}
+
+// CreateAffinityGroups creates the configured affinity groups in the
+// oVirt cluster and attaches the control-plane VMs to them. The oVirt
+// engine only accepts VM membership after the VMs exist, so this runs
+// after terraform has created them.
+func CreateAffinityGroups(config *types.InstallConfig, infraID string) error {
+	platform := config.Platform.Ovirt
+	if len(platform.AffinityGroups) == 0 {
+		return nil
+	}
+
+	ovirtConfig, err := NewConfig()
+	if err != nil {
+		return err
+	}
+	connection, err := GetConnection(ovirtConfig)
+	if err != nil {
+		return errors.Wrap(err, "failed to construct connection to oVirt platform")
+	}
+	defer connection.Close()
+
+	groupsService := connection.SystemService().ClustersService().
+		ClusterService(platform.ClusterID).AffinityGroupsService()
+
+	mastersResponse, err := connection.SystemService().VmsService().List().
+		Search(fmt.Sprintf("name=%s-master-*", infraID)).Send()
+	if err != nil {
+		return errors.Wrap(err, "failed to list control-plane VMs")
+	}
+	masters := mastersResponse.MustVms().Slice()
+
+	// The control-plane VMs only join the groups their pool names;
+	// groups a compute pool names are created here and joined by the
+	// machine API workers later.
+	masterGroups := map[string]bool{}
+	if config.ControlPlane != nil && config.ControlPlane.Platform.Ovirt != nil {
+		for _, name := range config.ControlPlane.Platform.Ovirt.AffinityGroupsNames {
+			masterGroups[name] = true
+		}
+	}
+
+	for _, group := range platform.AffinityGroups {
+		if group.Priority != 0 {
+			// The SDK in use cannot set a group's priority.
+			logrus.Warnf("Affinity group %s: the bundled oVirt SDK cannot set the group priority; %d is recorded in the install config only", group.Name, group.Priority)
+		}
+		builder := ovirtsdk.NewAffinityGroupBuilder().
+			Name(group.Name).
+			Description(group.Description).
+			Enforcing(group.Enforcing).
+			Positive(group.Positive)
+		sdkGroup, err := builder.Build()
+		if err != nil {
+			return errors.Wrapf(err, "failed to build affinity group %s", group.Name)
+		}
+		addResponse, err := groupsService.Add().Group(sdkGroup).Send()
+		if err != nil {
+			return errors.Wrapf(err, "failed to create affinity group %s", group.Name)
+		}
+		created := addResponse.MustGroup()
+		logrus.Infof("Created affinity group %s", group.Name)
+
+		if !masterGroups[group.Name] {
+			continue
+		}
+		vmsService := groupsService.GroupService(created.MustId()).VmsService()
+		for _, vm := range masters {
+			if _, err := vmsService.Add().Vm(vm).Send(); err != nil {
+				return errors.Wrapf(err, "failed to attach VM %s to affinity group %s", vm.MustName(), group.Name)
+			}
+		}
+	}
+	return nil
+}
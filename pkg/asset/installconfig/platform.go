@@ -97,6 +97,19 @@ func (a *platform) Name() string {
 }
 
 func (a *platform) queryUserForPlatform() (platform string, err error) {
+	if value, answered, err := surveyValue(platformEnv, "Platform"); err != nil {
+		return "", err
+	} else if answered {
+		// The environment may also pick the hidden-but-supported
+		// platforms the wizard does not offer.
+		for _, name := range append(append([]string{}, types.PlatformNames...), types.HiddenPlatformNames...) {
+			if name == value {
+				return value, nil
+			}
+		}
+		return "", errors.Errorf("invalid platform %q in %s", value, platformEnv)
+	}
+
 	err = survey.Ask([]*survey.Question{
 		{
 			Prompt: &survey.Select{
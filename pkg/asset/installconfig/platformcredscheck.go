@@ -7,6 +7,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/openshift/installer/pkg/asset"
+	awsconfig "github.com/openshift/installer/pkg/asset/installconfig/aws"
 	azureconfig "github.com/openshift/installer/pkg/asset/installconfig/azure"
 	gcpconfig "github.com/openshift/installer/pkg/asset/installconfig/gcp"
 	openstackconfig "github.com/openshift/installer/pkg/asset/installconfig/openstack"
@@ -46,10 +47,13 @@ func (a *PlatformCredsCheck) Generate(dependencies asset.Parents) error {
 	platform := ic.Config.Platform.Name()
 	switch platform {
 	case aws.Name:
-		_, err := ic.AWS.Session(ctx)
+		ssn, err := ic.AWS.Session(ctx)
 		if err != nil {
 			return err
 		}
+		if err := awsconfig.CheckCredentialLifetime(ssn); err != nil {
+			return err
+		}
 	case gcp.Name:
 		_, err = gcpconfig.GetSession(ctx)
 		if err != nil {
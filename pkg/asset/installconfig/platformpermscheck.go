@@ -54,7 +54,7 @@ func (a *PlatformPermsCheck) Generate(dependencies asset.Parents) error {
 			return err
 		}
 
-		err = awsconfig.ValidateCreds(ssn, permissionGroups, ic.Config.Platform.AWS.Region)
+		err = awsconfig.ValidateCreds(ssn, awsconfig.Permissions(permissionGroups), ic.Config.Platform.AWS.Region)
 		if err != nil {
 			return errors.Wrap(err, "validate AWS credentials")
 		}
@@ -1,6 +1,7 @@
 package installconfig
 
 import (
+	"github.com/pkg/errors"
 	survey "gopkg.in/AlecAivazis/survey.v1"
 
 	"github.com/openshift/installer/pkg/asset"
@@ -20,6 +21,16 @@ func (a *pullSecret) Dependencies() []asset.Asset {
 
 // Generate queries for the pull secret from the user.
 func (a *pullSecret) Generate(asset.Parents) error {
+	if value, answered, err := surveyValue(pullSecretEnv, "Pull Secret"); err != nil {
+		return err
+	} else if answered {
+		if err := validate.ImagePullSecret(value); err != nil {
+			return errors.Wrapf(err, "invalid %s", pullSecretEnv)
+		}
+		a.PullSecret = value
+		return nil
+	}
+
 	return survey.Ask([]*survey.Question{
 		{
 			Prompt: &survey.Password{
@@ -47,6 +47,20 @@ func readSSHKey(path string) (string, error) {
 
 // Generate generates the SSH public key asset.
 func (a *sshPublicKey) Generate(asset.Parents) error {
+	if path, answered, err := surveyValue(sshKeyPathEnv, "SSH Public Key"); err != nil {
+		return err
+	} else if answered {
+		if path == "" {
+			return nil
+		}
+		key, err := readSSHKey(path)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read the public key from %s", sshKeyPathEnv)
+		}
+		a.Key = string(key)
+		return nil
+	}
+
 	pubKeys := map[string]string{
 		noSSHKey: "",
 	}
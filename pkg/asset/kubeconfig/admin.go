@@ -1,6 +1,7 @@
 package kubeconfig
 
 import (
+	"fmt"
 	"path/filepath"
 
 	"github.com/openshift/installer/pkg/asset"
@@ -10,6 +11,10 @@ import (
 
 var (
 	kubeconfigAdminPath = filepath.Join("auth", "kubeconfig")
+
+	// ContextName overrides the context name written into the admin
+	// kubeconfig; set from the --kubeconfig-context-name flag.
+	ContextName string
 )
 
 // AdminClient is the asset for the admin kubeconfig.
@@ -25,6 +30,7 @@ func (k *AdminClient) Dependencies() []asset.Asset {
 		&tls.AdminKubeConfigClientCertKey{},
 		&tls.KubeAPIServerCompleteCABundle{},
 		&installconfig.InstallConfig{},
+		&installconfig.ClusterID{},
 	}
 }
 
@@ -33,14 +39,19 @@ func (k *AdminClient) Generate(parents asset.Parents) error {
 	ca := &tls.KubeAPIServerCompleteCABundle{}
 	clientCertKey := &tls.AdminKubeConfigClientCertKey{}
 	installConfig := &installconfig.InstallConfig{}
-	parents.Get(ca, clientCertKey, installConfig)
+	clusterID := &installconfig.ClusterID{}
+	parents.Get(ca, clientCertKey, installConfig, clusterID)
 
+	// The infra ID carries the cluster name plus a per-install suffix,
+	// so kubeconfigs merged from several installs of the same cluster
+	// name cannot collide.
 	return k.kubeconfig.generate(
 		ca,
 		clientCertKey,
 		getExtAPIServerURL(installConfig.Config),
-		installConfig.Config.GetName(),
-		"admin",
+		clusterID.InfraID,
+		fmt.Sprintf("admin@%s", clusterID.InfraID),
+		ContextName,
 		kubeconfigAdminPath,
 	)
 }
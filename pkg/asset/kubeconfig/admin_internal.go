@@ -41,6 +41,7 @@ func (k *AdminInternalClient) Generate(parents asset.Parents) error {
 		getIntAPIServerURL(installConfig.Config),
 		installConfig.Config.GetName(),
 		"admin",
+		"",
 		kubeconfigAdminInternalPath,
 	)
 }
@@ -25,8 +25,12 @@ func (k *kubeconfig) generate(
 	apiURL string,
 	cluster string,
 	userName string,
+	contextName string,
 	kubeconfigPath string,
 ) error {
+	if contextName == "" {
+		contextName = userName
+	}
 	k.Config = &clientcmd.Config{
 		Clusters: []clientcmd.NamedCluster{
 			{
@@ -48,14 +52,14 @@ func (k *kubeconfig) generate(
 		},
 		Contexts: []clientcmd.NamedContext{
 			{
-				Name: userName,
+				Name: contextName,
 				Context: clientcmd.Context{
 					Cluster:  cluster,
 					AuthInfo: userName,
 				},
 			},
 		},
-		CurrentContext: userName,
+		CurrentContext: contextName,
 	}
 
 	data, err := yaml.Marshal(k.Config)
@@ -99,7 +103,7 @@ func (k *kubeconfig) load(f asset.FileFetcher, name string) (found bool, err err
 }
 
 func getExtAPIServerURL(ic *types.InstallConfig) string {
-	return fmt.Sprintf("https://api.%s:6443", ic.ClusterDomain())
+	return fmt.Sprintf("https://api.%s:%d", ic.ClusterDomain(), ic.APIPort())
 }
 
 func getIntAPIServerURL(ic *types.InstallConfig) string {
@@ -41,6 +41,7 @@ func (k *Kubelet) Generate(parents asset.Parents) error {
 		getIntAPIServerURL(installConfig.Config),
 		installConfig.Config.GetName(),
 		"kubelet",
+		"",
 		kubeconfigKubeletPath,
 	)
 }
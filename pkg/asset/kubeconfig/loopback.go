@@ -41,6 +41,7 @@ func (k *LoopbackClient) Generate(parents asset.Parents) error {
 		getLoopbackAPIServerURL(installConfig.Config),
 		installConfig.Config.GetName(),
 		"loopback",
+		"",
 		kubeconfigLoopbackPath,
 	)
 }
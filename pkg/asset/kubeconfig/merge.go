@@ -0,0 +1,116 @@
+package kubeconfig
+
+import (
+	"io/ioutil"
+	"os"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	clientcmd "k8s.io/client-go/tools/clientcmd/api/v1"
+)
+
+// Merge merges the kubeconfig at srcPath into the kubeconfig at
+// destPath, so several clusters' credentials can live in one file. An
+// entry (cluster, user or context) already present in the destination
+// under the same name is never overwritten unless force is set; the
+// destination's current-context is preserved when it has one. A
+// missing destination file is created from the source as-is. Both
+// files use the client-go kubeconfig schema, so the result loads with
+// any kubectl or client-go consumer.
+func Merge(destPath, srcPath string, force bool) error {
+	src, err := loadConfig(srcPath)
+	if err != nil {
+		return err
+	}
+
+	dest, err := loadConfig(destPath)
+	if os.IsNotExist(errors.Cause(err)) {
+		dest = &clientcmd.Config{}
+	} else if err != nil {
+		return err
+	}
+
+	if err := mergeConfig(dest, src, force); err != nil {
+		return errors.Wrapf(err, "cannot merge into %s", destPath)
+	}
+
+	data, err := yaml.Marshal(dest)
+	if err != nil {
+		return errors.Wrap(err, "failed to Marshal kubeconfig")
+	}
+	return errors.Wrapf(ioutil.WriteFile(destPath, data, 0600), "failed to write %s", destPath)
+}
+
+// loadConfig reads and unmarshals one kubeconfig.
+func loadConfig(path string) (*clientcmd.Config, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read %s", path)
+	}
+	config := &clientcmd.Config{}
+	if err := yaml.Unmarshal(data, config); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal %s", path)
+	}
+	return config, nil
+}
+
+// mergeConfig folds src into dest, refusing name collisions unless
+// force is set.
+func mergeConfig(dest, src *clientcmd.Config, force bool) error {
+	clusters := map[string]int{}
+	for i, c := range dest.Clusters {
+		clusters[c.Name] = i
+	}
+	authInfos := map[string]int{}
+	for i, u := range dest.AuthInfos {
+		authInfos[u.Name] = i
+	}
+	contexts := map[string]int{}
+	for i, c := range dest.Contexts {
+		contexts[c.Name] = i
+	}
+
+	if !force {
+		for _, c := range src.Clusters {
+			if _, ok := clusters[c.Name]; ok {
+				return errors.Errorf("cluster %q already exists; pass --force to overwrite it", c.Name)
+			}
+		}
+		for _, u := range src.AuthInfos {
+			if _, ok := authInfos[u.Name]; ok {
+				return errors.Errorf("user %q already exists; pass --force to overwrite it", u.Name)
+			}
+		}
+		for _, c := range src.Contexts {
+			if _, ok := contexts[c.Name]; ok {
+				return errors.Errorf("context %q already exists; pass --force to overwrite it", c.Name)
+			}
+		}
+	}
+
+	for _, c := range src.Clusters {
+		if i, ok := clusters[c.Name]; ok {
+			dest.Clusters[i] = c
+		} else {
+			dest.Clusters = append(dest.Clusters, c)
+		}
+	}
+	for _, u := range src.AuthInfos {
+		if i, ok := authInfos[u.Name]; ok {
+			dest.AuthInfos[i] = u
+		} else {
+			dest.AuthInfos = append(dest.AuthInfos, u)
+		}
+	}
+	for _, c := range src.Contexts {
+		if i, ok := contexts[c.Name]; ok {
+			dest.Contexts[i] = c
+		} else {
+			dest.Contexts = append(dest.Contexts, c)
+		}
+	}
+	if dest.CurrentContext == "" {
+		dest.CurrentContext = src.CurrentContext
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package kubeconfig
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/stretchr/testify/assert"
+	clientcmd "k8s.io/client-go/tools/clientcmd/api/v1"
+)
+
+func writeTestConfig(t *testing.T, path, suffix string) {
+	config := &clientcmd.Config{
+		Clusters: []clientcmd.NamedCluster{
+			{Name: "cluster-" + suffix, Cluster: clientcmd.Cluster{Server: "https://api." + suffix + ".example.com:6443"}},
+		},
+		AuthInfos: []clientcmd.NamedAuthInfo{
+			{Name: "admin@cluster-" + suffix, AuthInfo: clientcmd.AuthInfo{Token: "token-" + suffix}},
+		},
+		Contexts: []clientcmd.NamedContext{
+			{Name: "admin@cluster-" + suffix, Context: clientcmd.Context{Cluster: "cluster-" + suffix, AuthInfo: "admin@cluster-" + suffix}},
+		},
+		CurrentContext: "admin@cluster-" + suffix,
+	}
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(path, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func loadTestConfig(t *testing.T, path string) *clientcmd.Config {
+	config, err := loadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return config
+}
+
+func TestMerge(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kubeconfig-merge")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	destPath := filepath.Join(dir, "config")
+	srcAPath := filepath.Join(dir, "kubeconfig-a")
+	srcBPath := filepath.Join(dir, "kubeconfig-b")
+	writeTestConfig(t, srcAPath, "a")
+	writeTestConfig(t, srcBPath, "b")
+
+	// A missing destination is created from the source.
+	assert.NoError(t, Merge(destPath, srcAPath, false))
+
+	// A second cluster merges in without touching the first, and the
+	// result round-trips through the kubeconfig schema.
+	assert.NoError(t, Merge(destPath, srcBPath, false))
+	merged := loadTestConfig(t, destPath)
+	assert.Len(t, merged.Clusters, 2)
+	assert.Len(t, merged.Contexts, 2)
+	assert.Equal(t, "admin@cluster-a", merged.CurrentContext)
+	assert.Equal(t, "token-b", merged.AuthInfos[1].AuthInfo.Token)
+
+	// Merging the same names again collides and leaves the
+	// destination untouched.
+	writeTestConfig(t, srcAPath, "a")
+	rotated := loadTestConfig(t, srcAPath)
+	rotated.AuthInfos[0].AuthInfo.Token = "rotated"
+	data, err := yaml.Marshal(rotated)
+	assert.NoError(t, err)
+	assert.NoError(t, ioutil.WriteFile(srcAPath, data, 0600))
+	err = Merge(destPath, srcAPath, false)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "already exists")
+	}
+	merged = loadTestConfig(t, destPath)
+	assert.Equal(t, "token-a", merged.AuthInfos[0].AuthInfo.Token)
+
+	// force overwrites the colliding entries in place.
+	assert.NoError(t, Merge(destPath, srcAPath, true))
+	merged = loadTestConfig(t, destPath)
+	assert.Equal(t, "rotated", merged.AuthInfos[0].AuthInfo.Token)
+	assert.Len(t, merged.Clusters, 2)
+}
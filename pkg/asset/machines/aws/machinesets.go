@@ -21,17 +21,10 @@ func MachineSets(clusterID string, region string, subnets map[string]string, poo
 	mpool := pool.Platform.AWS
 	azs := mpool.Zones
 
-	total := int64(0)
-	if pool.Replicas != nil {
-		total = *pool.Replicas
-	}
-	numOfAZs := int64(len(azs))
+	zoneCounts := pool.ZoneDistribution(clusterID, azs)
 	var machinesets []*machineapi.MachineSet
 	for idx, az := range mpool.Zones {
-		replicas := int32(total / numOfAZs)
-		if int64(idx) < total%numOfAZs {
-			replicas++
-		}
+		replicas := int32(zoneCounts[idx])
 
 		subnet, ok := subnets[az]
 		if len(subnets) > 0 && !ok {
@@ -59,11 +52,10 @@ func MachineSets(clusterID string, region string, subnets map[string]string, poo
 				Kind:       "MachineSet",
 			},
 			ObjectMeta: metav1.ObjectMeta{
-				Namespace: "openshift-machine-api",
-				Name:      name,
-				Labels: map[string]string{
-					"machine.openshift.io/cluster-api-cluster": clusterID,
-				},
+				Namespace:   "openshift-machine-api",
+				Name:        name,
+				Annotations: placementGroupAnnotations(mpool),
+				Labels:      machineSetLabels(clusterID, mpool),
 			},
 			Spec: machineapi.MachineSetSpec{
 				Replicas: &replicas,
@@ -96,3 +88,45 @@ func MachineSets(clusterID string, region string, subnets map[string]string, poo
 
 	return machinesets, nil
 }
+
+// machineSetLabels returns the machineset labels, including the GPU
+// count hint the cluster autoscaler uses to scale GPU pools from
+// zero.
+func machineSetLabels(clusterID string, mpool *aws.MachinePool) map[string]string {
+	labels := map[string]string{
+		"machine.openshift.io/cluster-api-cluster": clusterID,
+	}
+	if gpus := gpuCount(mpool.InstanceType); gpus > 0 {
+		labels["machine.openshift.io/GPU"] = fmt.Sprintf("%d", gpus)
+	}
+	return labels
+}
+
+// gpuCount returns the number of NVIDIA GPUs an EC2 instance type
+// carries, or 0 for non-GPU families.
+func gpuCount(instanceType string) int64 {
+	gpus := map[string]int64{
+		"p2.xlarge": 1, "p2.8xlarge": 8, "p2.16xlarge": 16,
+		"p3.2xlarge": 1, "p3.8xlarge": 4, "p3.16xlarge": 8, "p3dn.24xlarge": 8,
+		"p4d.24xlarge": 8,
+		"g3s.xlarge":   1, "g3.4xlarge": 1, "g3.8xlarge": 2, "g3.16xlarge": 4,
+		"g4dn.xlarge": 1, "g4dn.2xlarge": 1, "g4dn.4xlarge": 1, "g4dn.8xlarge": 1, "g4dn.16xlarge": 1, "g4dn.12xlarge": 4, "g4dn.metal": 8,
+		"g5.xlarge": 1, "g5.2xlarge": 1, "g5.4xlarge": 1, "g5.8xlarge": 1, "g5.16xlarge": 1, "g5.12xlarge": 4, "g5.24xlarge": 4, "g5.48xlarge": 8,
+	}
+	return gpus[instanceType]
+}
+
+// placementGroupAnnotations records the placement group a compute
+// pool's machines should join. The aws machine provider does not
+// expose placement groups in its spec yet, so the name travels as an
+// annotation.
+func placementGroupAnnotations(mpool *aws.MachinePool) map[string]string {
+	annotations := map[string]string{}
+	if mpool.Placement != nil && mpool.Placement.GroupName != "" {
+		annotations["machine.openshift.io/placement-group-name"] = mpool.Placement.GroupName
+	}
+	if len(annotations) == 0 {
+		return nil
+	}
+	return annotations
+}
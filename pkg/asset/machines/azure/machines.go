@@ -89,6 +89,11 @@ func provider(platform *azure.Platform, mpool *azure.MachinePool, osImage string
 	if err != nil {
 		return nil, err
 	}
+	if mpool.Subnet != "" && role == "worker" {
+		// The pool is pinned to a specific subnet; control plane
+		// placement is never overridden.
+		subnet = mpool.Subnet
+	}
 
 	return &azureprovider.AzureMachineProviderSpec{
 		TypeMeta: metav1.TypeMeta{
@@ -99,9 +104,7 @@ func provider(platform *azure.Platform, mpool *azure.MachinePool, osImage string
 		CredentialsSecret: &corev1.SecretReference{Name: cloudsSecret, Namespace: cloudsSecretNamespace},
 		Location:          platform.Region,
 		VMSize:            mpool.InstanceType,
-		Image: azureprovider.Image{
-			ResourceID: fmt.Sprintf("/resourceGroups/%s/providers/Microsoft.Compute/images/%s", clusterID+"-rg", clusterID),
-		},
+		Image:             providerImage(mpool, clusterID),
 		OSDisk: azureprovider.OSDisk{
 			OSType:     "Linux",
 			DiskSizeGB: mpool.OSDisk.DiskSizeGB,
@@ -111,7 +114,7 @@ func provider(platform *azure.Platform, mpool *azure.MachinePool, osImage string
 		},
 		Zone:                 az,
 		Subnet:               subnet,
-		ManagedIdentity:      fmt.Sprintf("%s-identity", clusterID),
+		ManagedIdentity:      managedIdentity(platform, clusterID),
 		Vnet:                 virtualNetwork,
 		ResourceGroup:        fmt.Sprintf("%s-rg", clusterID),
 		NetworkResourceGroup: networkResourceGroup,
@@ -123,6 +126,23 @@ func ConfigMasters(machines []machineapi.Machine, clusterID string) {
 	//TODO
 }
 
+// providerImage points the machine at the marketplace image when one
+// is configured, and at the image the installer created from the RHCOS
+// VHD otherwise.
+func providerImage(mpool *azure.MachinePool, clusterID string) azureprovider.Image {
+	if mpool.OSImage != nil {
+		return azureprovider.Image{
+			Publisher: mpool.OSImage.Publisher,
+			Offer:     mpool.OSImage.Offer,
+			SKU:       mpool.OSImage.SKU,
+			Version:   mpool.OSImage.Version,
+		}
+	}
+	return azureprovider.Image{
+		ResourceID: fmt.Sprintf("/resourceGroups/%s/providers/Microsoft.Compute/images/%s", clusterID+"-rg", clusterID),
+	}
+}
+
 func getNetworkInfo(platform *azure.Platform, clusterID, role string) (string, string, string, error) {
 	if platform.VirtualNetwork == "" {
 		return fmt.Sprintf("%s-rg", clusterID), fmt.Sprintf("%s-vnet", clusterID), fmt.Sprintf("%s-%s-subnet", clusterID, role), nil
@@ -137,3 +157,13 @@ func getNetworkInfo(platform *azure.Platform, clusterID, role string) (string, s
 		return "", "", "", fmt.Errorf("unrecognized machine role %s", role)
 	}
 }
+
+// managedIdentity returns the identity assigned to the machines: the
+// user-provided identity resource ID when configured, otherwise the
+// identity the installer creates.
+func managedIdentity(platform *azure.Platform, clusterID string) string {
+	if platform.Identity != nil && platform.Identity.UserAssignedIdentityID != "" {
+		return platform.Identity.UserAssignedIdentityID
+	}
+	return fmt.Sprintf("%s-identity", clusterID)
+}
@@ -29,17 +29,10 @@ func MachineSets(clusterID string, config *types.InstallConfig, pool *types.Mach
 	}
 	azs := mpool.Zones
 
-	total := int64(0)
-	if pool.Replicas != nil {
-		total = *pool.Replicas
-	}
-	numOfAZs := int64(len(azs))
+	zoneCounts := pool.ZoneDistribution(clusterID, azs)
 	var machinesets []*clusterapi.MachineSet
 	for idx, az := range azs {
-		replicas := int32(total / numOfAZs)
-		if int64(idx) < total%numOfAZs {
-			replicas++
-		}
+		replicas := int32(zoneCounts[idx])
 		provider, err := provider(platform, mpool, osImage, userDataSecret, clusterID, role, &idx)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to create provider")
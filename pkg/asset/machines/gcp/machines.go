@@ -84,8 +84,8 @@ func provider(clusterID string, platform *gcp.Platform, mpool *gcp.MachinePool,
 		Disks: []*gcpprovider.GCPDisk{{
 			AutoDelete: true,
 			Boot:       true,
-			SizeGb:     128,
-			Type:       "pd-ssd",
+			SizeGb:     diskSizeGB(mpool),
+			Type:       diskType(mpool),
 			Image:      fmt.Sprintf("%s-rhcos-image", clusterID),
 		}},
 		NetworkInterfaces: []*gcpprovider.GCPNetworkInterface{{
@@ -97,6 +97,7 @@ func provider(clusterID string, platform *gcp.Platform, mpool *gcp.MachinePool,
 			Scopes: []string{"https://www.googleapis.com/auth/cloud-platform"},
 		}},
 		Tags:        []string{fmt.Sprintf("%s-%s", clusterID, role)},
+		Labels:      providerLabels(platform, clusterID),
 		MachineType: mpool.InstanceType,
 		Region:      platform.Region,
 		Zone:        az,
@@ -130,3 +131,32 @@ func getNetworks(platform *gcp.Platform, clusterID, role string) (string, string
 		return "", "", fmt.Errorf("unrecognized machine role %s", role)
 	}
 }
+
+// diskType returns the pool's persistent disk type, defaulting to the
+// pd-ssd the installer always used.
+func diskType(mpool *gcp.MachinePool) string {
+	if mpool.OSDisk.DiskType != "" {
+		return mpool.OSDisk.DiskType
+	}
+	return "pd-ssd"
+}
+
+// diskSizeGB returns the pool's disk size, defaulting to 128 GB.
+func diskSizeGB(mpool *gcp.MachinePool) int64 {
+	if mpool.OSDisk.DiskSizeGB != 0 {
+		return mpool.OSDisk.DiskSizeGB
+	}
+	return 128
+}
+
+// providerLabels merges the cluster ownership label with the
+// user-mandated labels for the machines' GCP resources.
+func providerLabels(platform *gcp.Platform, clusterID string) map[string]string {
+	labels := map[string]string{
+		fmt.Sprintf("kubernetes-io-cluster-%s", clusterID): "owned",
+	}
+	for key, value := range platform.UserLabels {
+		labels[key] = value
+	}
+	return labels
+}
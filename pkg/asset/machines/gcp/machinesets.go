@@ -26,17 +26,10 @@ func MachineSets(clusterID string, config *types.InstallConfig, pool *types.Mach
 	mpool := pool.Platform.GCP
 	azs := mpool.Zones
 
-	total := int64(0)
-	if pool.Replicas != nil {
-		total = *pool.Replicas
-	}
-	numOfAZs := int64(len(azs))
+	zoneCounts := pool.ZoneDistribution(clusterID, azs)
 	var machinesets []*machineapi.MachineSet
 	for idx, az := range azs {
-		replicas := int32(total / numOfAZs)
-		if int64(idx) < total%numOfAZs {
-			replicas++
-		}
+		replicas := int32(zoneCounts[idx])
 
 		provider, err := provider(clusterID, platform, mpool, osImage, idx, role, userDataSecret)
 		if err != nil {
@@ -49,8 +42,9 @@ func MachineSets(clusterID string, config *types.InstallConfig, pool *types.Mach
 				Kind:       "MachineSet",
 			},
 			ObjectMeta: metav1.ObjectMeta{
-				Namespace: "openshift-machine-api",
-				Name:      name,
+				Namespace:   "openshift-machine-api",
+				Name:        name,
+				Annotations: poolAnnotations(mpool),
 				Labels: map[string]string{
 					"machine.openshift.io/cluster-api-cluster": clusterID,
 				},
@@ -86,3 +80,26 @@ func MachineSets(clusterID string, config *types.InstallConfig, pool *types.Mach
 
 	return machinesets, nil
 }
+
+// poolAnnotations records the sole-tenant node group and the shielded
+// VM options a pool's machines need. The gcp machine provider does
+// not expose these in its spec yet, so they travel as annotations.
+func poolAnnotations(mpool *gcp.MachinePool) map[string]string {
+	annotations := map[string]string{}
+	if mpool.SoleTenancy != nil && mpool.SoleTenancy.NodeGroup != "" {
+		annotations["machine.openshift.io/gcp-node-group"] = mpool.SoleTenancy.NodeGroup
+	}
+	if mpool.SecureBoot {
+		annotations["machine.openshift.io/gcp-secure-boot"] = "enabled"
+	}
+	if mpool.VirtualizedTrustedPlatformModule {
+		annotations["machine.openshift.io/gcp-vtpm"] = "enabled"
+	}
+	if mpool.IntegrityMonitoring {
+		annotations["machine.openshift.io/gcp-integrity-monitoring"] = "enabled"
+	}
+	if len(annotations) == 0 {
+		return nil
+	}
+	return annotations
+}
@@ -26,13 +26,23 @@ func MachineSets(clusterID string, config *types.InstallConfig, pool *types.Mach
 	// FIXME: libvirt actuator does not support any options from machinepool.
 	// mpool := pool.Platform.Libvirt
 
-	total := int64(0)
-	if pool.Replicas != nil {
-		total = *pool.Replicas
+	// Spread the pool round-robin across the configured hypervisors,
+	// one machineset per host.
+	uris := append([]string{platform.URI}, platform.AdditionalURIs...)
+	counts := pool.ZoneDistribution(clusterID, uris)
+
+	var machinesets []*machineapi.MachineSet
+	for idx, uri := range uris {
+		machinesets = append(machinesets, machineSet(clusterID, config, platform, pool, role, userDataSecret, idx, uri, int32(counts[idx])))
 	}
+	return machinesets, nil
+}
 
-	provider := provider(clusterID, config.Networking.MachineNetwork[0].CIDR.String(), platform, userDataSecret)
-	name := fmt.Sprintf("%s-%s-%d", clusterID, pool.Name, 0)
+func machineSet(clusterID string, config *types.InstallConfig, platform *libvirt.Platform, pool *types.MachinePool, role, userDataSecret string, idx int, uri string, total int32) *machineapi.MachineSet {
+	hostPlatform := *platform
+	hostPlatform.URI = uri
+	provider := provider(clusterID, config.Networking.MachineNetwork[0].CIDR.String(), &hostPlatform, userDataSecret)
+	name := fmt.Sprintf("%s-%s-%d", clusterID, pool.Name, idx)
 	mset := &machineapi.MachineSet{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: "machine.openshift.io/v1beta1",
@@ -74,5 +84,5 @@ func MachineSets(clusterID string, config *types.InstallConfig, pool *types.Mach
 		},
 	}
 
-	return []*machineapi.MachineSet{mset}, nil
+	return mset
 }
@@ -0,0 +1,52 @@
+package machineconfig
+
+import (
+	"fmt"
+	"strings"
+
+	igntypes "github.com/coreos/ignition/config/v2_2/types"
+	mcfgv1 "github.com/openshift/machine-config-operator/pkg/apis/machineconfiguration.openshift.io/v1"
+	"github.com/vincent-petithory/dataurl"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ForDNSForwarders creates the MachineConfig that pins the
+// node-hosted CoreDNS upstreams.
+func ForDNSForwarders(role string, forwarders []string) *mcfgv1.MachineConfig {
+	mode := 420
+	return &mcfgv1.MachineConfig{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: mcfgv1.SchemeGroupVersion.String(),
+			Kind:       "MachineConfig",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: fmt.Sprintf("99-%s-coredns-forwarders", role),
+			Labels: map[string]string{
+				"machineconfiguration.openshift.io/role": role,
+			},
+		},
+		Spec: mcfgv1.MachineConfigSpec{
+			Config: igntypes.Config{
+				Ignition: igntypes.Ignition{
+					Version: igntypes.MaxVersion.String(),
+				},
+				Storage: igntypes.Storage{
+					Files: []igntypes.File{
+						{
+							Node: igntypes.Node{
+								Filesystem: "root",
+								Path:       "/etc/kubernetes/coredns-forwarders",
+							},
+							FileEmbedded1: igntypes.FileEmbedded1{
+								Mode: &mode,
+								Contents: igntypes.FileContents{
+									Source: dataurl.EncodeBytes([]byte(strings.Join(forwarders, "\n") + "\n")),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,76 @@
+package machineconfig
+
+import (
+	"fmt"
+
+	igntypes "github.com/coreos/ignition/config/v2_2/types"
+	mcfgv1 "github.com/openshift/machine-config-operator/pkg/apis/machineconfiguration.openshift.io/v1"
+	"github.com/vincent-petithory/dataurl"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// nmConf puts NetworkManager's dnsmasq plugin in front of /etc/resolv.conf
+// so kubelet and CRI-O resolve through the local cache.
+const nmConf = `[main]
+dns=dnsmasq
+`
+
+// ForNodeLocalDNS creates the MachineConfig that enables a node-local
+// caching resolver. When upstream is non-empty it is pinned as the
+// forwarder (the platform's resolver); otherwise dnsmasq keeps the
+// DHCP-provided upstreams, which lets platforms with on-host DNS merge
+// their own servers instead of conflicting.
+func ForNodeLocalDNS(role string, upstream string) *mcfgv1.MachineConfig {
+	mode := 420
+	dnsmasqConf := "cache-size=10000\nmin-cache-ttl=30\n"
+	if upstream != "" {
+		dnsmasqConf += fmt.Sprintf("server=%s\n", upstream)
+	}
+	return &mcfgv1.MachineConfig{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: mcfgv1.SchemeGroupVersion.String(),
+			Kind:       "MachineConfig",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: fmt.Sprintf("99-%s-node-local-dns", role),
+			Labels: map[string]string{
+				"machineconfiguration.openshift.io/role": role,
+			},
+		},
+		Spec: mcfgv1.MachineConfigSpec{
+			Config: igntypes.Config{
+				Ignition: igntypes.Ignition{
+					Version: igntypes.MaxVersion.String(),
+				},
+				Storage: igntypes.Storage{
+					Files: []igntypes.File{
+						{
+							Node: igntypes.Node{
+								Filesystem: "root",
+								Path:       "/etc/NetworkManager/conf.d/node-local-dns.conf",
+							},
+							FileEmbedded1: igntypes.FileEmbedded1{
+								Mode: &mode,
+								Contents: igntypes.FileContents{
+									Source: dataurl.EncodeBytes([]byte(nmConf)),
+								},
+							},
+						},
+						{
+							Node: igntypes.Node{
+								Filesystem: "root",
+								Path:       "/etc/NetworkManager/dnsmasq.d/node-local-cache.conf",
+							},
+							FileEmbedded1: igntypes.FileEmbedded1{
+								Mode: &mode,
+								Contents: igntypes.FileContents{
+									Source: dataurl.EncodeBytes([]byte(dnsmasqConf)),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
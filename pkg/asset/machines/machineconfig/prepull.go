@@ -0,0 +1,106 @@
+package machineconfig
+
+import (
+	"fmt"
+	"strings"
+
+	igntypes "github.com/coreos/ignition/config/v2_2/types"
+	mcfgv1 "github.com/openshift/machine-config-operator/pkg/apis/machineconfiguration.openshift.io/v1"
+	"github.com/vincent-petithory/dataurl"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const prePullScriptPath = "/usr/local/bin/release-image-prepull.sh"
+
+// prePullScript pulls each image with a pause between pulls so slow
+// links are not saturated, and never fails the unit: warming the image
+// cache is best-effort. Mirrors are honored because podman reads the
+// host's registries configuration, which carries the install-time
+// image content sources.
+const prePullScript = `#!/usr/bin/env bash
+set -u
+while IFS= read -r image; do
+    [ -z "${image}" ] && continue
+    podman pull --quiet "${image}" || echo "pre-pull of ${image} failed, continuing" >&2
+    sleep 2
+done </usr/local/share/release-image-prepull.list
+exit 0
+`
+
+const prePullUnit = `[Unit]
+Description=Pre-pull release payload images
+Wants=network-online.target crio.service
+After=network-online.target crio.service
+
+[Service]
+Type=oneshot
+ExecStart=%s
+RemainAfterExit=yes
+
+[Install]
+WantedBy=multi-user.target
+`
+
+// ForReleaseImagePrePull creates the MachineConfig that pre-pulls the
+// given release payload images on first boot.
+func ForReleaseImagePrePull(role string, images []string) *mcfgv1.MachineConfig {
+	mode := 493 // 0755
+	listMode := 420
+	enabled := true
+	return &mcfgv1.MachineConfig{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: mcfgv1.SchemeGroupVersion.String(),
+			Kind:       "MachineConfig",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: fmt.Sprintf("99-%s-release-image-prepull", role),
+			Labels: map[string]string{
+				"machineconfiguration.openshift.io/role": role,
+			},
+		},
+		Spec: mcfgv1.MachineConfigSpec{
+			Config: igntypes.Config{
+				Ignition: igntypes.Ignition{
+					Version: igntypes.MaxVersion.String(),
+				},
+				Storage: igntypes.Storage{
+					Files: []igntypes.File{
+						{
+							Node: igntypes.Node{
+								Filesystem: "root",
+								Path:       prePullScriptPath,
+							},
+							FileEmbedded1: igntypes.FileEmbedded1{
+								Mode: &mode,
+								Contents: igntypes.FileContents{
+									Source: dataurl.EncodeBytes([]byte(prePullScript)),
+								},
+							},
+						},
+						{
+							Node: igntypes.Node{
+								Filesystem: "root",
+								Path:       "/usr/local/share/release-image-prepull.list",
+							},
+							FileEmbedded1: igntypes.FileEmbedded1{
+								Mode: &listMode,
+								Contents: igntypes.FileContents{
+									Source: dataurl.EncodeBytes([]byte(strings.Join(images, "\n") + "\n")),
+								},
+							},
+						},
+					},
+				},
+				Systemd: igntypes.Systemd{
+					Units: []igntypes.Unit{
+						{
+							Name:     "release-image-prepull.service",
+							Enabled:  &enabled,
+							Contents: fmt.Sprintf(prePullUnit, prePullScriptPath),
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -42,6 +42,7 @@ import (
 	"github.com/openshift/installer/pkg/asset/machines/openstack"
 	"github.com/openshift/installer/pkg/asset/machines/ovirt"
 	"github.com/openshift/installer/pkg/asset/machines/vsphere"
+	"github.com/openshift/installer/pkg/asset/releaseimage"
 	"github.com/openshift/installer/pkg/asset/rhcos"
 	rhcosutils "github.com/openshift/installer/pkg/rhcos"
 	"github.com/openshift/installer/pkg/types"
@@ -118,6 +119,7 @@ func (m *Master) Dependencies() []asset.Asset {
 		&installconfig.PlatformCredsCheck{},
 		&installconfig.InstallConfig{},
 		new(rhcos.Image),
+		&releaseimage.Image{},
 		&machine.Master{},
 	}
 }
@@ -137,8 +139,9 @@ func (m *Master) Generate(dependencies asset.Parents) error {
 	clusterID := &installconfig.ClusterID{}
 	installConfig := &installconfig.InstallConfig{}
 	rhcosImage := new(rhcos.Image)
+	releaseImage := &releaseimage.Image{}
 	mign := &machine.Master{}
-	dependencies.Get(clusterID, installConfig, rhcosImage, mign)
+	dependencies.Get(clusterID, installConfig, rhcosImage, releaseImage, mign)
 
 	ic := installConfig.Config
 
@@ -237,7 +240,7 @@ func (m *Master) Generate(dependencies asset.Parents) error {
 		openstack.ConfigMasters(machines, clusterID.InfraID)
 	case azuretypes.Name:
 		mpool := defaultAzureMachinePoolPlatform()
-		mpool.InstanceType = azuredefaults.ControlPlaneInstanceType(installConfig.Config.Platform.Azure.Region)
+		mpool.InstanceType = azureViableInstanceType(installConfig.Config.Platform.Azure.Region, azuredefaults.ControlPlaneInstanceType(installConfig.Config.Platform.Azure.Region), "control-plane")
 		mpool.OSDisk.DiskSizeGB = 1024
 		mpool.Set(ic.Platform.Azure.DefaultMachinePlatform)
 		mpool.Set(pool.Platform.Azure)
@@ -356,6 +359,18 @@ func (m *Master) Generate(dependencies asset.Parents) error {
 	if ic.FIPS {
 		machineConfigs = append(machineConfigs, machineconfig.ForFIPSEnabled("master"))
 	}
+	if forwarders := dnsForwarders(ic); len(forwarders) > 0 {
+		machineConfigs = append(machineConfigs, machineconfig.ForDNSForwarders("master", forwarders))
+	}
+	if len(pool.KernelArguments) > 0 {
+		machineConfigs = append(machineConfigs, machineconfig.ForKernelArguments("master", pool.KernelArguments))
+	}
+	if ic.Networking != nil && ic.Networking.NodeLocalDNSCache {
+		machineConfigs = append(machineConfigs, machineconfig.ForNodeLocalDNS("master", nodeLocalDNSUpstream(ic)))
+	}
+	if pool.PrePullReleaseImages {
+		machineConfigs = append(machineConfigs, machineconfig.ForReleaseImagePrePull("master", releaseimage.References(releaseImage.PullSpec)))
+	}
 
 	m.MachineConfigFiles, err = machineconfig.Manifests(machineConfigs, "master", directory)
 	if err != nil {
@@ -504,3 +519,17 @@ func IsMachineManifest(file *asset.File) bool {
 		return matched
 	}
 }
+
+// dnsForwarders returns the on-prem platform's CoreDNS upstreams, if
+// any.
+func dnsForwarders(config *types.InstallConfig) []string {
+	switch {
+	case config.Platform.BareMetal != nil:
+		return config.Platform.BareMetal.DNSForwarders
+	case config.Platform.VSphere != nil:
+		return config.Platform.VSphere.DNSForwarders
+	case config.Platform.OpenStack != nil:
+		return config.Platform.OpenStack.DNSForwarders
+	}
+	return nil
+}
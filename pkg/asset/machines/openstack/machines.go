@@ -102,12 +102,8 @@ func provider(clusterID string, platform *openstack.Platform, mpool *openstack.M
 			},
 		},
 		AvailabilityZone: az,
-		SecurityGroups: []openstackprovider.SecurityGroupParam{
-			{
-				Name: fmt.Sprintf("%s-%s", clusterID, role),
-			},
-		},
-		Trunk: trunkSupportBoolean(trunk),
+		SecurityGroups:   securityGroups(clusterID, role, mpool),
+		Trunk:            trunkSupportBoolean(trunk),
 		Tags: []string{
 			fmt.Sprintf("openshiftClusterID=%s", clusterID),
 		},
@@ -144,3 +140,15 @@ func ConfigMasters(machines []machineapi.Machine, clusterID string) {
 		providerSpec := machine.Spec.ProviderSpec.Value.Object.(*openstackprovider.OpenstackProviderSpec)
 	}*/
 }
+
+// securityGroups lists the installer-managed role group first and then
+// any groups the pool pins in addition.
+func securityGroups(clusterID string, role string, mpool *openstack.MachinePool) []openstackprovider.SecurityGroupParam {
+	groups := []openstackprovider.SecurityGroupParam{
+		{Name: fmt.Sprintf("%s-%s", clusterID, role)},
+	}
+	for _, groupID := range mpool.AdditionalSecurityGroupIDs {
+		groups = append(groups, openstackprovider.SecurityGroupParam{UUID: groupID})
+	}
+	return groups
+}
@@ -3,6 +3,7 @@ package ovirt
 
 import (
 	"fmt"
+	"strings"
 
 	machineapi "github.com/openshift/cluster-api/pkg/apis/machine/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -38,8 +39,9 @@ func MachineSets(clusterID string, config *types.InstallConfig, pool *types.Mach
 			Kind:       "MachineSet",
 		},
 		ObjectMeta: metav1.ObjectMeta{
-			Namespace: "openshift-machine-api",
-			Name:      name,
+			Namespace:   "openshift-machine-api",
+			Name:        name,
+			Annotations: poolAnnotations(clusterID, pool.Platform.Ovirt),
 			Labels: map[string]string{
 				"machine.openshift.io/cluster-api-cluster":      clusterID,
 				"machine.openshift.io/cluster-api-machine-role": role,
@@ -75,3 +77,17 @@ func MachineSets(clusterID string, config *types.InstallConfig, pool *types.Mach
 
 	return []*machineapi.MachineSet{mset}, nil
 }
+
+// poolAnnotations records the affinity groups a pool's VMs should
+// join and the oVirt tag destroy finds them by. The ovirt machine
+// provider does not expose either in its spec yet, so they travel as
+// annotations.
+func poolAnnotations(clusterID string, mpool *ovirt.MachinePool) map[string]string {
+	annotations := map[string]string{
+		"machine.openshift.io/ovirt-tag": clusterID,
+	}
+	if mpool != nil && len(mpool.AffinityGroupsNames) > 0 {
+		annotations["machine.openshift.io/affinity-groups"] = strings.Join(mpool.AffinityGroupsNames, ",")
+	}
+	return annotations
+}
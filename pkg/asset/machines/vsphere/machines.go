@@ -73,17 +73,14 @@ func provider(clusterID string, platform *vsphere.Platform, mpool *vsphere.Machi
 		CredentialsSecret: &corev1.LocalObjectReference{Name: "vsphere-cloud-credentials"},
 		Template:          osImage,
 		Network: vsphereapis.NetworkSpec{
-			Devices: []vsphereapis.NetworkDeviceSpec{
-				{
-					NetworkName: platform.Network,
-				},
-			},
+			Devices: poolNetworkDevices(platform, mpool),
 		},
 		Workspace: &vsphereapis.Workspace{
-			Server:     platform.VCenter,
-			Datacenter: platform.Datacenter,
-			Datastore:  platform.DefaultDatastore,
-			Folder:     clusterID,
+			Server:       platform.VCenter,
+			Datacenter:   platform.Datacenter,
+			Datastore:    platform.DefaultDatastore,
+			Folder:       poolFolder(clusterID, platform, mpool),
+			ResourcePool: poolResourcePool(platform, mpool),
 		},
 		NumCPUs:           mpool.NumCPUs,
 		NumCoresPerSocket: mpool.NumCoresPerSocket,
@@ -95,3 +92,39 @@ func provider(clusterID string, platform *vsphere.Platform, mpool *vsphere.Machi
 // ConfigMasters sets the PublicIP flag and assigns a set of load balancers to the given machines
 func ConfigMasters(machines []machineapi.Machine, clusterID string) {
 }
+
+// poolFolder picks the folder for a pool's VMs: the pool override, the
+// cluster-wide folder, or the default folder named after the cluster.
+func poolFolder(clusterID string, platform *vsphere.Platform, mpool *vsphere.MachinePool) string {
+	if mpool.Folder != "" {
+		return mpool.Folder
+	}
+	if platform.Folder != "" {
+		return platform.Folder
+	}
+	return clusterID
+}
+
+// poolResourcePool picks the resource pool for a pool's VMs: the pool
+// override or the cluster-wide resource pool.
+func poolResourcePool(platform *vsphere.Platform, mpool *vsphere.MachinePool) string {
+	if mpool.ResourcePool != "" {
+		return mpool.ResourcePool
+	}
+	return platform.ResourcePool
+}
+
+// poolNetworkDevices builds the NIC list for a pool's VMs: the pool's
+// primary network override (or the cluster-wide network), followed by
+// any additional networks.
+func poolNetworkDevices(platform *vsphere.Platform, mpool *vsphere.MachinePool) []vsphereapis.NetworkDeviceSpec {
+	primary := platform.Network
+	if mpool.Network != "" {
+		primary = mpool.Network
+	}
+	devices := []vsphereapis.NetworkDeviceSpec{{NetworkName: primary}}
+	for _, network := range mpool.AdditionalNetworks {
+		devices = append(devices, vsphereapis.NetworkDeviceSpec{NetworkName: network})
+	}
+	return devices
+}
@@ -3,8 +3,11 @@ package machines
 import (
 	"context"
 	"fmt"
+	"net"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 
 	"github.com/ghodss/yaml"
 	baremetalapi "github.com/metal3-io/cluster-api-provider-baremetal/pkg/apis"
@@ -31,6 +34,7 @@ import (
 	"github.com/openshift/installer/pkg/asset"
 	"github.com/openshift/installer/pkg/asset/ignition/machine"
 	"github.com/openshift/installer/pkg/asset/installconfig"
+	azureconfig "github.com/openshift/installer/pkg/asset/installconfig/azure"
 	"github.com/openshift/installer/pkg/asset/machines/aws"
 	"github.com/openshift/installer/pkg/asset/machines/azure"
 	"github.com/openshift/installer/pkg/asset/machines/baremetal"
@@ -40,6 +44,7 @@ import (
 	"github.com/openshift/installer/pkg/asset/machines/openstack"
 	"github.com/openshift/installer/pkg/asset/machines/ovirt"
 	"github.com/openshift/installer/pkg/asset/machines/vsphere"
+	"github.com/openshift/installer/pkg/asset/releaseimage"
 	"github.com/openshift/installer/pkg/asset/rhcos"
 	rhcosutils "github.com/openshift/installer/pkg/rhcos"
 	"github.com/openshift/installer/pkg/types"
@@ -154,6 +159,7 @@ func (w *Worker) Dependencies() []asset.Asset {
 		&installconfig.PlatformCredsCheck{},
 		&installconfig.InstallConfig{},
 		new(rhcos.Image),
+		&releaseimage.Image{},
 		&machine.Worker{},
 	}
 }
@@ -164,8 +170,9 @@ func (w *Worker) Generate(dependencies asset.Parents) error {
 	clusterID := &installconfig.ClusterID{}
 	installConfig := &installconfig.InstallConfig{}
 	rhcosImage := new(rhcos.Image)
+	releaseImage := &releaseimage.Image{}
 	wign := &machine.Worker{}
-	dependencies.Get(clusterID, installConfig, rhcosImage, wign)
+	dependencies.Get(clusterID, installConfig, rhcosImage, releaseImage, wign)
 
 	machineConfigs := []*mcfgv1.MachineConfig{}
 	machineSets := []runtime.Object{}
@@ -181,6 +188,23 @@ func (w *Worker) Generate(dependencies asset.Parents) error {
 		if ic.FIPS {
 			machineConfigs = append(machineConfigs, machineconfig.ForFIPSEnabled("worker"))
 		}
+		if len(pool.KernelArguments) > 0 {
+			machineConfigs = append(machineConfigs, machineconfig.ForKernelArguments("worker", pool.KernelArguments))
+		}
+		if ic.Networking != nil && ic.Networking.NodeLocalDNSCache {
+			machineConfigs = append(machineConfigs, machineconfig.ForNodeLocalDNS("worker", nodeLocalDNSUpstream(ic)))
+		}
+		if pool.PrePullReleaseImages {
+			machineConfigs = append(machineConfigs, machineconfig.ForReleaseImagePrePull("worker", releaseimage.References(releaseImage.PullSpec)))
+		}
+		if pool.CreateMachineSets != nil && !*pool.CreateMachineSets {
+			// The pool is managed by day-2 automation; keep the worker
+			// user-data secret and machine configs but generate no
+			// machinesets for it.
+			logrus.Infof("Not creating machinesets for pool %s (createMachineSets is false)", pool.Name)
+			continue
+		}
+		poolSetsStart := len(machineSets)
 		switch ic.Platform.Name() {
 		case awstypes.Name:
 			subnets := map[string]string{}
@@ -197,11 +221,22 @@ func (w *Worker) Generate(dependencies asset.Parents) error {
 			mpool := defaultAWSMachinePoolPlatform()
 			mpool.Set(ic.Platform.AWS.DefaultMachinePlatform)
 			mpool.Set(pool.Platform.AWS)
+			if len(mpool.Subnets) > 0 {
+				// The pool is pinned to specific subnets; place its
+				// machines there instead of the cluster-wide selection.
+				subnets, err = installConfig.AWS.SubnetZones(ctx, mpool.Subnets)
+				if err != nil {
+					return err
+				}
+			}
 			if len(mpool.Zones) == 0 {
 				if len(subnets) > 0 {
 					for zone := range subnets {
 						mpool.Zones = append(mpool.Zones, zone)
 					}
+					// Map iteration order must not leak into the
+					// replica distribution.
+					sort.Strings(mpool.Zones)
 				} else {
 					mpool.Zones, err = installConfig.AWS.AvailabilityZones(ctx)
 					if err != nil {
@@ -235,7 +270,7 @@ func (w *Worker) Generate(dependencies asset.Parents) error {
 			}
 		case azuretypes.Name:
 			mpool := defaultAzureMachinePoolPlatform()
-			mpool.InstanceType = azuredefaults.ComputeInstanceType(installConfig.Config.Platform.Azure.Region)
+			mpool.InstanceType = azureViableInstanceType(installConfig.Config.Platform.Azure.Region, azuredefaults.ComputeInstanceType(installConfig.Config.Platform.Azure.Region), "compute")
 			mpool.Set(ic.Platform.Azure.DefaultMachinePlatform)
 			mpool.Set(pool.Platform.Azure)
 			if len(mpool.Zones) == 0 {
@@ -344,6 +379,8 @@ func (w *Worker) Generate(dependencies asset.Parents) error {
 		default:
 			return fmt.Errorf("invalid Platform")
 		}
+		applyNodeLabelsAndTaints(machineSets[poolSetsStart:], &pool)
+		logMachineSetDistribution(machineSets[poolSetsStart:], pool.Name)
 	}
 
 	data, err := userDataSecret("worker-user-data", wign.File.Data)
@@ -452,3 +489,105 @@ func (w *Worker) MachineSets() ([]machineapi.MachineSet, error) {
 
 	return machineSets, nil
 }
+
+// applyNodeLabelsAndTaints copies a pool's node labels and taints into
+// the machine template of the pool's machinesets so the nodes come up
+// labeled without post-render patching.
+func applyNodeLabelsAndTaints(sets []runtime.Object, pool *types.MachinePool) {
+	if len(pool.Labels) == 0 && len(pool.Taints) == 0 {
+		return
+	}
+	for _, obj := range sets {
+		set, ok := obj.(*machineapi.MachineSet)
+		if !ok {
+			continue
+		}
+		spec := &set.Spec.Template.Spec
+		if spec.ObjectMeta.Labels == nil {
+			spec.ObjectMeta.Labels = map[string]string{}
+		}
+		for key, value := range pool.Labels {
+			spec.ObjectMeta.Labels[key] = value
+		}
+		spec.Taints = append(spec.Taints, pool.Taints...)
+	}
+}
+
+// nodeLocalDNSUpstream returns the platform's native resolver to pin
+// as the node-local cache's forwarder, or "" to keep the
+// DHCP-provided upstreams.
+func nodeLocalDNSUpstream(ic *types.InstallConfig) string {
+	switch ic.Platform.Name() {
+	case awstypes.Name:
+		// The VPC resolver lives at the base of the machine network +2.
+		if len(ic.Networking.MachineNetwork) > 0 {
+			ip := ic.Networking.MachineNetwork[0].CIDR.IP.To4()
+			if ip != nil {
+				resolver := make(net.IP, len(ip))
+				copy(resolver, ip)
+				resolver[3] += 2
+				return resolver.String()
+			}
+		}
+	case azuretypes.Name:
+		return "168.63.129.16"
+	case gcptypes.Name:
+		return "169.254.169.254"
+	}
+	return ""
+}
+
+// logMachineSetDistribution reports the computed per-machineset
+// replica counts so the rounding is visible in the run summary. The
+// rounding rule is documented on MachinePool.ZoneDistribution: even
+// division first, the remainder spread one-per-zone from the start of
+// the stable zone order (or seeded-randomly with
+// spread-remainder-random).
+func logMachineSetDistribution(sets []runtime.Object, poolName string) {
+	if len(sets) == 0 {
+		return
+	}
+	parts := make([]string, 0, len(sets))
+	for _, obj := range sets {
+		set, ok := obj.(*machineapi.MachineSet)
+		if !ok || set.Spec.Replicas == nil {
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("%s=%d", set.Name, *set.Spec.Replicas))
+	}
+	logrus.Infof("Replica distribution for pool %s: %s", poolName, strings.Join(parts, ", "))
+}
+
+// azureViableInstanceType keeps the regional default instance type
+// when the region offers it, and otherwise substitutes the smallest
+// size that still meets the minimum requirements (4 vCPUs), using the
+// VM size data the validation client already fetched. Explicit
+// user-specified types never reach this path.
+func azureViableInstanceType(region, defaultType, role string) string {
+	client, err := azureconfig.NewClient(context.TODO())
+	if err != nil {
+		return defaultType
+	}
+	sizes, err := client.GetVirtualMachineSizes(context.TODO(), region)
+	if err != nil || len(sizes) == 0 {
+		return defaultType
+	}
+	if _, available := sizes[defaultType]; available {
+		return defaultType
+	}
+	substitute := ""
+	substituteCores := int32(0)
+	for name, cores := range sizes {
+		if cores < 4 || !strings.HasPrefix(name, "Standard_D") {
+			continue
+		}
+		if substitute == "" || cores < substituteCores || (cores == substituteCores && name < substitute) {
+			substitute, substituteCores = name, cores
+		}
+	}
+	if substitute == "" {
+		return defaultType
+	}
+	logrus.Warnf("The default %s instance type %s is not available in %s; substituting the smallest viable type %s", role, defaultType, region, substitute)
+	return substitute
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/openshift/installer/pkg/asset"
 	"github.com/openshift/installer/pkg/asset/ignition/machine"
 	"github.com/openshift/installer/pkg/asset/installconfig"
+	"github.com/openshift/installer/pkg/asset/releaseimage"
 	"github.com/openshift/installer/pkg/asset/rhcos"
 	"github.com/openshift/installer/pkg/types"
 	awstypes "github.com/openshift/installer/pkg/types/aws"
@@ -197,6 +198,7 @@ spec:
 					},
 				},
 				(*rhcos.Image)(pointer.StringPtr("test-image")),
+				&releaseimage.Image{PullSpec: "registry.example.com/release:latest"},
 				&machine.Worker{
 					File: &asset.File{
 						Filename: "worker-ignition",
@@ -7,6 +7,8 @@ import (
 
 //CloudProviderConfig is the azure cloud provider config
 type CloudProviderConfig struct {
+	CloudName                string
+	ARMEndpoint              string
 	TenantID                 string
 	SubscriptionID           string
 	GroupLocation            string
@@ -21,9 +23,13 @@ type CloudProviderConfig struct {
 // managed resource names are matching the convention defined by capz
 func (params CloudProviderConfig) JSON() (string, error) {
 	resourceGroupName := params.ResourcePrefix + "-rg"
+	cloud := params.CloudName
+	if cloud == "" {
+		cloud = "AzurePublicCloud"
+	}
 	config := config{
 		authConfig: authConfig{
-			Cloud:                       "AzurePublicCloud",
+			Cloud:                       cloud,
 			TenantID:                    params.TenantID,
 			SubscriptionID:              params.SubscriptionID,
 			UseManagedIdentityExtension: true,
@@ -64,6 +70,16 @@ func (params CloudProviderConfig) JSON() (string, error) {
 		//https://docs.microsoft.com/en-us/azure/load-balancer/load-balancer-tcp-reset
 		LoadBalancerSku: "standard",
 	}
+	if params.CloudName == "AzureStackCloud" {
+		// Azure Stack Hub has no instance metadata service, no managed
+		// identities and no availability zones; the custom environment
+		// endpoints come from the ARM endpoint written alongside.
+		config.authConfig.UseManagedIdentityExtension = false
+		config.UseInstanceMetadata = false
+		config.VMType = "standard"
+		config.ResourceManagerEndpoint = params.ARMEndpoint
+	}
+
 	buff := &bytes.Buffer{}
 	encoder := json.NewEncoder(buff)
 	encoder.SetIndent("", "\t")
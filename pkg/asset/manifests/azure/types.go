@@ -28,6 +28,10 @@ type authConfig struct {
 type config struct {
 	authConfig
 
+	// The Azure Resource Manager endpoint to use; set for Azure Stack
+	// Hub instances whose endpoints differ from the public cloud.
+	ResourceManagerEndpoint string `json:"resourceManagerEndpoint,omitempty" yaml:"resourceManagerEndpoint,omitempty"`
+
 	// The name of the resource group that the cluster is deployed in
 	ResourceGroup string `json:"resourceGroup" yaml:"resourceGroup"`
 	// The location of the resource group that the cluster is deployed in
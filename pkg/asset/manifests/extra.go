@@ -0,0 +1,93 @@
+package manifests
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/installer/pkg/asset"
+)
+
+// ExtraManifestDirs lists directories whose YAML files are appended to
+// the generated manifests in a single invocation, without the
+// create-manifests/copy/create-cluster dance. Set from the
+// --manifests-dir flag before the assets are generated.
+var ExtraManifestDirs []string
+
+// openshiftDirPrefixes route extra manifests into the openshift/
+// generation directory; everything else lands in manifests/.
+var openshiftDirPrefixes = []string{
+	"99_openshift-machineconfig_",
+	"99_openshift-cluster-api_",
+	"99_role",
+	"99_binding",
+	"99_kubeadmin",
+	"99_cloud-creds",
+}
+
+// extraManifestFiles loads the user-provided YAML files that belong in
+// targetDir, validating that they parse and do not collide with the
+// filenames the installer generated.
+func extraManifestFiles(targetDir string, generated []*asset.File) ([]*asset.File, error) {
+	existing := map[string]bool{}
+	for _, file := range generated {
+		existing[file.Filename] = true
+	}
+
+	var files []*asset.File
+	for _, dir := range ExtraManifestDirs {
+		entries, err := ioutil.ReadDir(dir)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read extra manifests directory %q", dir)
+		}
+		for _, entry := range entries {
+			name := entry.Name()
+			if entry.IsDir() || (!strings.HasSuffix(name, ".yaml") && !strings.HasSuffix(name, ".yml") && !strings.HasSuffix(name, ".json")) {
+				continue
+			}
+			if routeManifest(name) != targetDir {
+				continue
+			}
+			data, err := ioutil.ReadFile(filepath.Join(dir, name))
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to read extra manifest %q", name)
+			}
+			filename := filepath.Join(targetDir, name)
+			parsed := map[string]interface{}{}
+			if err := yaml.Unmarshal(data, &parsed); err != nil {
+				logrus.Warnf("Skipping extra manifest %q: not valid YAML: %v", filepath.Join(dir, name), err)
+				recordSkippedManifest(filename, fmt.Sprintf("not valid YAML: %v", err))
+				continue
+			}
+			if existing[filename] {
+				logrus.Warnf("Skipping extra manifest %q: duplicates an installer-generated manifest", filename)
+				recordSkippedManifest(filename, "duplicates an installer-generated manifest")
+				continue
+			}
+			existing[filename] = true
+			logrus.Debugf("Appending extra manifest %s", filename)
+			recordUserManifest(filename)
+			files = append(files, &asset.File{
+				Filename: filename,
+				Data:     data,
+			})
+		}
+	}
+	return files, nil
+}
+
+// routeManifest decides which generation directory an extra manifest
+// belongs to based on its filename.
+func routeManifest(name string) string {
+	for _, prefix := range openshiftDirPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return openshiftManifestDir
+		}
+	}
+	return manifestDir
+}
@@ -0,0 +1,109 @@
+package manifests
+
+import (
+	"path/filepath"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+)
+
+var (
+	imageCfgFilename      = filepath.Join(manifestDir, "cluster-image-02-config.yml")
+	registryCACfgFilename = filepath.Join(manifestDir, "registry-ca-configmap.yml")
+
+	// registryCAConfigMapName is the openshift-config configmap the
+	// Image config's additionalTrustedCA references.
+	registryCAConfigMapName = "registry-additional-ca"
+)
+
+// Image generates the cluster-image-*.yml files so the registry pull
+// policy is active before any workload runs.
+type Image struct {
+	FileList []*asset.File
+}
+
+var _ asset.WritableAsset = (*Image)(nil)
+
+// Name returns a human friendly name for the asset.
+func (*Image) Name() string {
+	return "Image Config"
+}
+
+// Dependencies returns all of the dependencies directly needed to generate
+// the asset.
+func (*Image) Dependencies() []asset.Asset {
+	return []asset.Asset{
+		&installconfig.InstallConfig{},
+	}
+}
+
+// Generate generates the Image config and the registry CA configmap.
+func (i *Image) Generate(dependencies asset.Parents) error {
+	installConfig := &installconfig.InstallConfig{}
+	dependencies.Get(installConfig)
+
+	registries := installConfig.Config.ImageRegistries
+	if registries == nil {
+		return nil
+	}
+
+	config := &configv1.Image{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: configv1.SchemeGroupVersion.String(),
+			Kind:       "Image",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "cluster",
+			// not namespaced
+		},
+		Spec: configv1.ImageSpec{
+			RegistrySources: configv1.RegistrySources{
+				AllowedRegistries:  registries.AllowedRegistries,
+				BlockedRegistries:  registries.BlockedRegistries,
+				InsecureRegistries: registries.InsecureRegistries,
+			},
+		},
+	}
+
+	if registries.AdditionalTrustedCA != "" {
+		config.Spec.AdditionalTrustedCA = configv1.ConfigMapNameReference{Name: registryCAConfigMapName}
+
+		caConfigMap := configMap("openshift-config", registryCAConfigMapName, genericData{
+			"ca-bundle.crt": registries.AdditionalTrustedCA,
+		})
+		caData, err := yaml.Marshal(caConfigMap)
+		if err != nil {
+			return errors.Wrapf(err, "failed to create %s/%s configmap", "openshift-config", registryCAConfigMapName)
+		}
+		i.FileList = append(i.FileList, &asset.File{
+			Filename: registryCACfgFilename,
+			Data:     caData,
+		})
+	}
+
+	configData, err := yaml.Marshal(config)
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal %s", imageCfgFilename)
+	}
+	i.FileList = append(i.FileList, &asset.File{
+		Filename: imageCfgFilename,
+		Data:     configData,
+	})
+
+	return nil
+}
+
+// Files returns the files generated by the asset.
+func (i *Image) Files() []*asset.File {
+	return i.FileList
+}
+
+// Load loads the already-rendered files back from disk.
+func (i *Image) Load(f asset.FileFetcher) (bool, error) {
+	return false, nil
+}
@@ -68,6 +68,12 @@ func (i *Infrastructure) Generate(dependencies asset.Parents) error {
 		},
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "cluster",
+			// The chosen publishing strategy has no field in the
+			// infrastructure status yet, so it travels as an
+			// annotation; metadata.json records the same value.
+			Annotations: map[string]string{
+				"install.openshift.io/publish-strategy": string(installConfig.Config.Publish),
+			},
 			// not namespaced
 		},
 		Status: configv1.InfrastructureStatus{
@@ -1,7 +1,6 @@
 package manifests
 
 import (
-	"fmt"
 	"path/filepath"
 
 	"github.com/ghodss/yaml"
@@ -88,7 +87,7 @@ func (ing *Ingress) generateClusterConfig(config *types.InstallConfig) ([]byte,
 			// not namespaced
 		},
 		Spec: configv1.IngressSpec{
-			Domain: fmt.Sprintf("apps.%s", config.ClusterDomain()),
+			Domain: config.AppsDefaultDomain(),
 		},
 	}
 	return yaml.Marshal(obj)
@@ -0,0 +1,77 @@
+package manifests
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/openshift/installer/pkg/asset"
+)
+
+// InventoryEntry describes one manifest that was (or should have been)
+// included in the bootstrap ignition.
+type InventoryEntry struct {
+	Path   string `json:"path"`
+	SHA256 string `json:"sha256,omitempty"`
+	// Source is "installer" or "user".
+	Source string `json:"source"`
+	// SkippedReason is set when a user manifest did not make it into
+	// the cluster (name collision, parse failure).
+	SkippedReason string `json:"skippedReason,omitempty"`
+}
+
+var (
+	inventoryMutex sync.Mutex
+	// userManifestPaths tracks which generated filenames came from the
+	// user (extra manifest dirs, overlays).
+	userManifestPaths = map[string]bool{}
+	// skippedManifests tracks user manifests that were dropped, with
+	// the reason.
+	skippedManifests = map[string]string{}
+)
+
+// recordUserManifest marks a generated manifest as user-supplied.
+func recordUserManifest(path string) {
+	inventoryMutex.Lock()
+	defer inventoryMutex.Unlock()
+	userManifestPaths[path] = true
+}
+
+// recordSkippedManifest records a user manifest that was dropped.
+func recordSkippedManifest(path, reason string) {
+	inventoryMutex.Lock()
+	defer inventoryMutex.Unlock()
+	skippedManifests[path] = reason
+}
+
+// Inventory renders the manifest inventory for the given generated
+// files: every path with its digest and origin, plus the user
+// manifests that were skipped and why.
+func Inventory(files []*asset.File) ([]byte, error) {
+	inventoryMutex.Lock()
+	defer inventoryMutex.Unlock()
+
+	entries := []InventoryEntry{}
+	for _, file := range files {
+		source := "installer"
+		if userManifestPaths[file.Filename] {
+			source = "user"
+		}
+		entries = append(entries, InventoryEntry{
+			Path:   file.Filename,
+			SHA256: fmt.Sprintf("%x", sha256.Sum256(file.Data)),
+			Source: source,
+		})
+	}
+	for path, reason := range skippedManifests {
+		entries = append(entries, InventoryEntry{
+			Path:          path,
+			Source:        "user",
+			SkippedReason: reason,
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Path < entries[j].Path })
+	return json.MarshalIndent(entries, "", "  ")
+}
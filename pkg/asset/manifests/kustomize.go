@@ -0,0 +1,130 @@
+package manifests
+
+import (
+	"path/filepath"
+	"sort"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/installer/pkg/asset"
+)
+
+// KustomizeBase controls whether create manifests also writes a
+// kustomization.yaml into the manifests and openshift directories so
+// GitOps teams can manage the day-1 manifests as a kustomize base.
+// Set from the --kustomize flag.
+var KustomizeBase bool
+
+const (
+	kustomizationFilename = "kustomization.yaml"
+
+	// overlaysDir is the extension point for overlays: YAML files in
+	// overlays/manifests and overlays/openshift are merged over (or
+	// appended to) the generated manifests with the same filename when
+	// the manifests are consumed to generate ignition.
+	overlaysDir = "overlays"
+)
+
+// kustomizationFile enumerates the directory's manifests, in stable
+// sorted order, as a kustomize base.
+func kustomizationFile(dir string, files []*asset.File) *asset.File {
+	resources := []string{}
+	for _, file := range files {
+		if filepath.Dir(file.Filename) == dir {
+			resources = append(resources, filepath.Base(file.Filename))
+		}
+	}
+	sort.Strings(resources)
+
+	data, err := yaml.Marshal(map[string]interface{}{
+		"apiVersion": "kustomize.config.k8s.io/v1beta1",
+		"kind":       "Kustomization",
+		"resources":  resources,
+	})
+	if err != nil {
+		// Marshalling a map of strings cannot fail.
+		panic(err)
+	}
+	return &asset.File{
+		Filename: filepath.Join(dir, kustomizationFilename),
+		Data:     data,
+	}
+}
+
+// dropKustomization removes the kustomization.yaml from a loaded file
+// list so it never lands in the bootstrap ignition.
+func dropKustomization(dir string, files []*asset.File) []*asset.File {
+	kept := files[:0]
+	for _, file := range files {
+		if file.Filename == filepath.Join(dir, kustomizationFilename) {
+			continue
+		}
+		kept = append(kept, file)
+	}
+	return kept
+}
+
+// applyOverlays merges the extension-point overlay files for dir over
+// the loaded manifests. Overlays with the same filename as a generated
+// manifest are deep-merged over it (patch values win, maps merge
+// recursively); unmatched overlays are appended as new manifests, so
+// the ignition generation consumes the overlaid output.
+func applyOverlays(f asset.FileFetcher, dir string, files []*asset.File) ([]*asset.File, error) {
+	overlays, err := f.FetchByPattern(filepath.Join(overlaysDir, dir, "*.yaml"))
+	if err != nil {
+		return nil, err
+	}
+
+	byName := map[string]*asset.File{}
+	for _, file := range files {
+		byName[file.Filename] = file
+	}
+
+	for _, overlay := range overlays {
+		target := filepath.Join(dir, filepath.Base(overlay.Filename))
+		base, ok := byName[target]
+		if !ok {
+			logrus.Debugf("Appending overlay manifest %s", target)
+			recordUserManifest(target)
+			files = append(files, &asset.File{Filename: target, Data: overlay.Data})
+			continue
+		}
+		merged, err := mergeYAML(base.Data, overlay.Data)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to apply overlay %s", overlay.Filename)
+		}
+		logrus.Debugf("Applied overlay to %s", target)
+		base.Data = merged
+	}
+	return files, nil
+}
+
+// mergeYAML deep-merges the patch document over the base document.
+func mergeYAML(base, patch []byte) ([]byte, error) {
+	baseMap := map[string]interface{}{}
+	if err := yaml.Unmarshal(base, &baseMap); err != nil {
+		return nil, err
+	}
+	patchMap := map[string]interface{}{}
+	if err := yaml.Unmarshal(patch, &patchMap); err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(mergeMaps(baseMap, patchMap))
+}
+
+// mergeMaps recursively merges patch into base; scalar and list patch
+// values replace the base values.
+func mergeMaps(base, patch map[string]interface{}) map[string]interface{} {
+	for key, patchValue := range patch {
+		if baseValue, ok := base[key].(map[string]interface{}); ok {
+			if patchChild, ok := patchValue.(map[string]interface{}); ok {
+				base[key] = mergeMaps(baseValue, patchChild)
+				continue
+			}
+		}
+		base[key] = patchValue
+	}
+	return base
+}
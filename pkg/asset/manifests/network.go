@@ -101,6 +101,26 @@ func (no *Networking) Generate(dependencies asset.Parents) error {
 		return errors.Wrapf(err, "failed to create %s manifests from InstallConfig", no.Name())
 	}
 
+	if apiServer := installConfig.Config.APIServer; apiServer != nil && apiServer.ServiceNodePortRange != "" {
+		// The vendored API types predate spec.serviceNodePortRange;
+		// inject it through a map so the setting is active from the
+		// first rollout.
+		object := map[string]interface{}{}
+		if err := yaml.Unmarshal(configData, &object); err != nil {
+			return errors.Wrapf(err, "failed to reparse %s", noCfgFilename)
+		}
+		spec, _ := object["spec"].(map[string]interface{})
+		if spec == nil {
+			spec = map[string]interface{}{}
+		}
+		spec["serviceNodePortRange"] = apiServer.ServiceNodePortRange
+		object["spec"] = spec
+		configData, err = yaml.Marshal(object)
+		if err != nil {
+			return errors.Wrapf(err, "failed to marshal %s", noCfgFilename)
+		}
+	}
+
 	crdContents := ""
 	for _, crdFile := range crds.Files() {
 		crdContents = fmt.Sprintf("%s\n---\n%s", crdContents, crdFile.Data)
@@ -233,6 +233,16 @@ func (o *Openshift) Generate(dependencies asset.Parents) error {
 
 	o.FileList = append(o.FileList, openshiftInstall.Files()...)
 
+	extra, err := extraManifestFiles(openshiftManifestDir, o.FileList)
+	if err != nil {
+		return err
+	}
+	o.FileList = append(o.FileList, extra...)
+
+	if KustomizeBase {
+		o.FileList = append(o.FileList, kustomizationFile(openshiftManifestDir, o.FileList))
+	}
+
 	asset.SortFiles(o.FileList)
 
 	return nil
@@ -258,6 +268,12 @@ func (o *Openshift) Load(f asset.FileFetcher) (bool, error) {
 		o.FileList = append(o.FileList, file)
 	}
 
+	o.FileList = dropKustomization(openshiftManifestDir, o.FileList)
+	o.FileList, err = applyOverlays(f, openshiftManifestDir, o.FileList)
+	if err != nil {
+		return false, err
+	}
+
 	asset.SortFiles(o.FileList)
 	return len(o.FileList) > 0, nil
 }
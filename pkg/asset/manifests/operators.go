@@ -60,7 +60,9 @@ func (m *Manifests) Dependencies() []asset.Asset {
 		&Infrastructure{},
 		&Networking{},
 		&Proxy{},
+		&APIServer{},
 		&Scheduler{},
+		&Image{},
 		&ImageContentSourcePolicy{},
 		&tls.RootCA{},
 		&tls.EtcdSignerCertKey{},
@@ -99,9 +101,11 @@ func (m *Manifests) Generate(dependencies asset.Parents) error {
 	infra := &Infrastructure{}
 	installConfig := &installconfig.InstallConfig{}
 	proxy := &Proxy{}
+	apiServer := &APIServer{}
 	scheduler := &Scheduler{}
+	image := &Image{}
 	imageContentSourcePolicy := &ImageContentSourcePolicy{}
-	dependencies.Get(installConfig, ingress, dns, network, infra, proxy, scheduler, imageContentSourcePolicy)
+	dependencies.Get(installConfig, ingress, dns, network, infra, proxy, apiServer, scheduler, image, imageContentSourcePolicy)
 
 	redactedConfig, err := redactedInstallConfig(*installConfig.Config)
 	if err != nil {
@@ -129,9 +133,21 @@ func (m *Manifests) Generate(dependencies asset.Parents) error {
 	m.FileList = append(m.FileList, network.Files()...)
 	m.FileList = append(m.FileList, infra.Files()...)
 	m.FileList = append(m.FileList, proxy.Files()...)
+	m.FileList = append(m.FileList, apiServer.Files()...)
 	m.FileList = append(m.FileList, scheduler.Files()...)
+	m.FileList = append(m.FileList, image.Files()...)
 	m.FileList = append(m.FileList, imageContentSourcePolicy.Files()...)
 
+	extra, err := extraManifestFiles(manifestDir, m.FileList)
+	if err != nil {
+		return err
+	}
+	m.FileList = append(m.FileList, extra...)
+
+	if KustomizeBase {
+		m.FileList = append(m.FileList, kustomizationFile(manifestDir, m.FileList))
+	}
+
 	asset.SortFiles(m.FileList)
 
 	return nil
@@ -258,6 +274,12 @@ func (m *Manifests) Load(f asset.FileFetcher) (bool, error) {
 
 	}
 
+	fileList = dropKustomization(manifestDir, fileList)
+	fileList, err = applyOverlays(f, manifestDir, fileList)
+	if err != nil {
+		return false, err
+	}
+
 	m.FileList, m.KubeSysConfig = fileList, kubeSysConfig
 
 	asset.SortFiles(m.FileList)
@@ -267,6 +289,11 @@ func (m *Manifests) Load(f asset.FileFetcher) (bool, error) {
 
 func redactedInstallConfig(config types.InstallConfig) ([]byte, error) {
 	config.PullSecret = ""
+	if config.Proxy != nil && config.Proxy.ClientKey != "" {
+		p := *config.Proxy
+		p.ClientKey = ""
+		config.Proxy = &p
+	}
 	if config.Platform.VSphere != nil {
 		p := *config.Platform.VSphere
 		p.Username = ""
@@ -9,6 +9,7 @@ import (
 	"github.com/ghodss/yaml"
 	configv1 "github.com/openshift/api/config/v1"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 
@@ -20,7 +21,10 @@ import (
 	"github.com/openshift/installer/pkg/types/openstack"
 )
 
-var proxyCfgFilename = filepath.Join(manifestDir, "cluster-proxy-01-config.yaml")
+var (
+	proxyCfgFilename          = filepath.Join(manifestDir, "cluster-proxy-01-config.yaml")
+	proxyClientSecretFilename = filepath.Join(manifestDir, "cluster-proxy-01-client-cert-secret.yaml")
+)
 
 // Proxy generates the cluster-proxy-*.yml files.
 type Proxy struct {
@@ -99,6 +103,32 @@ func (p *Proxy) Generate(dependencies asset.Parents) error {
 		},
 	}
 
+	if proxyConfig := installConfig.Config.Proxy; proxyConfig != nil && proxyConfig.ClientCertificate != "" {
+		secret := &corev1.Secret{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: corev1.SchemeGroupVersion.String(),
+				Kind:       "Secret",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "openshift-config",
+				Name:      "proxy-client-cert",
+			},
+			Type: corev1.SecretTypeTLS,
+			StringData: map[string]string{
+				corev1.TLSCertKey:       proxyConfig.ClientCertificate,
+				corev1.TLSPrivateKeyKey: proxyConfig.ClientKey,
+			},
+		}
+		secretData, err := yaml.Marshal(secret)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal proxy client certificate secret")
+		}
+		p.FileList = append(p.FileList, &asset.File{
+			Filename: proxyClientSecretFilename,
+			Data:     secretData,
+		})
+	}
+
 	return nil
 }
 
@@ -0,0 +1,67 @@
+package manifests
+
+import (
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	"github.com/openshift/installer/pkg/asset"
+)
+
+// strictUserManifestValidationEnv turns the mismatch warnings below
+// into errors.
+const strictUserManifestValidationEnv = "OPENSHIFT_INSTALL_STRICT_MANIFEST_VALIDATION"
+
+var clusterAPIClusterLabel = regexp.MustCompile(`machine\.openshift\.io/cluster-api-cluster["']?\s*:\s*["']?([A-Za-z0-9-]+)`)
+
+// ValidateUserManifests scans the user-provided manifests for values
+// copied from another cluster: a cluster-api-cluster label that does
+// not match this cluster's infra ID, or api/api-int hostnames under a
+// different cluster domain. Findings are warnings naming the file and
+// the mismatched value, or errors when
+// OPENSHIFT_INSTALL_STRICT_MANIFEST_VALIDATION=true.
+func ValidateUserManifests(files []*asset.File, infraID string, clusterDomain string) error {
+	inventoryMutex.Lock()
+	userPaths := make(map[string]bool, len(userManifestPaths))
+	for path := range userManifestPaths {
+		userPaths[path] = true
+	}
+	inventoryMutex.Unlock()
+
+	domainPattern := regexp.MustCompile(`\bapi(?:-int)?\.([a-z0-9][a-z0-9.-]+)`)
+
+	var findings []error
+	for _, file := range files {
+		if !userPaths[file.Filename] {
+			continue
+		}
+		content := string(file.Data)
+		for _, match := range clusterAPIClusterLabel.FindAllStringSubmatch(content, -1) {
+			if match[1] != infraID {
+				findings = append(findings, errors.Errorf("%s references cluster %q via machine.openshift.io/cluster-api-cluster, but this cluster's infra ID is %q", file.Filename, match[1], infraID))
+			}
+		}
+		for _, match := range domainPattern.FindAllStringSubmatch(content, -1) {
+			domain := strings.TrimSuffix(match[1], ".")
+			if domain != clusterDomain && !strings.HasSuffix(domain, "."+clusterDomain) {
+				findings = append(findings, errors.Errorf("%s references %s, but this cluster's domain is %s", file.Filename, match[0], clusterDomain))
+			}
+		}
+	}
+
+	if len(findings) == 0 {
+		return nil
+	}
+	if os.Getenv(strictUserManifestValidationEnv) == "true" {
+		return errors.Wrap(utilerrors.NewAggregate(findings), "user-provided manifests reference another cluster")
+	}
+	for _, finding := range findings {
+		logrus.Warnf("User manifest mismatch: %v", finding)
+	}
+	logrus.Warnf("Set %s=true to fail on the mismatches above", strictUserManifestValidationEnv)
+	return nil
+}
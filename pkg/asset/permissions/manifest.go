@@ -0,0 +1,124 @@
+// Package permissions generates a manifest of the exact cloud
+// permissions an install with the given install-config will need.
+package permissions
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	awsconfig "github.com/openshift/installer/pkg/asset/installconfig/aws"
+	azureconfig "github.com/openshift/installer/pkg/asset/installconfig/azure"
+	gcpconfig "github.com/openshift/installer/pkg/asset/installconfig/gcp"
+	awstypes "github.com/openshift/installer/pkg/types/aws"
+	azuretypes "github.com/openshift/installer/pkg/types/azure"
+	gcptypes "github.com/openshift/installer/pkg/types/gcp"
+)
+
+const manifestFilename = "permissions-manifest.json"
+
+// Manifest generates the permissions-manifest.json file so teams can
+// provision scoped credentials before running the install. The set
+// depends on the install-config: using a pre-existing network drops
+// the networking permissions.
+type Manifest struct {
+	File *asset.File
+}
+
+var _ asset.WritableAsset = (*Manifest)(nil)
+
+// Name returns the human-friendly name of the asset.
+func (m *Manifest) Name() string {
+	return "Permissions Manifest"
+}
+
+// Dependencies returns the direct dependencies of the asset.
+func (m *Manifest) Dependencies() []asset.Asset {
+	return []asset.Asset{
+		&installconfig.InstallConfig{},
+	}
+}
+
+// Generate generates the permissions manifest for the configured
+// platform.
+func (m *Manifest) Generate(parents asset.Parents) error {
+	installConfig := &installconfig.InstallConfig{}
+	parents.Get(installConfig)
+	config := installConfig.Config
+
+	var document interface{}
+	switch platform := config.Platform.Name(); platform {
+	case awstypes.Name:
+		groups := []awsconfig.PermissionGroup{awsconfig.PermissionCreateBase, awsconfig.PermissionDeleteBase}
+		if len(config.AWS.Subnets) == 0 {
+			groups = append(groups, awsconfig.PermissionCreateNetworking, awsconfig.PermissionDeleteNetworking)
+		}
+		document = awsPolicyDocument(awsconfig.Permissions(groups))
+	case azuretypes.Name:
+		actions := azureconfig.RoleDefinitionActions(config.Azure.VirtualNetwork != "")
+		sort.Strings(actions)
+		document = map[string]interface{}{
+			"Name":        fmt.Sprintf("openshift-installer-%s", config.ObjectMeta.Name),
+			"Description": "Minimal role for installing an OpenShift cluster with this install-config",
+			"Actions":     actions,
+			"AssignableScopes": []string{
+				"/subscriptions/<subscription-id>",
+			},
+		}
+	case gcptypes.Name:
+		permissions := gcpconfig.RequiredPermissions(config.GCP.Network != "")
+		sort.Strings(permissions)
+		document = map[string]interface{}{
+			"title":               fmt.Sprintf("openshift-installer-%s", config.ObjectMeta.Name),
+			"description":         "Minimal role for installing an OpenShift cluster with this install-config",
+			"stage":               "GA",
+			"includedPermissions": permissions,
+		}
+	default:
+		return errors.Errorf("platform %q does not have a permissions catalog", platform)
+	}
+
+	data, err := json.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal permissions manifest")
+	}
+
+	m.File = &asset.File{
+		Filename: manifestFilename,
+		Data:     append(data, '\n'),
+	}
+	return nil
+}
+
+// awsPolicyDocument wraps the IAM actions in a standard policy
+// document.
+func awsPolicyDocument(actions []string) interface{} {
+	return map[string]interface{}{
+		"Version": "2012-10-17",
+		"Statement": []map[string]interface{}{
+			{
+				"Effect":   "Allow",
+				"Action":   actions,
+				"Resource": "*",
+			},
+		},
+	}
+}
+
+// Files returns the files generated by the asset.
+func (m *Manifest) Files() []*asset.File {
+	if m.File != nil {
+		return []*asset.File{m.File}
+	}
+	return []*asset.File{}
+}
+
+// Load returns false, because the manifest is always regenerated from
+// the install config.
+func (m *Manifest) Load(f asset.FileFetcher) (found bool, err error) {
+	return false, nil
+}
@@ -0,0 +1,76 @@
+package permissions
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	"github.com/openshift/installer/pkg/types"
+	awstypes "github.com/openshift/installer/pkg/types/aws"
+	azuretypes "github.com/openshift/installer/pkg/types/azure"
+	gcptypes "github.com/openshift/installer/pkg/types/gcp"
+	nonetypes "github.com/openshift/installer/pkg/types/none"
+)
+
+func generate(t *testing.T, config *types.InstallConfig) string {
+	parents := asset.Parents{}
+	parents.Add(&installconfig.InstallConfig{Config: config})
+	manifest := &Manifest{}
+	if err := manifest.Generate(parents); err != nil {
+		t.Fatal(err)
+	}
+	return string(manifest.Files()[0].Data)
+}
+
+func baseConfig(platform types.Platform) *types.InstallConfig {
+	return &types.InstallConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Platform:   platform,
+	}
+}
+
+func TestAWSManifestIncludesNetworkingOnlyForNewVPC(t *testing.T) {
+	newVPC := generate(t, baseConfig(types.Platform{AWS: &awstypes.Platform{Region: "us-east-1"}}))
+	assert.Contains(t, newVPC, "ec2:CreateVpc")
+	assert.Contains(t, newVPC, "ec2:RunInstances")
+
+	existingVPC := generate(t, baseConfig(types.Platform{AWS: &awstypes.Platform{
+		Region:  "us-east-1",
+		Subnets: []string{"subnet-1"},
+	}}))
+	assert.NotContains(t, existingVPC, "ec2:CreateVpc")
+	assert.Contains(t, existingVPC, "ec2:RunInstances")
+}
+
+func TestAzureManifestIncludesNetworkingOnlyForNewVNet(t *testing.T) {
+	newVNet := generate(t, baseConfig(types.Platform{Azure: &azuretypes.Platform{Region: "eastus"}}))
+	assert.Contains(t, newVNet, "Microsoft.Network/virtualNetworks/write")
+
+	existingVNet := generate(t, baseConfig(types.Platform{Azure: &azuretypes.Platform{
+		Region:         "eastus",
+		VirtualNetwork: "vnet",
+	}}))
+	assert.NotContains(t, existingVNet, "Microsoft.Network/virtualNetworks/write")
+}
+
+func TestGCPManifestIncludesNetworkingOnlyForNewNetwork(t *testing.T) {
+	newNetwork := generate(t, baseConfig(types.Platform{GCP: &gcptypes.Platform{Region: "us-east1"}}))
+	assert.Contains(t, newNetwork, "compute.networks.create")
+
+	existingNetwork := generate(t, baseConfig(types.Platform{GCP: &gcptypes.Platform{
+		Region:  "us-east1",
+		Network: "network",
+	}}))
+	assert.True(t, !strings.Contains(existingNetwork, "compute.networks.create"))
+}
+
+func TestUnsupportedPlatform(t *testing.T) {
+	parents := asset.Parents{}
+	parents.Add(&installconfig.InstallConfig{Config: baseConfig(types.Platform{None: &nonetypes.Platform{}})})
+	manifest := &Manifest{}
+	assert.Error(t, manifest.Generate(parents))
+}
@@ -0,0 +1,223 @@
+package permissions
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	awsconfig "github.com/openshift/installer/pkg/asset/installconfig/aws"
+	azureconfig "github.com/openshift/installer/pkg/asset/installconfig/azure"
+	gcpconfig "github.com/openshift/installer/pkg/asset/installconfig/gcp"
+	"github.com/openshift/installer/pkg/asset/releaseimage"
+	awstypes "github.com/openshift/installer/pkg/types/aws"
+	azuretypes "github.com/openshift/installer/pkg/types/azure"
+	gcptypes "github.com/openshift/installer/pkg/types/gcp"
+)
+
+const (
+	reportJSONFilename     = "permissions-report.json"
+	reportMarkdownFilename = "permissions-report.md"
+)
+
+// Report generates permissions-report.{json,md}: the installer's own
+// static permissions merged with the per-operator permissions from the
+// CredentialsRequests carried by the resolved release image.
+type Report struct {
+	FileList []*asset.File
+}
+
+var _ asset.WritableAsset = (*Report)(nil)
+
+// permissionsReport is the JSON shape of the report.
+type permissionsReport struct {
+	ReleaseImage string              `json:"releaseImage"`
+	Platform     string              `json:"platform"`
+	Installer    []string            `json:"installer"`
+	Operators    map[string][]string `json:"operators,omitempty"`
+}
+
+// Name returns the human-friendly name of the asset.
+func (r *Report) Name() string {
+	return "Permissions Report"
+}
+
+// Dependencies returns the direct dependencies of the asset.
+func (r *Report) Dependencies() []asset.Asset {
+	return []asset.Asset{
+		&installconfig.InstallConfig{},
+		&releaseimage.Image{},
+	}
+}
+
+// Generate generates the permissions report.
+func (r *Report) Generate(parents asset.Parents) error {
+	installConfig := &installconfig.InstallConfig{}
+	releaseImage := &releaseimage.Image{}
+	parents.Get(installConfig, releaseImage)
+	config := installConfig.Config
+	platform := config.Platform.Name()
+
+	report := permissionsReport{
+		ReleaseImage: releaseImage.PullSpec,
+		Platform:     platform,
+	}
+
+	switch platform {
+	case awstypes.Name:
+		groups := []awsconfig.PermissionGroup{awsconfig.PermissionCreateBase, awsconfig.PermissionDeleteBase}
+		if len(config.AWS.Subnets) == 0 {
+			groups = append(groups, awsconfig.PermissionCreateNetworking, awsconfig.PermissionDeleteNetworking)
+		}
+		report.Installer = awsconfig.Permissions(groups)
+	case azuretypes.Name:
+		report.Installer = azureconfig.RoleDefinitionActions(config.Azure.VirtualNetwork != "")
+		sort.Strings(report.Installer)
+	case gcptypes.Name:
+		report.Installer = gcpconfig.RequiredPermissions(config.GCP.Network != "")
+		sort.Strings(report.Installer)
+	default:
+		return errors.Errorf("platform %q does not have a permissions catalog", platform)
+	}
+
+	operators, err := extractCredentialsRequests(releaseImage.PullSpec, platform)
+	if err != nil {
+		logrus.Warnf("Unable to extract CredentialsRequests from the release image; the report covers only the installer's permissions: %v", err)
+	} else {
+		report.Operators = operators
+	}
+
+	jsonData, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal permissions report")
+	}
+	r.FileList = []*asset.File{
+		{Filename: reportJSONFilename, Data: append(jsonData, '\n')},
+		{Filename: reportMarkdownFilename, Data: renderMarkdown(report)},
+	}
+	return nil
+}
+
+// extractCredentialsRequests extracts the CredentialsRequest manifests
+// from the release image using the oc binary when available, and
+// returns the per-operator permission lists for the platform.
+func extractCredentialsRequests(pullSpec, platform string) (map[string][]string, error) {
+	ocPath, err := exec.LookPath("oc")
+	if err != nil {
+		return nil, errors.New("oc binary not found in PATH")
+	}
+
+	dir, err := ioutil.TempDir("", "openshift-install-credreqs-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	cmd := exec.Command(ocPath, "adm", "release", "extract", "--credentials-requests", "--to", dir, pullSpec)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return nil, errors.Wrapf(err, "oc adm release extract failed: %s", strings.TrimSpace(string(output)))
+	}
+
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	operators := map[string][]string{}
+	for _, entry := range entries {
+		data, err := ioutil.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			continue
+		}
+		name, permissions := parseCredentialsRequest(data, platform)
+		if name != "" && len(permissions) > 0 {
+			operators[name] = permissions
+		}
+	}
+	return operators, nil
+}
+
+// parseCredentialsRequest pulls the platform-specific permission list
+// out of a single CredentialsRequest manifest.
+func parseCredentialsRequest(data []byte, platform string) (string, []string) {
+	request := struct {
+		Metadata struct {
+			Name string `json:"name"`
+		} `json:"metadata"`
+		Spec struct {
+			ProviderSpec struct {
+				Kind            string   `json:"kind"`
+				PredefinedRoles []string `json:"predefinedRoles"`
+				RoleBindings    []struct {
+					Role string `json:"role"`
+				} `json:"roleBindings"`
+				StatementEntries []struct {
+					Action []string `json:"action"`
+				} `json:"statementEntries"`
+			} `json:"providerSpec"`
+		} `json:"spec"`
+	}{}
+	if err := yaml.Unmarshal(data, &request); err != nil {
+		return "", nil
+	}
+
+	spec := request.Spec.ProviderSpec
+	var permissions []string
+	switch {
+	case platform == awstypes.Name && spec.Kind == "AWSProviderSpec":
+		for _, statement := range spec.StatementEntries {
+			permissions = append(permissions, statement.Action...)
+		}
+	case platform == gcptypes.Name && spec.Kind == "GCPProviderSpec":
+		permissions = spec.PredefinedRoles
+	case platform == azuretypes.Name && spec.Kind == "AzureProviderSpec":
+		for _, binding := range spec.RoleBindings {
+			permissions = append(permissions, binding.Role)
+		}
+	}
+	sort.Strings(permissions)
+	return request.Metadata.Name, permissions
+}
+
+// renderMarkdown renders the report for human review.
+func renderMarkdown(report permissionsReport) []byte {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "# Cloud permissions report\n\n")
+	fmt.Fprintf(&builder, "Release image: `%s`\n\nPlatform: %s\n\n", report.ReleaseImage, report.Platform)
+	fmt.Fprintf(&builder, "## Installer\n\n")
+	for _, permission := range report.Installer {
+		fmt.Fprintf(&builder, "- `%s`\n", permission)
+	}
+	names := make([]string, 0, len(report.Operators))
+	for name := range report.Operators {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(&builder, "\n## Operator: %s\n\n", name)
+		for _, permission := range report.Operators[name] {
+			fmt.Fprintf(&builder, "- `%s`\n", permission)
+		}
+	}
+	return []byte(builder.String())
+}
+
+// Files returns the files generated by the asset.
+func (r *Report) Files() []*asset.File {
+	return r.FileList
+}
+
+// Load returns false, because the report is always regenerated.
+func (r *Report) Load(f asset.FileFetcher) (found bool, err error) {
+	return false, nil
+}
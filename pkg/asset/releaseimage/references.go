@@ -0,0 +1,99 @@
+package releaseimage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// References returns the pull specs of every image referenced by the
+// release payload, using the oc binary to read the release metadata.
+// When oc is unavailable the list degrades to the release image
+// itself, so callers stay functional on hosts without oc.
+func References(pullSpec string) []string {
+	references, err := releaseReferences(pullSpec)
+	if err != nil {
+		logrus.Warnf("Unable to read the release image references, using only the release image itself: %v", err)
+		return []string{pullSpec}
+	}
+	return references
+}
+
+func releaseReferences(pullSpec string) ([]string, error) {
+	ocPath, err := exec.LookPath("oc")
+	if err != nil {
+		return nil, errors.New("oc binary not found in PATH")
+	}
+
+	output, err := exec.Command(ocPath, "adm", "release", "info", "--output=json", pullSpec).Output()
+	if err != nil {
+		return nil, errors.Wrap(err, "oc adm release info failed")
+	}
+
+	info := struct {
+		References struct {
+			Spec struct {
+				Tags []struct {
+					From struct {
+						Name string `json:"name"`
+					} `json:"from"`
+				} `json:"tags"`
+			} `json:"spec"`
+		} `json:"references"`
+	}{}
+	if err := json.Unmarshal(output, &info); err != nil {
+		return nil, errors.Wrap(err, "failed to parse release info")
+	}
+
+	seen := map[string]bool{}
+	references := []string{pullSpec}
+	for _, tag := range info.References.Spec.Tags {
+		name := strings.TrimSpace(tag.From.Name)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		references = append(references, name)
+	}
+	sort.Strings(references[1:])
+	return references, nil
+}
+
+// Digest resolves a release image pull spec to its pinned
+// <repository>@<digest> form, using the oc binary like References
+// does. Pull specs that already carry a digest come back unchanged.
+func Digest(pullSpec string) (string, error) {
+	if strings.Contains(pullSpec, "@sha256:") {
+		return pullSpec, nil
+	}
+
+	ocPath, err := exec.LookPath("oc")
+	if err != nil {
+		return "", errors.New("oc binary not found in PATH")
+	}
+	output, err := exec.Command(ocPath, "adm", "release", "info", "--output=json", pullSpec).Output()
+	if err != nil {
+		return "", errors.Wrap(err, "oc adm release info failed")
+	}
+
+	info := struct {
+		Digest string `json:"digest"`
+	}{}
+	if err := json.Unmarshal(output, &info); err != nil {
+		return "", errors.Wrap(err, "failed to parse release info")
+	}
+	if info.Digest == "" {
+		return "", errors.New("the release info carries no digest")
+	}
+
+	repository := pullSpec
+	if idx := strings.LastIndex(repository, ":"); idx > strings.LastIndex(repository, "/") {
+		repository = repository[:idx]
+	}
+	return fmt.Sprintf("%s@%s", repository, info.Digest), nil
+}
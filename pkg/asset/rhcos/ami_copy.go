@@ -0,0 +1,71 @@
+package rhcos
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	awsconfig "github.com/openshift/installer/pkg/asset/installconfig/aws"
+	"github.com/openshift/installer/pkg/types"
+)
+
+// amiCopyTimeout bounds the encrypted AMI copy; copying and
+// re-encrypting the RHCOS snapshots routinely takes several minutes.
+const amiCopyTimeout = 20 * time.Minute
+
+// copyAMIWithEncryption copies the RHCOS AMI into the account with EBS
+// encryption using the customer-managed KMS key from the install
+// config, waits for the copy to become available and tags it with the
+// cluster's ownership tag so the tag-based destroyer deregisters it
+// (and deletes its snapshots) with the rest of the cluster.
+func copyAMIWithEncryption(config *types.InstallConfig, infraID string, sourceAMI string) (string, error) {
+	region := config.Platform.AWS.Region
+	kmsKeyID := config.Platform.AWS.AMIEncryption.KMSKeyID
+
+	session, err := awsconfig.GetSession()
+	if err != nil {
+		return "", err
+	}
+	client := ec2.New(session, aws.NewConfig().WithRegion(region))
+
+	ctx, cancel := context.WithTimeout(context.TODO(), amiCopyTimeout)
+	defer cancel()
+
+	logrus.Infof("Copying AMI %s with EBS encryption using KMS key %s...", sourceAMI, kmsKeyID)
+	copied, err := client.CopyImageWithContext(ctx, &ec2.CopyImageInput{
+		Name:          aws.String(fmt.Sprintf("%s-rhcos-encrypted", infraID)),
+		Description:   aws.String(fmt.Sprintf("Encrypted copy of %s for cluster %s", sourceAMI, infraID)),
+		SourceImageId: aws.String(sourceAMI),
+		SourceRegion:  aws.String(region),
+		Encrypted:     aws.Bool(true),
+		KmsKeyId:      aws.String(kmsKeyID),
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to copy AMI %s with encryption", sourceAMI)
+	}
+
+	amiID := aws.StringValue(copied.ImageId)
+	if _, err := client.CreateTagsWithContext(ctx, &ec2.CreateTagsInput{
+		Resources: []*string{copied.ImageId},
+		Tags: []*ec2.Tag{
+			{Key: aws.String(fmt.Sprintf("kubernetes.io/cluster/%s", infraID)), Value: aws.String("owned")},
+			{Key: aws.String("Name"), Value: aws.String(fmt.Sprintf("%s-rhcos-encrypted", infraID))},
+		},
+	}); err != nil {
+		return "", errors.Wrapf(err, "failed to tag encrypted AMI %s", amiID)
+	}
+
+	logrus.Infof("Waiting up to %v for encrypted AMI %s to become available...", amiCopyTimeout, amiID)
+	if err := client.WaitUntilImageAvailableWithContext(ctx, &ec2.DescribeImagesInput{
+		ImageIds: []*string{copied.ImageId},
+	}); err != nil {
+		return "", errors.Wrapf(err, "encrypted AMI %s did not become available", amiID)
+	}
+
+	return amiID, nil
+}
@@ -40,6 +40,7 @@ func (i *Image) Name() string {
 func (i *Image) Dependencies() []asset.Asset {
 	return []asset.Asset{
 		&installconfig.InstallConfig{},
+		&installconfig.ClusterID{},
 	}
 }
 
@@ -52,12 +53,21 @@ func (i *Image) Generate(p asset.Parents) error {
 	}
 
 	ic := &installconfig.InstallConfig{}
-	p.Get(ic)
+	clusterID := &installconfig.ClusterID{}
+	p.Get(ic, clusterID)
 	config := ic.Config
 	osimage, err := osImage(config)
 	if err != nil {
 		return err
 	}
+
+	if config.Platform.AWS != nil && config.Platform.AWS.AMIEncryption != nil {
+		osimage, err = copyAMIWithEncryption(config, clusterID.InfraID, osimage)
+		if err != nil {
+			return err
+		}
+	}
+
 	*i = Image(osimage)
 	return nil
 }
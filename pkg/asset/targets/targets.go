@@ -10,6 +10,7 @@ import (
 	"github.com/openshift/installer/pkg/asset/machines"
 	"github.com/openshift/installer/pkg/asset/manifests"
 	"github.com/openshift/installer/pkg/asset/password"
+	"github.com/openshift/installer/pkg/asset/permissions"
 	"github.com/openshift/installer/pkg/asset/templates/content/bootkube"
 	"github.com/openshift/installer/pkg/asset/templates/content/openshift"
 	"github.com/openshift/installer/pkg/asset/tls"
@@ -51,6 +52,16 @@ var (
 		&openshift.RoleCloudCredsSecretReader{},
 	}
 
+	// PermissionsManifest are the permissions-manifest targeted assets.
+	PermissionsManifest = []asset.WritableAsset{
+		&permissions.Manifest{},
+	}
+
+	// PermissionsReport are the permissions-report targeted assets.
+	PermissionsReport = []asset.WritableAsset{
+		&permissions.Report{},
+	}
+
 	// IgnitionConfigs are the ignition-configs targeted assets.
 	IgnitionConfigs = []asset.WritableAsset{
 		&kubeconfig.AdminClient{},
@@ -68,6 +79,7 @@ var (
 		&password.KubeadminPassword{},
 		&tls.JournalCertKey{},
 		&cluster.Metadata{},
+		&cluster.Provenance{},
 		&cluster.Cluster{},
 	}
 )
@@ -5,6 +5,7 @@ import (
 	"crypto/x509/pkix"
 
 	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
 )
 
 // AdminKubeConfigSignerCertKey is a key/cert pair that signs the admin kubeconfig client certs.
@@ -16,7 +17,9 @@ var _ asset.WritableAsset = (*AdminKubeConfigSignerCertKey)(nil)
 
 // Dependencies returns the dependency of the root-ca, which is empty.
 func (c *AdminKubeConfigSignerCertKey) Dependencies() []asset.Asset {
-	return []asset.Asset{}
+	return []asset.Asset{
+		&installconfig.InstallConfig{},
+	}
 }
 
 // Generate generates the root-ca key and cert pair.
@@ -28,7 +31,10 @@ func (c *AdminKubeConfigSignerCertKey) Generate(parents asset.Parents) error {
 		IsCA:      true,
 	}
 
-	return c.SelfSignedCertKey.Generate(cfg, "admin-kubeconfig-signer")
+	installConfig := &installconfig.InstallConfig{}
+	parents.Get(installConfig)
+
+	return c.SelfSignedCertKey.Generate(installConfig.Config.ExperimentalExternalSigners, cfg, "admin-kubeconfig-signer")
 }
 
 // Name returns the human-friendly name of the asset.
@@ -66,7 +72,7 @@ func (a *AdminKubeConfigCABundle) Name() string {
 	return "Certificate (admin-kubeconfig-ca-bundle)"
 }
 
-//AdminKubeConfigClientCertKey is the asset that generates the key/cert pair for admin client to apiserver.
+// AdminKubeConfigClientCertKey is the asset that generates the key/cert pair for admin client to apiserver.
 type AdminKubeConfigClientCertKey struct {
 	SignedCertKey
 }
@@ -5,6 +5,7 @@ import (
 	"crypto/x509/pkix"
 
 	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
 )
 
 // AggregatorCA is the asset that generates the aggregator-ca key/cert pair.
@@ -19,7 +20,9 @@ var _ asset.Asset = (*AggregatorCA)(nil)
 // the parent CA, and install config if it depends on the install config for
 // DNS names, etc.
 func (a *AggregatorCA) Dependencies() []asset.Asset {
-	return []asset.Asset{}
+	return []asset.Asset{
+		&installconfig.InstallConfig{},
+	}
 }
 
 // Generate generates the cert/key pair based on its dependencies.
@@ -31,7 +34,10 @@ func (a *AggregatorCA) Generate(dependencies asset.Parents) error {
 		IsCA:      true,
 	}
 
-	return a.SelfSignedCertKey.Generate(cfg, "aggregator-ca")
+	installConfig := &installconfig.InstallConfig{}
+	dependencies.Get(installConfig)
+
+	return a.SelfSignedCertKey.Generate(installConfig.Config.ExperimentalExternalSigners, cfg, "aggregator-ca")
 }
 
 // Name returns the human-friendly name of the asset.
@@ -85,7 +91,9 @@ var _ asset.WritableAsset = (*AggregatorSignerCertKey)(nil)
 
 // Dependencies returns the dependency of the root-ca, which is empty.
 func (c *AggregatorSignerCertKey) Dependencies() []asset.Asset {
-	return []asset.Asset{}
+	return []asset.Asset{
+		&installconfig.InstallConfig{},
+	}
 }
 
 // Generate generates the root-ca key and cert pair.
@@ -97,7 +105,10 @@ func (c *AggregatorSignerCertKey) Generate(parents asset.Parents) error {
 		IsCA:      true,
 	}
 
-	return c.SelfSignedCertKey.Generate(cfg, "aggregator-signer")
+	installConfig := &installconfig.InstallConfig{}
+	parents.Get(installConfig)
+
+	return c.SelfSignedCertKey.Generate(installConfig.Config.ExperimentalExternalSigners, cfg, "aggregator-signer")
 }
 
 // Name returns the human-friendly name of the asset.
@@ -19,7 +19,9 @@ var _ asset.WritableAsset = (*KubeAPIServerToKubeletSignerCertKey)(nil)
 
 // Dependencies returns the dependency of the root-ca, which is empty.
 func (c *KubeAPIServerToKubeletSignerCertKey) Dependencies() []asset.Asset {
-	return []asset.Asset{}
+	return []asset.Asset{
+		&installconfig.InstallConfig{},
+	}
 }
 
 // Generate generates the root-ca key and cert pair.
@@ -31,7 +33,10 @@ func (c *KubeAPIServerToKubeletSignerCertKey) Generate(parents asset.Parents) er
 		IsCA:      true,
 	}
 
-	return c.SelfSignedCertKey.Generate(cfg, "kube-apiserver-to-kubelet-signer")
+	installConfig := &installconfig.InstallConfig{}
+	parents.Get(installConfig)
+
+	return c.SelfSignedCertKey.Generate(installConfig.Config.ExperimentalExternalSigners, cfg, "kube-apiserver-to-kubelet-signer")
 }
 
 // Name returns the human-friendly name of the asset.
@@ -112,7 +117,9 @@ var _ asset.WritableAsset = (*KubeAPIServerLocalhostSignerCertKey)(nil)
 
 // Dependencies returns the dependency of the root-ca, which is empty.
 func (c *KubeAPIServerLocalhostSignerCertKey) Dependencies() []asset.Asset {
-	return []asset.Asset{}
+	return []asset.Asset{
+		&installconfig.InstallConfig{},
+	}
 }
 
 // Generate generates the root-ca key and cert pair.
@@ -124,7 +131,10 @@ func (c *KubeAPIServerLocalhostSignerCertKey) Generate(parents asset.Parents) er
 		IsCA:      true,
 	}
 
-	return c.SelfSignedCertKey.Generate(cfg, "kube-apiserver-localhost-signer")
+	installConfig := &installconfig.InstallConfig{}
+	parents.Get(installConfig)
+
+	return c.SelfSignedCertKey.Generate(installConfig.Config.ExperimentalExternalSigners, cfg, "kube-apiserver-localhost-signer")
 }
 
 // Name returns the human-friendly name of the asset.
@@ -209,7 +219,9 @@ var _ asset.WritableAsset = (*KubeAPIServerServiceNetworkSignerCertKey)(nil)
 
 // Dependencies returns the dependency of the root-ca, which is empty.
 func (c *KubeAPIServerServiceNetworkSignerCertKey) Dependencies() []asset.Asset {
-	return []asset.Asset{}
+	return []asset.Asset{
+		&installconfig.InstallConfig{},
+	}
 }
 
 // Generate generates the root-ca key and cert pair.
@@ -221,7 +233,10 @@ func (c *KubeAPIServerServiceNetworkSignerCertKey) Generate(parents asset.Parent
 		IsCA:      true,
 	}
 
-	return c.SelfSignedCertKey.Generate(cfg, "kube-apiserver-service-network-signer")
+	installConfig := &installconfig.InstallConfig{}
+	parents.Get(installConfig)
+
+	return c.SelfSignedCertKey.Generate(installConfig.Config.ExperimentalExternalSigners, cfg, "kube-apiserver-service-network-signer")
 }
 
 // Name returns the human-friendly name of the asset.
@@ -317,7 +332,9 @@ var _ asset.WritableAsset = (*KubeAPIServerLBSignerCertKey)(nil)
 
 // Dependencies returns the dependency of the root-ca, which is empty.
 func (c *KubeAPIServerLBSignerCertKey) Dependencies() []asset.Asset {
-	return []asset.Asset{}
+	return []asset.Asset{
+		&installconfig.InstallConfig{},
+	}
 }
 
 // Generate generates the root-ca key and cert pair.
@@ -329,7 +346,10 @@ func (c *KubeAPIServerLBSignerCertKey) Generate(parents asset.Parents) error {
 		IsCA:      true,
 	}
 
-	return c.SelfSignedCertKey.Generate(cfg, "kube-apiserver-lb-signer")
+	installConfig := &installconfig.InstallConfig{}
+	parents.Get(installConfig)
+
+	return c.SelfSignedCertKey.Generate(installConfig.Config.ExperimentalExternalSigners, cfg, "kube-apiserver-lb-signer")
 }
 
 // Name returns the human-friendly name of the asset.
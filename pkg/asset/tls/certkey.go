@@ -28,6 +28,16 @@ type CertKey struct {
 	CertRaw  []byte
 	KeyRaw   []byte
 	FileList []*asset.File
+	// ExternalSignerBinary names the external signer that produced
+	// this certificate, when one did; children of an escrowed CA read
+	// it off their parent to route their own CSRs.
+	ExternalSignerBinary string
+}
+
+// ExternalSigner returns the external signer binary that produced
+// this certificate, or "".
+func (c *CertKey) ExternalSigner() string {
+	return c.ExternalSignerBinary
 }
 
 // Cert returns the certificate.
@@ -96,14 +106,42 @@ func (c *SignedCertKey) Generate(
 	var crt *x509.Certificate
 	var err error
 
-	caKey, err := PemToPrivateKey(parentCA.Key())
+	caCert, err := PemToCertificate(parentCA.Cert())
 	if err != nil {
-		return errors.Wrap(err, "failed to parse rsa private key")
+		return errors.Wrap(err, "failed to parse x509 certificate")
 	}
 
-	caCert, err := PemToCertificate(parentCA.Cert())
+	if len(parentCA.Key()) == 0 {
+		// The parent CA's key is escrowed behind an external signer;
+		// never self-sign in its stead. The parent records which
+		// signer produced it.
+		binary := ""
+		if signed, ok := parentCA.(interface{ ExternalSigner() string }); ok {
+			binary = signed.ExternalSigner()
+		}
+		if binary == "" {
+			return errors.Errorf("CA %q has no local key and no external signer is configured; refusing to self-sign", caCert.Subject.CommonName)
+		}
+		csr, childKey, err := generateCSR(cfg)
+		if err != nil {
+			return err
+		}
+		certPEM, err := signExternally(binary, csr)
+		if err != nil {
+			return err
+		}
+		c.KeyRaw = PrivateKeyToPem(childKey)
+		c.CertRaw = certPEM
+		if appendParent {
+			c.CertRaw = bytes.Join([][]byte{c.CertRaw, CertToPem(caCert)}, []byte("\n"))
+		}
+		c.generateFiles(filenameBase)
+		return nil
+	}
+
+	caKey, err := PemToPrivateKey(parentCA.Key())
 	if err != nil {
-		return errors.Wrap(err, "failed to parse x509 certificate")
+		return errors.Wrap(err, "failed to parse rsa private key")
 	}
 
 	key, crt, err = GenerateSignedCertificate(caKey, caCert, cfg)
@@ -128,11 +166,29 @@ type SelfSignedCertKey struct {
 	CertKey
 }
 
-// Generate generates a cert/key pair signed by the specified parent CA.
+// Generate generates a self-signed cert/key pair, delegating to the
+// external signer configured for the subject's common name, if any.
+// The signers map comes from the install config, threaded in by each
+// CA asset.
 func (c *SelfSignedCertKey) Generate(
+	externalSigners map[string]string,
 	cfg *CertCfg,
 	filenameBase string,
 ) error {
+	if binary := externalSigners[cfg.Subject.CommonName]; binary != "" {
+		certPEM, err := generateExternallySignedCA(binary, cfg, filenameBase)
+		if err != nil {
+			return err
+		}
+		// The private key stays in the external signer's KMS/HSM; only
+		// the certificate materializes locally.
+		c.KeyRaw = nil
+		c.CertRaw = certPEM
+		c.ExternalSignerBinary = binary
+		c.generateFiles(filenameBase)
+		return nil
+	}
+
 	key, crt, err := GenerateSelfSignedCertificate(cfg)
 	if err != nil {
 		return errors.Wrap(err, "failed to generate self-signed cert/key pair")
@@ -7,6 +7,10 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	"github.com/openshift/installer/pkg/types"
 )
 
 func TestSignedCertKeyGenerate(t *testing.T) {
@@ -45,7 +49,9 @@ func TestSignedCertKeyGenerate(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			rootCA := &RootCA{}
-			err := rootCA.Generate(nil)
+			parents := asset.Parents{}
+			parents.Add(&installconfig.InstallConfig{Config: &types.InstallConfig{}})
+			err := rootCA.Generate(parents)
 			assert.NoError(t, err, "failed to generate root CA")
 
 			certKey := &SignedCertKey{}
@@ -5,6 +5,7 @@ import (
 	"crypto/x509/pkix"
 
 	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
 )
 
 // EtcdMetricSignerCertKey is a key/cert pair that signs the etcd-metrics client and server certs.
@@ -16,7 +17,9 @@ var _ asset.WritableAsset = (*EtcdMetricSignerCertKey)(nil)
 
 // Dependencies returns the dependency of the root-ca, which is empty.
 func (c *EtcdMetricSignerCertKey) Dependencies() []asset.Asset {
-	return []asset.Asset{}
+	return []asset.Asset{
+		&installconfig.InstallConfig{},
+	}
 }
 
 // Generate generates the root-ca key and cert pair.
@@ -28,7 +31,10 @@ func (c *EtcdMetricSignerCertKey) Generate(parents asset.Parents) error {
 		IsCA:      true,
 	}
 
-	return c.SelfSignedCertKey.Generate(cfg, "etcd-metric-signer")
+	installConfig := &installconfig.InstallConfig{}
+	parents.Get(installConfig)
+
+	return c.SelfSignedCertKey.Generate(installConfig.Config.ExperimentalExternalSigners, cfg, "etcd-metric-signer")
 }
 
 // Name returns the human-friendly name of the asset.
@@ -0,0 +1,72 @@
+package tls
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"os/exec"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// signExternally invokes the signer binary with csrPEM on stdin and
+// returns the PEM certificate it emits. An unavailable or failing
+// signer is a hard error: silently falling back to local self-signing
+// would defeat the escrow.
+func signExternally(binary string, csrPEM []byte) ([]byte, error) {
+	path, err := exec.LookPath(binary)
+	if err != nil {
+		return nil, errors.Wrapf(err, "external signer %q is not available; refusing to self-sign", binary)
+	}
+
+	cmd := exec.Command(path)
+	cmd.Stdin = bytes.NewReader(csrPEM)
+	stderr := &bytes.Buffer{}
+	cmd.Stderr = stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, errors.Wrapf(err, "external signer %q failed: %s", binary, bytes.TrimSpace(stderr.Bytes()))
+	}
+
+	if _, err := PemToCertificate(output); err != nil {
+		return nil, errors.Wrapf(err, "external signer %q did not return a valid PEM certificate", binary)
+	}
+	return output, nil
+}
+
+// generateExternallySignedCA asks the external signer for the CA
+// certificate: a local key is generated for the CSR but discarded, so
+// the escrowed key stays the only signer for the CA.
+func generateExternallySignedCA(binary string, cfg *CertCfg, filenameBase string) ([]byte, error) {
+	logrus.Infof("Obtaining the %s certificate from the external signer", filenameBase)
+	csr, _, err := generateCSR(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return signExternally(binary, csr)
+}
+
+// generateCSR builds a PEM CSR (and returns the throwaway key) for the
+// given config.
+func generateCSR(cfg *CertCfg) ([]byte, *rsa.PrivateKey, error) {
+	key, err := PrivateKey()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to generate private key for CSR")
+	}
+	csrTmpl := x509.CertificateRequest{
+		Subject:     cfg.Subject,
+		DNSNames:    cfg.DNSNames,
+		IPAddresses: cfg.IPAddresses,
+	}
+	csrBytes, err := x509.CreateCertificateRequest(rand.Reader, &csrTmpl, key)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to create certificate request")
+	}
+	csr, err := x509.ParseCertificateRequest(csrBytes)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "error parsing x509 certificate request")
+	}
+	return CSRToPem(csr), key, nil
+}
@@ -0,0 +1,61 @@
+package aws
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/pkg/errors"
+)
+
+// authErrorCodes are the AWS error codes that mean the credentials
+// lack a permission; retrying them forever can never succeed.
+var authErrorCodes = map[string]bool{
+	"AccessDenied":          true,
+	"AccessDeniedException": true,
+	"AuthFailure":           true,
+	"Forbidden":             true,
+	"UnauthorizedOperation": true,
+}
+
+// isAuthError returns whether err reports missing permissions.
+func isAuthError(err error) bool {
+	for err != nil {
+		if awsErr, ok := err.(awserr.Error); ok {
+			if authErrorCodes[awsErr.Code()] {
+				return true
+			}
+			err = awsErr.OrigErr()
+			continue
+		}
+		cause := errors.Cause(err)
+		if cause == err {
+			return false
+		}
+		err = cause
+	}
+	return false
+}
+
+// callerIdentity returns the ARN of the principal behind the session,
+// best-effort, for permission error messages.
+func callerIdentity(awsSession *session.Session) string {
+	output, err := sts.New(awsSession).GetCallerIdentity(&sts.GetCallerIdentityInput{})
+	if err != nil || output.Arn == nil {
+		return "unknown"
+	}
+	return *output.Arn
+}
+
+// permissionsError aggregates the resource types that could not be
+// deleted for lack of permissions into a single actionable error.
+func permissionsError(identity string, deniedServices map[string]bool) error {
+	services := make([]string, 0, len(deniedServices))
+	for service := range deniedServices {
+		services = append(services, service)
+	}
+	sort.Strings(services)
+	return errors.Errorf("the credentials for %s lack permission to delete resources of the following services: %s; grant the delete permissions and re-run destroy", identity, strings.Join(services, ", "))
+}
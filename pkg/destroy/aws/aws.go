@@ -62,6 +62,11 @@ type ClusterUninstaller struct {
 	Region    string
 	ClusterID string
 
+	// UserProvidedNetwork makes the destroyer skip shared network
+	// infrastructure (subnets, route tables, NAT gateways, the VPC)
+	// that other clusters may still be using.
+	UserProvidedNetwork bool
+
 	// Session is the AWS session to be used for deletion.  If nil, a
 	// new session will be created based on the usual credential
 	// configuration (AWS_PROFILE, AWS_ACCESS_KEY_ID, etc.).
@@ -86,6 +91,8 @@ func New(logger logrus.FieldLogger, metadata *types.ClusterMetadata) (providers.
 		Logger:    logger,
 		ClusterID: metadata.InfraID,
 		Session:   session,
+
+		UserProvidedNetwork: metadata.ClusterPlatformMetadata.AWS.UserProvidedNetwork,
 	}, nil
 }
 
@@ -119,13 +126,26 @@ func (o *ClusterUninstaller) Run() error {
 		Fn:   request.MakeAddToUserAgentHandler("OpenShift/4.x Destroyer", version.Raw),
 	})
 
+	// Deletion can run as long as an install; fail up front instead of
+	// stopping half-way through with auth errors.
+	if err := awssession.CheckCredentialLifetime(awsSession); err != nil {
+		return err
+	}
+
+	identity := callerIdentity(awsSession)
+	o.Logger.Debugf("Destroying as %s", identity)
+	deniedServices := map[string]bool{}
+
 	tagClients := []*resourcegroupstaggingapi.ResourceGroupsTaggingAPI{
 		resourcegroupstaggingapi.New(awsSession),
 	}
 	tagClientNames := map[*resourcegroupstaggingapi.ResourceGroupsTaggingAPI]string{
 		tagClients[0]: o.Region,
 	}
-	if o.Region != "us-east-1" {
+	if o.Region != "us-east-1" && !strings.HasPrefix(o.Region, "cn-") {
+		// The second client catches us-east-1-homed resources
+		// (e.g. Route 53 and IAM tags); the aws-cn partition has no
+		// us-east-1 and its Route 53 works differently.
 		tagClient := resourcegroupstaggingapi.New(
 			awsSession, aws.NewConfig().WithRegion("us-east-1"),
 		)
@@ -152,9 +172,12 @@ func (o *ClusterUninstaller) Run() error {
 
 	tracker := new(errorTracker)
 	tagClientStack := append([]*resourcegroupstaggingapi.ResourceGroupsTaggingAPI(nil), tagClients...)
-	err = wait.PollImmediateInfinite(
-		time.Second*10,
-		func() (done bool, err error) {
+	err = providers.PollUntilDone(
+		o.Logger,
+		10*time.Second,
+		2*time.Minute,
+		func() (done bool, progressed bool, err error) {
+			deletedBefore := len(deleted)
 			var loopError error
 			nextTagClients := tagClients[:0]
 			for _, tagClient := range tagClientStack {
@@ -182,8 +205,22 @@ func (o *ClusterUninstaller) Run() error {
 										continue
 									}
 
+									if o.UserProvidedNetwork && isSharedNetworkResource(parsed) {
+										arnLogger.Debug("skipping shared network resource in a user-provided network")
+										deleted[arnString] = exists
+										continue
+									}
 									err = deleteARN(awsSession, parsed, filter, arnLogger)
 									if err != nil {
+										if isAuthError(err) {
+											// Retrying can never succeed; record
+											// the denied service and stop
+											// revisiting the resource.
+											arnLogger.Error(err)
+											deniedServices[parsed.Service] = true
+											deleted[arnString] = exists
+											continue
+										}
 										tracker.suppressWarning(arnString, err, arnLogger)
 										err = errors.Wrapf(err, "deleting %s", arnString)
 										continue
@@ -241,6 +278,12 @@ func (o *ClusterUninstaller) Run() error {
 
 					err = deleteARN(awsSession, parsed, nil, arnLogger)
 					if err != nil {
+						if isAuthError(err) {
+							arnLogger.Error(err)
+							deniedServices[parsed.Service] = true
+							deleted[arnString] = exists
+							continue
+						}
 						tracker.suppressWarning(arnString, err, arnLogger)
 						err = errors.Wrapf(err, "deleting %s", arnString)
 						loopError = err
@@ -250,7 +293,7 @@ func (o *ClusterUninstaller) Run() error {
 				}
 			}
 
-			return len(tagClientStack) == 0 && loopError == nil, nil
+			return len(tagClientStack) == 0 && loopError == nil, len(deleted) > deletedBefore, nil
 		},
 	)
 	if err != nil {
@@ -268,6 +311,10 @@ func (o *ClusterUninstaller) Run() error {
 		return err
 	}
 
+	if len(deniedServices) > 0 {
+		return permissionsError(identity, deniedServices)
+	}
+
 	return nil
 }
 
@@ -1941,3 +1988,29 @@ func removeSharedTag(ctx context.Context, tagClients []*resourcegroupstaggingapi
 
 	return nil
 }
+
+// sharedNetworkResourceTypes are the EC2 resource types that belong to
+// the network fabric rather than to a single cluster.
+var sharedNetworkResourceTypes = map[string]bool{
+	"dhcp-options":     true,
+	"internet-gateway": true,
+	"natgateway":       true,
+	"route-table":      true,
+	"subnet":           true,
+	"vpc":              true,
+	"vpc-endpoint":     true,
+}
+
+// isSharedNetworkResource returns whether the ARN names network
+// infrastructure that is shared when the cluster was installed into a
+// user-provided VPC.
+func isSharedNetworkResource(parsed arn.ARN) bool {
+	if parsed.Service != "ec2" {
+		return false
+	}
+	resourceType, _, err := splitSlash("resource", parsed.Resource)
+	if err != nil {
+		return false
+	}
+	return sharedNetworkResourceTypes[resourceType]
+}
@@ -0,0 +1,28 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsSharedNetworkResource(t *testing.T) {
+	cases := []struct {
+		resource string
+		shared   bool
+	}{
+		{"arn:aws:ec2:us-east-1:1234:subnet/subnet-1", true},
+		{"arn:aws:ec2:us-east-1:1234:route-table/rtb-1", true},
+		{"arn:aws:ec2:us-east-1:1234:natgateway/nat-1", true},
+		{"arn:aws:ec2:us-east-1:1234:vpc/vpc-1", true},
+		{"arn:aws:ec2:us-east-1:1234:instance/i-1", false},
+		{"arn:aws:ec2:us-east-1:1234:security-group/sg-1", false},
+		{"arn:aws:elasticloadbalancing:us-east-1:1234:loadbalancer/net/foo/bar", false},
+	}
+	for _, tc := range cases {
+		parsed, err := arn.Parse(tc.resource)
+		assert.NoError(t, err, tc.resource)
+		assert.Equal(t, tc.shared, isSharedNetworkResource(parsed), tc.resource)
+	}
+}
@@ -0,0 +1,100 @@
+package aws
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/version"
+)
+
+const clusterTagPrefix = "kubernetes.io/cluster/"
+
+// Identify lists the resources matching the uninstaller's tag filters
+// without deleting anything. Resources are grouped by the cluster
+// ownership tag found on them, so leaked clusters can be told apart
+// when a broad filter is used.
+func (o *ClusterUninstaller) Identify() error {
+	err := o.validate()
+	if err != nil {
+		return err
+	}
+
+	awsConfig := &aws.Config{Region: aws.String(o.Region)}
+	awsSession := o.Session
+	if awsSession == nil {
+		awsSession, err = session.NewSession(awsConfig)
+		if err != nil {
+			return err
+		}
+	} else {
+		awsSession = awsSession.Copy(awsConfig)
+	}
+	awsSession.Handlers.Build.PushBackNamed(request.NamedHandler{
+		Name: "openshiftInstaller.OpenshiftInstallerUserAgentHandler",
+		Fn:   request.MakeAddToUserAgentHandler("OpenShift/4.x Destroyer", version.Raw),
+	})
+
+	tagClients := []*resourcegroupstaggingapi.ResourceGroupsTaggingAPI{
+		resourcegroupstaggingapi.New(awsSession),
+	}
+	if o.Region != "us-east-1" && !strings.HasPrefix(o.Region, "cn-") {
+		tagClients = append(tagClients, resourcegroupstaggingapi.New(
+			awsSession, aws.NewConfig().WithRegion("us-east-1"),
+		))
+	}
+
+	byCluster := map[string][]string{}
+	for _, tagClient := range tagClients {
+		for _, filter := range o.Filters {
+			o.Logger.Debugf("search for matching resources by tag matching %#+v", filter)
+			tagFilters := make([]*resourcegroupstaggingapi.TagFilter, 0, len(filter))
+			for key, value := range filter {
+				tagFilters = append(tagFilters, &resourcegroupstaggingapi.TagFilter{
+					Key:    aws.String(key),
+					Values: []*string{aws.String(value)},
+				})
+			}
+			err = tagClient.GetResourcesPages(
+				&resourcegroupstaggingapi.GetResourcesInput{TagFilters: tagFilters},
+				func(results *resourcegroupstaggingapi.GetResourcesOutput, lastPage bool) bool {
+					for _, resource := range results.ResourceTagMappingList {
+						cluster := clusterFromTags(resource.Tags)
+						byCluster[cluster] = append(byCluster[cluster], *resource.ResourceARN)
+					}
+					return !lastPage
+				},
+			)
+			if err != nil {
+				return errors.Wrap(err, "get tagged resources")
+			}
+		}
+	}
+
+	for cluster, arns := range byCluster {
+		o.Logger.Infof("Cluster %s: %d resources", cluster, len(arns))
+		for _, arnString := range arns {
+			o.Logger.WithField("cluster", cluster).Info(arnString)
+		}
+	}
+	if len(byCluster) == 0 {
+		o.Logger.Info("No matching resources found")
+	}
+	return nil
+}
+
+// clusterFromTags extracts the infra ID from a resource's cluster
+// ownership tag, falling back to "unknown" when the resource carries
+// none.
+func clusterFromTags(tags []*resourcegroupstaggingapi.Tag) string {
+	for _, tag := range tags {
+		if tag.Key != nil && strings.HasPrefix(*tag.Key, clusterTagPrefix) {
+			return strings.TrimPrefix(*tag.Key, clusterTagPrefix)
+		}
+	}
+	return "unknown"
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"os"
 	"sort"
 	"strings"
 	"time"
@@ -16,6 +17,8 @@ import (
 	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+	"golang.org/x/crypto/ssh/terminal"
+	"gopkg.in/AlecAivazis/survey.v1"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
 
@@ -24,6 +27,10 @@ import (
 	"github.com/openshift/installer/pkg/types"
 )
 
+// SkipAppRegistrations leaves AAD application registrations in place;
+// set from the destroy cluster --skip-app-registrations flag.
+var SkipAppRegistrations bool
+
 // ClusterUninstaller holds the various options for the cluster we want to delete.
 type ClusterUninstaller struct {
 	SubscriptionID  string
@@ -33,6 +40,18 @@ type ClusterUninstaller struct {
 
 	InfraID string
 
+	// ResourceGroupName is set when the cluster lives in a user-owned
+	// resource group: destroy deletes the cluster's resources inside
+	// it individually and leaves the group in place.
+	ResourceGroupName string
+
+	// ClientID identifies the principal used, for permission errors.
+	ClientID string
+
+	// SkipAppRegistrations leaves AAD application registrations and
+	// service principals minted for the cluster in place.
+	SkipAppRegistrations bool
+
 	Logger logrus.FieldLogger
 
 	resourceGroupsClient    resources.GroupsClient
@@ -75,12 +94,16 @@ func New(logger logrus.FieldLogger, metadata *types.ClusterMetadata) (providers.
 	}
 
 	return &ClusterUninstaller{
-		SubscriptionID:  session.Credentials.SubscriptionID,
-		TenantID:        session.Credentials.TenantID,
-		GraphAuthorizer: session.GraphAuthorizer,
-		Authorizer:      session.Authorizer,
-		InfraID:         metadata.InfraID,
-		Logger:          logger,
+		SubscriptionID:    session.Credentials.SubscriptionID,
+		TenantID:          session.Credentials.TenantID,
+		GraphAuthorizer:   session.GraphAuthorizer,
+		Authorizer:        session.Authorizer,
+		InfraID:           metadata.InfraID,
+		ResourceGroupName: metadata.ClusterPlatformMetadata.Azure.ResourceGroupName,
+		ClientID:          session.Credentials.ClientID,
+		Logger:            logger,
+
+		SkipAppRegistrations: SkipAppRegistrations,
 	}, nil
 }
 
@@ -88,25 +111,176 @@ func New(logger logrus.FieldLogger, metadata *types.ClusterMetadata) (providers.
 func (o *ClusterUninstaller) Run() error {
 	o.configureClients()
 	group := o.InfraID + "-rg"
+	if o.ResourceGroupName != "" {
+		group = o.ResourceGroupName
+	}
 	o.Logger.Debug("deleting public records")
 	if err := deletePublicRecords(context.TODO(), o.zonesClient, o.recordsClient, o.privateZonesClient, o.privateRecordSetsClient, o.Logger, group); err != nil {
 		o.Logger.Debug(err)
 		return errors.Wrap(err, "failed to delete public DNS records")
 	}
+	if o.ResourceGroupName != "" {
+		// The group is user-owned: delete the cluster's resources
+		// inside it by ownership tag and leave the group alone.
+		if err := o.deleteTaggedGroupResources(context.TODO(), group); err != nil {
+			return err
+		}
+		o.Logger.Debug("deleting application registrations")
+		return o.finishAppRegistrations()
+	}
 	o.Logger.Debug("deleting resource group")
 	if err := deleteResourceGroup(context.TODO(), o.resourceGroupsClient, o.Logger, group); err != nil {
 		o.Logger.Debug(err)
+		if isAuthError(err) {
+			return errors.Wrapf(err, "the credentials (client %s) lack permission to delete resource group %s; grant the delete permissions and re-run destroy", o.ClientID, group)
+		}
 		return errors.Wrap(err, "failed to delete resource group")
 	}
+	return o.finishAppRegistrations()
+}
+
+// finishAppRegistrations removes the cluster's AAD application
+// registrations, honoring the skip flag, confirmation and graceful
+// graph-permission handling.
+func (o *ClusterUninstaller) finishAppRegistrations() error {
+	if o.SkipAppRegistrations {
+		o.Logger.Info("Skipping application registrations (--skip-app-registrations)")
+		return nil
+	}
+	if !o.confirmAppRegistrationDeletion() {
+		o.Logger.Info("Leaving application registrations in place")
+		return nil
+	}
 	o.Logger.Debug("deleting application registrations")
 	if err := deleteApplicationRegistrations(context.TODO(), o.applicationsClient, o.serviceprincipalsClient, o.Logger, o.InfraID); err != nil {
 		o.Logger.Debug(err)
+		if isAuthError(err) {
+			// The rest of the cluster is gone; leaving the app
+			// registrations behind is recoverable by hand, failing the
+			// destroy is not.
+			o.Logger.Warnf("Insufficient graph permissions to delete the cluster's application registrations; delete them manually: %v", err)
+			return nil
+		}
 		return errors.Wrap(err, "failed to delete application registrations and their service principals")
 	}
 
 	return nil
 }
 
+// maxPerResourceDeleteFailures is how many rounds one resource may
+// fail to delete before the destroy gives up and reports it, instead
+// of retrying forever behind debug logs.
+const maxPerResourceDeleteFailures = 10
+
+// deleteTaggedGroupResources deletes every resource in the group that
+// carries the cluster's ownership tag, retrying until the dependency
+// ordering lets everything go. Each resource is deleted with an
+// api-version its own resource provider supports - ARM rejects the
+// generic Microsoft.Resources version for compute and network types.
+func (o *ClusterUninstaller) deleteTaggedGroupResources(ctx context.Context, group string) error {
+	client := resources.NewClient(o.SubscriptionID)
+	client.Authorizer = o.Authorizer
+	providersClient := resources.NewProvidersClient(o.SubscriptionID)
+	providersClient.Authorizer = o.Authorizer
+	tagKey := fmt.Sprintf("kubernetes.io_cluster.%s", o.InfraID)
+
+	apiVersions := map[string]string{}
+	failures := map[string]int{}
+
+	return providers.PollUntilDone(o.Logger, 10*time.Second, 2*time.Minute, func() (bool, bool, error) {
+		remaining := 0
+		deleted := 0
+		for page, err := client.ListByResourceGroup(ctx, group, "", "", nil); page.NotDone(); err = page.NextWithContext(ctx) {
+			if err != nil {
+				return false, false, errors.Wrapf(err, "failed to list the resources of group %s", group)
+			}
+			for _, resource := range page.Values() {
+				if _, owned := resource.Tags[tagKey]; !owned {
+					continue
+				}
+				remaining++
+				resourceID := to.String(resource.ID)
+				apiVersion, err := apiVersionForType(ctx, providersClient, apiVersions, to.String(resource.Type))
+				if err != nil {
+					o.Logger.Debugf("failed to resolve the api-version for %s: %v", to.String(resource.Type), err)
+					apiVersion = "2018-05-01"
+				}
+				if _, err := client.DeleteByID(ctx, resourceID, apiVersion); err != nil {
+					failures[resourceID]++
+					if failures[resourceID] >= maxPerResourceDeleteFailures {
+						return false, false, errors.Wrapf(err, "giving up on %s after %d failed deletions", to.String(resource.Name), failures[resourceID])
+					}
+					o.Logger.Debugf("failed to delete %s: %v", to.String(resource.Name), err)
+					continue
+				}
+				o.Logger.Infof("deleted %s", to.String(resource.Name))
+				deleted++
+			}
+		}
+		return remaining == 0, deleted > 0, nil
+	})
+}
+
+// apiVersionForType resolves the api-version to delete a resource of
+// the given type ("Microsoft.Compute/virtualMachines") with, asking
+// the owning resource provider for its supported versions and caching
+// the answers. Preview-only types fall back to their newest preview
+// version.
+func apiVersionForType(ctx context.Context, client resources.ProvidersClient, cache map[string]string, resourceType string) (string, error) {
+	key := strings.ToLower(resourceType)
+	if version, ok := cache[key]; ok {
+		return version, nil
+	}
+
+	parts := strings.SplitN(resourceType, "/", 2)
+	if len(parts) != 2 {
+		return "", errors.Errorf("unexpected resource type %q", resourceType)
+	}
+	namespace, typeName := parts[0], parts[1]
+
+	provider, err := client.Get(ctx, namespace, "")
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to read the %s provider metadata", namespace)
+	}
+	if provider.ResourceTypes == nil {
+		return "", errors.Errorf("the %s provider reports no resource types", namespace)
+	}
+	for _, candidate := range *provider.ResourceTypes {
+		if !strings.EqualFold(to.String(candidate.ResourceType), typeName) || candidate.APIVersions == nil || len(*candidate.APIVersions) == 0 {
+			continue
+		}
+		// The versions come newest-first; prefer the newest stable one.
+		version := (*candidate.APIVersions)[0]
+		for _, v := range *candidate.APIVersions {
+			if !strings.Contains(v, "-preview") {
+				version = v
+				break
+			}
+		}
+		cache[key] = version
+		return version, nil
+	}
+	return "", errors.Errorf("the %s provider does not expose type %s", namespace, typeName)
+}
+
+// confirmAppRegistrationDeletion asks the operator before touching AAD
+// application registrations, which can be shared across clusters when
+// credentials were provisioned by hand. Non-interactive runs proceed.
+func (o *ClusterUninstaller) confirmAppRegistrationDeletion() bool {
+	if !terminal.IsTerminal(int(os.Stdin.Fd())) {
+		return true
+	}
+	delete := true
+	err := survey.AskOne(&survey.Confirm{
+		Message: fmt.Sprintf("Delete the AAD application registrations minted for cluster %s?", o.InfraID),
+		Default: true,
+	}, &delete, nil)
+	if err != nil {
+		return true
+	}
+	return delete
+}
+
 func deletePublicRecords(ctx context.Context, dnsClient dns.ZonesClient, recordsClient dns.RecordSetsClient, privateDNSClient privatedns.PrivateZonesClient, privateRecordsClient privatedns.RecordSetsClient, logger logrus.FieldLogger, rgName string) error {
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Minute)
 	defer cancel()
@@ -306,7 +480,7 @@ func deleteApplicationRegistrations(ctx context.Context, appClient graphrbac.App
 
 		_, err = appClient.Delete(ctx, *apps[0].ObjectID)
 		if err != nil {
-			errorList = append(errorList, err)
+			errorList = append(errorList, errors.Wrapf(err, "appID %s", *sp.AppID))
 			continue
 		}
 		logger.Info("deleted")
@@ -337,3 +511,18 @@ func getServicePrincipalsByTag(ctx context.Context, spClient graphrbac.ServicePr
 
 	return matchedSPs, nil
 }
+
+// isAuthError returns whether err reports missing permissions.
+func isAuthError(err error) bool {
+	for err != nil {
+		if detailed, ok := err.(autorest.DetailedError); ok {
+			return detailed.StatusCode == http.StatusForbidden || detailed.StatusCode == http.StatusUnauthorized
+		}
+		cause := errors.Cause(err)
+		if cause == err {
+			return false
+		}
+		err = cause
+	}
+	return false
+}
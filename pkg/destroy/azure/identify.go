@@ -0,0 +1,44 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/pkg/errors"
+)
+
+// Identify lists the resource groups the uninstaller would delete
+// without deleting anything. Groups are matched by the cluster's
+// ownership tag as well as by the infra-ID naming convention, so
+// leaked clusters can be found when metadata.json has been lost.
+func (o *ClusterUninstaller) Identify() error {
+	o.configureClients()
+
+	ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Minute)
+	defer cancel()
+
+	tagKey := fmt.Sprintf("kubernetes.io_cluster.%s", o.InfraID)
+	found := 0
+	for groupsPage, err := o.resourceGroupsClient.List(ctx, "", nil); groupsPage.NotDone(); err = groupsPage.NextWithContext(ctx) {
+		if err != nil {
+			return errors.Wrap(err, "failed to list resource groups")
+		}
+		for _, group := range groupsPage.Values() {
+			name := to.String(group.Name)
+			if _, tagged := group.Tags[tagKey]; !tagged && !strings.HasPrefix(name, o.InfraID+"-") {
+				continue
+			}
+			found++
+			o.Logger.WithField("cluster", o.InfraID).Infof("resource group %s", name)
+		}
+	}
+	if found == 0 {
+		o.Logger.Info("No matching resource groups found")
+		return nil
+	}
+	o.Logger.Infof("Cluster %s: %d resource groups", o.InfraID, found)
+	return nil
+}
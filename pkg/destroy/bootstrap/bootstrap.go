@@ -15,6 +15,7 @@ import (
 	"github.com/openshift/installer/pkg/types/libvirt"
 	"github.com/openshift/installer/pkg/types/openstack"
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 )
 
 // Destroy uses Terraform to remove bootstrap resources.
@@ -31,6 +32,23 @@ func Destroy(dir string) (err error) {
 
 	tfPlatformVarsFileName := fmt.Sprintf(cluster.TfPlatformVarsFileName, platform)
 
+	// If the primary state was corrupted by an interrupted apply, fall
+	// back to the newest consistent snapshot before driving terraform.
+	primaryState := filepath.Join(dir, terraform.StateFileName)
+	if _, err := terraform.ReadState(primaryState); err != nil {
+		backup, backupErr := terraform.NewestConsistentBackup(dir)
+		if backupErr != nil {
+			return errors.Wrapf(err, "terraform state is unreadable and no consistent backup exists (%v)", backupErr)
+		}
+		logrus.Warnf("Terraform state is unreadable (%v); restoring backup %s", err, backup)
+		if err := terraform.RestoreBackup(dir, backup); err != nil {
+			return err
+		}
+	}
+	if data, err := ioutil.ReadFile(primaryState); err == nil {
+		terraform.BackupState(dir, "bootstrap-destroy-pre", data)
+	}
+
 	tempDir, err := ioutil.TempDir("", "openshift-install-")
 	if err != nil {
 		return errors.Wrap(err, "failed to create temporary directory for Terraform execution")
@@ -6,6 +6,7 @@ import (
 
 	"github.com/openshift/installer/pkg/asset/cluster"
 	"github.com/openshift/installer/pkg/destroy/providers"
+	"github.com/openshift/installer/pkg/types"
 )
 
 // New returns a Destroyer based on `metadata.json` in `rootDir`.
@@ -15,6 +16,13 @@ func New(logger logrus.FieldLogger, rootDir string) (providers.Destroyer, error)
 		return nil, err
 	}
 
+	return NewFromMetadata(logger, metadata)
+}
+
+// NewFromMetadata returns a Destroyer for already-loaded cluster
+// metadata, e.g. metadata reconstructed from command-line flags when
+// metadata.json has been lost.
+func NewFromMetadata(logger logrus.FieldLogger, metadata *types.ClusterMetadata) (providers.Destroyer, error) {
 	platform := metadata.Platform()
 	if platform == "" {
 		return nil, errors.New("no platform configured in metadata")
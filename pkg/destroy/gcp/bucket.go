@@ -1,6 +1,7 @@
 package gcp
 
 import (
+	"fmt"
 	"regexp"
 
 	"github.com/pkg/errors"
@@ -15,7 +16,33 @@ var (
 )
 
 func (o *ClusterUninstaller) listBuckets() ([]cloudResource, error) {
-	return o.listBucketsWithFilter("items(name),nextPageToken", o.ClusterID+"-", nil)
+	byName, err := o.listBucketsWithFilter("items(name),nextPageToken", o.ClusterID+"-", nil)
+	if err != nil {
+		return nil, err
+	}
+	// The bucket list API has no server-side label filter, so match the
+	// cluster label client-side to also catch buckets whose names do not
+	// carry the infra ID prefix.
+	byLabel, err := o.listBucketsWithFilter("items(name,labels),nextPageToken", "", o.isClusterLabeledBucket)
+	if err != nil {
+		return nil, err
+	}
+	found := map[string]bool{}
+	for _, item := range byName {
+		found[item.name] = true
+	}
+	for _, item := range byLabel {
+		if !found[item.name] {
+			byName = append(byName, item)
+		}
+	}
+	return byName, nil
+}
+
+// isClusterLabeledBucket returns whether the bucket carries the
+// cluster's ownership label.
+func (o *ClusterUninstaller) isClusterLabeledBucket(item *storage.Bucket) bool {
+	return item.Labels[fmt.Sprintf("kubernetes-io-cluster-%s", o.ClusterID)] == "owned"
 }
 
 // listBucketsWithFilter lists buckets in the project that satisfy the filter criteria.
@@ -0,0 +1,39 @@
+package gcp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	storage "google.golang.org/api/storage/v1"
+)
+
+func TestIsClusterLabeledBucket(t *testing.T) {
+	o := &ClusterUninstaller{ClusterID: "test-1234"}
+	cases := []struct {
+		name     string
+		labels   map[string]string
+		expected bool
+	}{
+		{
+			name:     "owned",
+			labels:   map[string]string{"kubernetes-io-cluster-test-1234": "owned"},
+			expected: true,
+		},
+		{
+			name:   "other cluster",
+			labels: map[string]string{"kubernetes-io-cluster-other-5678": "owned"},
+		},
+		{
+			name:   "shared",
+			labels: map[string]string{"kubernetes-io-cluster-test-1234": "shared"},
+		},
+		{
+			name: "no labels",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, o.isClusterLabeledBucket(&storage.Bucket{Labels: tc.labels}))
+		})
+	}
+}
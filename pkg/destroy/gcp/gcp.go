@@ -11,7 +11,6 @@ import (
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
-	"k8s.io/apimachinery/pkg/util/wait"
 
 	resourcemanager "google.golang.org/api/cloudresourcemanager/v1"
 	compute "google.golang.org/api/compute/v1"
@@ -38,7 +37,12 @@ type ClusterUninstaller struct {
 	Region    string
 	ProjectID string
 	ClusterID string
-	Context   context.Context
+
+	// UserProvidedNetwork makes the destroyer skip shared network
+	// infrastructure (networks, subnetworks, routers, routes) that
+	// other clusters may still be using.
+	UserProvidedNetwork bool
+	Context             context.Context
 
 	computeSvc *compute.Service
 	iamSvc     *iam.Service
@@ -58,14 +62,15 @@ type ClusterUninstaller struct {
 // New returns an AWS destroyer from ClusterMetadata.
 func New(logger logrus.FieldLogger, metadata *types.ClusterMetadata) (providers.Destroyer, error) {
 	return &ClusterUninstaller{
-		Logger:             logger,
-		Region:             metadata.ClusterPlatformMetadata.GCP.Region,
-		ProjectID:          metadata.ClusterPlatformMetadata.GCP.ProjectID,
-		ClusterID:          metadata.InfraID,
-		Context:            context.Background(),
-		cloudControllerUID: gcptypes.CloudControllerUID(metadata.InfraID),
-		requestIDTracker:   newRequestIDTracker(),
-		pendingItemTracker: newPendingItemTracker(),
+		Logger:              logger,
+		Region:              metadata.ClusterPlatformMetadata.GCP.Region,
+		ProjectID:           metadata.ClusterPlatformMetadata.GCP.ProjectID,
+		ClusterID:           metadata.InfraID,
+		Context:             context.Background(),
+		UserProvidedNetwork: metadata.ClusterPlatformMetadata.GCP.UserProvidedNetwork,
+		cloudControllerUID:  gcptypes.CloudControllerUID(metadata.InfraID),
+		requestIDTracker:    newRequestIDTracker(),
+		pendingItemTracker:  newPendingItemTracker(),
 	}, nil
 }
 
@@ -109,15 +114,16 @@ func (o *ClusterUninstaller) Run() error {
 		return errors.Wrap(err, "failed to create resourcemanager service")
 	}
 
-	err = wait.PollImmediateInfinite(
-		time.Second*10,
+	err = providers.PollUntilDone(
+		o.Logger,
+		10*time.Second,
+		2*time.Minute,
 		o.destroyCluster,
 	)
-	return nil
-
+	return err
 }
 
-func (o *ClusterUninstaller) destroyCluster() (bool, error) {
+func (o *ClusterUninstaller) destroyCluster() (bool, bool, error) {
 	stagedFuncs := [][]struct {
 		name    string
 		execute func() error
@@ -133,7 +139,7 @@ func (o *ClusterUninstaller) destroyCluster() (bool, error) {
 		{name: "Images", execute: o.destroyImages},
 		{name: "DNS", execute: o.destroyDNS},
 		{name: "Buckets", execute: o.destroyBuckets},
-		{name: "Routes", execute: o.destroyRoutes},
+		{name: "Routes", execute: o.skipForUserProvidedNetwork("Routes", o.destroyRoutes)},
 		{name: "Firewalls", execute: o.destroyFirewalls},
 		{name: "Addresses", execute: o.destroyAddresses},
 		{name: "Target Pools", execute: o.destroyTargetPools},
@@ -142,23 +148,29 @@ func (o *ClusterUninstaller) destroyCluster() (bool, error) {
 		{name: "Backend services", execute: o.destroyBackendServices},
 		{name: "Health checks", execute: o.destroyHealthChecks},
 		{name: "HTTP Health checks", execute: o.destroyHTTPHealthChecks},
-		{name: "Routers", execute: o.destroyRouters},
-		{name: "Subnetworks", execute: o.destroySubnetworks},
-		{name: "Networks", execute: o.destroyNetworks},
+		{name: "Routers", execute: o.skipForUserProvidedNetwork("Routers", o.destroyRouters)},
+		{name: "Subnetworks", execute: o.skipForUserProvidedNetwork("Subnetworks", o.destroySubnetworks)},
+		{name: "Networks", execute: o.skipForUserProvidedNetwork("Networks", o.destroyNetworks)},
 	}}
+	pendingBefore := o.pendingItemTracker.pendingItemCount()
 	done := true
 	for _, stage := range stagedFuncs {
 		if done {
 			for _, f := range stage {
 				err := f.execute()
 				if err != nil {
+					if isForbidden(err) {
+						// Retrying a permission error can never succeed;
+						// surface it instead of spinning forever.
+						return false, false, errors.Wrapf(err, "%s: the credentials lack delete permissions in project %s; grant the delete permissions and re-run destroy", f.name, o.ProjectID)
+					}
 					o.Logger.Debugf("%s: %v", f.name, err)
 					done = false
 				}
 			}
 		}
 	}
-	return done, nil
+	return done, o.pendingItemTracker.pendingItemCount() != pendingBefore, nil
 }
 
 // getZoneName extracts a zone name from a zone URL of the form:
@@ -200,6 +212,21 @@ func (o *ClusterUninstaller) clusterLabelFilter() string {
 	return fmt.Sprintf("labels.kubernetes-io-cluster-%s eq \"owned\"", o.ClusterID)
 }
 
+// isForbidden returns whether err reports missing permissions.
+func isForbidden(err error) bool {
+	for err != nil {
+		if ae, ok := err.(*googleapi.Error); ok {
+			return ae.Code == http.StatusForbidden
+		}
+		cause := errors.Cause(err)
+		if cause == err {
+			return false
+		}
+		err = cause
+	}
+	return false
+}
+
 func isNoOp(err error) bool {
 	if err == nil {
 		return false
@@ -278,6 +305,16 @@ func newPendingItemTracker() pendingItemTracker {
 	}
 }
 
+// pendingItemCount returns the total number of resources still
+// pending deletion, across all types.
+func (t pendingItemTracker) pendingItemCount() int {
+	count := 0
+	for _, items := range t.pendingItems {
+		count += len(items)
+	}
+	return count
+}
+
 // getPendingItems returns the list of resources to be deleted.
 func (t pendingItemTracker) getPendingItems(itemType string) []cloudResource {
 	lastFound, exists := t.pendingItems[itemType]
@@ -325,3 +362,16 @@ func operationErrorMessage(op *compute.Operation) string {
 	}
 	return strings.Join(errs, ", ")
 }
+
+// skipForUserProvidedNetwork wraps a destroy stage so it becomes a
+// no-op when the cluster was installed into a user-provided network
+// whose fabric other clusters may still be using.
+func (o *ClusterUninstaller) skipForUserProvidedNetwork(name string, destroy func() error) func() error {
+	return func() error {
+		if o.UserProvidedNetwork {
+			o.Logger.Debugf("%s: skipping shared network resources in a user-provided network", name)
+			return nil
+		}
+		return destroy()
+	}
+}
@@ -0,0 +1,96 @@
+package gcp
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	resourcemanager "google.golang.org/api/cloudresourcemanager/v1"
+	compute "google.golang.org/api/compute/v1"
+	dns "google.golang.org/api/dns/v1"
+	iam "google.golang.org/api/iam/v1"
+	"google.golang.org/api/option"
+	storage "google.golang.org/api/storage/v1"
+
+	gcpconfig "github.com/openshift/installer/pkg/asset/installconfig/gcp"
+	"github.com/openshift/installer/pkg/version"
+)
+
+// Identify lists the resources the uninstaller would delete without
+// deleting anything.
+func (o *ClusterUninstaller) Identify() error {
+	ctx, cancel := o.contextWithTimeout()
+	defer cancel()
+
+	ssn, err := gcpconfig.GetSession(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get session")
+	}
+
+	options := []option.ClientOption{
+		option.WithCredentials(ssn.Credentials),
+		option.WithUserAgent(fmt.Sprintf("OpenShift/4.x Destroyer/%s", version.Raw)),
+	}
+
+	o.computeSvc, err = compute.NewService(ctx, options...)
+	if err != nil {
+		return errors.Wrap(err, "failed to create compute service")
+	}
+
+	o.iamSvc, err = iam.NewService(ctx, options...)
+	if err != nil {
+		return errors.Wrap(err, "failed to create iam service")
+	}
+
+	o.dnsSvc, err = dns.NewService(ctx, options...)
+	if err != nil {
+		return errors.Wrap(err, "failed to create dns service")
+	}
+
+	o.storageSvc, err = storage.NewService(ctx, options...)
+	if err != nil {
+		return errors.Wrap(err, "failed to create storage service")
+	}
+
+	o.rmSvc, err = resourcemanager.NewService(ctx, options...)
+	if err != nil {
+		return errors.Wrap(err, "failed to create resourcemanager service")
+	}
+
+	inventory := []struct {
+		name string
+		list func() ([]cloudResource, error)
+	}{
+		{"instance", o.listInstances},
+		{"disk", o.listDisks},
+		{"service account", o.listServiceAccounts},
+		{"image", o.listImages},
+		{"bucket", o.listBuckets},
+		{"route", o.listRoutes},
+		{"firewall", o.listFirewalls},
+		{"address", o.listAddresses},
+		{"target pool", o.listTargetPools},
+		{"instance group", o.listInstanceGroups},
+		{"forwarding rule", o.listForwardingRules},
+		{"backend service", o.listBackendServices},
+		{"health check", o.listHealthChecks},
+		{"HTTP health check", o.listHTTPHealthChecks},
+		{"router", o.listRouters},
+		{"subnetwork", o.listSubnetworks},
+		{"network", o.listNetworks},
+	}
+
+	total := 0
+	for _, resource := range inventory {
+		items, err := resource.list()
+		if err != nil {
+			return errors.Wrapf(err, "failed to list matching %ss", resource.name)
+		}
+		for _, item := range items {
+			o.Logger.WithField("cluster", o.ClusterID).Infof("%s %s", resource.name, item.name)
+			total++
+		}
+	}
+	o.Logger.Infof("Cluster %s: %d resources", o.ClusterID, total)
+	return nil
+}
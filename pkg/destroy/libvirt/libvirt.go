@@ -43,22 +43,37 @@ type deleteFunc func(conn *libvirt.Connect, filter filterFunc, logger logrus.Fie
 // ClusterUninstaller holds the various options for the cluster we want to delete.
 type ClusterUninstaller struct {
 	LibvirtURI string
-	Filter     filterFunc
-	Logger     logrus.FieldLogger
+	// AdditionalURIs are the other hypervisors the cluster's machines
+	// were spread across.
+	AdditionalURIs []string
+	Filter         filterFunc
+	Logger         logrus.FieldLogger
 }
 
 // New returns libvirt Uninstaller from ClusterMetadata.
 func New(logger logrus.FieldLogger, metadata *types.ClusterMetadata) (providers.Destroyer, error) {
 	return &ClusterUninstaller{
-		LibvirtURI: metadata.ClusterPlatformMetadata.Libvirt.URI,
-		Filter:     ClusterIDPrefixFilter(metadata.InfraID),
-		Logger:     logger,
+		LibvirtURI:     metadata.ClusterPlatformMetadata.Libvirt.URI,
+		AdditionalURIs: metadata.ClusterPlatformMetadata.Libvirt.AdditionalURIs,
+		Filter:         ClusterIDPrefixFilter(metadata.InfraID),
+		Logger:         logger,
 	}, nil
 }
 
 // Run is the entrypoint to start the uninstall process.
 func (o *ClusterUninstaller) Run() error {
-	conn, err := libvirt.NewConnect(o.LibvirtURI)
+	for _, uri := range o.AdditionalURIs {
+		if err := o.destroyAt(uri); err != nil {
+			return err
+		}
+	}
+	return o.destroyAt(o.LibvirtURI)
+}
+
+// destroyAt deletes the cluster's resources reachable through one
+// libvirtd connection.
+func (o *ClusterUninstaller) destroyAt(uri string) error {
+	conn, err := libvirt.NewConnect(uri)
 	if err != nil {
 		return errors.Wrap(err, "failed to connect to Libvirt daemon")
 	}
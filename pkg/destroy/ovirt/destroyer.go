@@ -2,6 +2,7 @@ package ovirt
 
 import (
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -13,13 +14,30 @@ import (
 	"github.com/openshift/installer/pkg/types"
 )
 
+// NamePrefixFallback additionally finds VMs by the <infra-id>- name
+// prefix. Clusters created by older installers did not tag every VM,
+// so the tag search alone can miss machines there; set from the
+// --ovirt-name-prefix-fallback flag.
+var NamePrefixFallback bool
+
+// imageTransferRetries is how often a removal colliding with an image
+// transfer lock is retried before giving up.
+const imageTransferRetries = 6
+
+// imageTransferRetryDelay is the pause between those retries.
+const imageTransferRetryDelay = 10 * time.Second
+
 // ClusterUninstaller holds the various options for the cluster we want to delete.
 type ClusterUninstaller struct {
 	Metadata types.ClusterMetadata
 	Logger   logrus.FieldLogger
 }
 
-// Run is the entrypoint to start the uninstall process.
+// Run is the entrypoint to start the uninstall process. Everything
+// the installer created is found through the <infra-id> tag it
+// attached on create; disks are cleaned up through the tagged VMs and
+// the uploaded template and affinity groups, which oVirt cannot tag,
+// by the names recorded in the metadata.
 func (uninstaller *ClusterUninstaller) Run() error {
 	config, err := ovirt.NewConfig()
 	if err != nil {
@@ -27,117 +45,281 @@ func (uninstaller *ClusterUninstaller) Run() error {
 	}
 
 	con, err := ovirt.GetConnection(config)
-
 	if err != nil {
 		return fmt.Errorf("failed to initialize connection to ovirt-engine's %s", err)
 	}
 	defer con.Close()
 
-	err = uninstaller.removeVms(con)
-	uninstaller.Logger.Errorf("Removing VMs - error: %s", err)
-	err = uninstaller.removeTag(con)
-	uninstaller.Logger.Errorf("Removing Tag - error: %s", err)
-	err = uninstaller.removeTemplate(con)
-	uninstaller.Logger.Errorf("Removing Template - error: %s", err)
+	if err := uninstaller.removeVMs(con); err != nil {
+		uninstaller.Logger.Errorf("Failed removing VMs: %s", err)
+	}
+	if err := uninstaller.removeAffinityGroups(con); err != nil {
+		uninstaller.Logger.Errorf("Failed removing affinity groups: %s", err)
+	}
+	if err := uninstaller.removeTemplate(con); err != nil {
+		uninstaller.Logger.Errorf("Failed removing the template: %s", err)
+	}
+	if err := uninstaller.removeTag(con); err != nil {
+		uninstaller.Logger.Errorf("Failed removing the tag: %s", err)
+	}
 	return nil
 }
 
-func (uninstaller *ClusterUninstaller) removeVms(con *ovirtsdk.Connection) error {
-	// - find all vms by tag name=infraID
+// findVMs enumerates the cluster's VMs by the <infra-id> tag, plus by
+// name prefix when the fallback is enabled.
+func (uninstaller *ClusterUninstaller) findVMs(vmsService *ovirtsdk.VmsService) ([]*ovirtsdk.Vm, error) {
+	searchTerms := []string{
+		fmt.Sprintf("tag=%s", uninstaller.Metadata.InfraID),
+		// The bootstrap VM carries its own tag so bootstrap teardown
+		// can drop it without touching the cluster-wide tag.
+		fmt.Sprintf("tag=%s-bootstrap", uninstaller.Metadata.InfraID),
+	}
+	if NamePrefixFallback {
+		searchTerms = append(searchTerms, fmt.Sprintf("name=%s-*", uninstaller.Metadata.InfraID))
+	}
+
+	found := map[string]*ovirtsdk.Vm{}
+	for _, searchTerm := range searchTerms {
+		uninstaller.Logger.Infof("searching VMs by %s", searchTerm)
+		vmsResponse, err := vmsService.List().Search(searchTerm).Send()
+		if err != nil {
+			return nil, err
+		}
+		for _, vm := range vmsResponse.MustVms().Slice() {
+			found[vm.MustId()] = vm
+		}
+	}
+
+	vms := make([]*ovirtsdk.Vm, 0, len(found))
+	for _, vm := range found {
+		vms = append(vms, vm)
+	}
+	return vms, nil
+}
+
+func (uninstaller *ClusterUninstaller) removeVMs(con *ovirtsdk.Connection) error {
 	vmsService := con.SystemService().VmsService()
-	searchTerm := fmt.Sprintf("tag=%s", uninstaller.Metadata.InfraID)
-	uninstaller.Logger.Infof("searching VMs by %s", searchTerm)
-	vmsResponse, err := vmsService.List().Search(searchTerm).Send()
+	vms, err := uninstaller.findVMs(vmsService)
 	if err != nil {
 		return err
 	}
-	// - stop + delete VMS
-	vms := vmsResponse.MustVms().Slice()
-	uninstaller.Logger.Infof("Found %s VMs", len(vms))
+	uninstaller.Logger.Infof("Found %d VMs", len(vms))
+
 	wg := sync.WaitGroup{}
 	wg.Add(len(vms))
 	for _, vm := range vms {
 		go func(vm *ovirtsdk.Vm) {
+			defer wg.Done()
 			uninstaller.stopVM(vmsService, vm)
+			diskIDs := uninstaller.vmDiskIDs(vmsService, vm)
 			uninstaller.removeVM(vmsService, vm)
-			wg.Done()
+			uninstaller.removeDisks(con, diskIDs)
 		}(vm)
 	}
 	wg.Wait()
 	return nil
 }
 
-func (uninstaller *ClusterUninstaller) removeTag(con *ovirtsdk.Connection) error {
-	// finally remove the tag
-	tagsService := con.SystemService().TagsService()
-	tagsServiceListResponse, err := tagsService.List().Send()
-	if err != nil {
-		return err
-	}
-	if tagsServiceListResponse != nil {
-		for _, t := range tagsServiceListResponse.MustTags().Slice() {
-			if t.MustName() == uninstaller.Metadata.InfraID {
-				_, err := tagsService.TagService(t.MustId()).Remove().Send()
-				uninstaller.Logger.Infof("Removing tag %s : %s", t.MustName(), "errors: %s", err)
-				if err != nil {
-					uninstaller.Logger.Debugf("Failed removing tag %s : %s", t.MustName(), err)
-					return err
-				}
-			}
-		}
-	}
-	return nil
-}
-
 func (uninstaller *ClusterUninstaller) stopVM(vmsService *ovirtsdk.VmsService, vm *ovirtsdk.Vm) {
 	vmService := vmsService.VmService(vm.MustId())
 	// this is a teardown, stopping instead of shutting down.
 	_, err := vmService.Stop().Send()
-	uninstaller.Logger.Infof("Stopping VM %s : %s", vm.MustName(), "errors: %s", err)
 	if err != nil {
-		uninstaller.Logger.Debugf("Failed stopping VM %s : %s", vm.MustName(), err)
+		uninstaller.Logger.Debugf("Failed stopping VM %s: %s", vm.MustName(), err)
+		return
 	}
+	uninstaller.Logger.Infof("Stopping VM %s", vm.MustName())
 	waitForDownDuration := time.Minute * 10
 	err = vmService.Connection().WaitForVM(vm.MustId(), ovirtsdk.VMSTATUS_DOWN, waitForDownDuration)
 	if err != nil {
-		uninstaller.Logger.Warnf("Waiting %d for VM %s to power-off", waitForDownDuration, vm.MustName())
+		uninstaller.Logger.Warnf("Waiting %s for VM %s to power-off", waitForDownDuration, vm.MustName())
 	}
 }
 
+// vmDiskIDs collects the IDs of the VM's attached disks before the VM
+// goes away, so leftovers can be removed afterwards.
+func (uninstaller *ClusterUninstaller) vmDiskIDs(vmsService *ovirtsdk.VmsService, vm *ovirtsdk.Vm) []string {
+	attachmentsResponse, err := vmsService.VmService(vm.MustId()).DiskAttachmentsService().List().Send()
+	if err != nil {
+		uninstaller.Logger.Debugf("Failed listing disk attachments of VM %s: %s", vm.MustName(), err)
+		return nil
+	}
+	var diskIDs []string
+	for _, attachment := range attachmentsResponse.MustAttachments().Slice() {
+		if disk, ok := attachment.Disk(); ok {
+			if id, ok := disk.Id(); ok {
+				diskIDs = append(diskIDs, id)
+			}
+		}
+	}
+	return diskIDs
+}
+
 func (uninstaller *ClusterUninstaller) removeVM(vmsService *ovirtsdk.VmsService, vm *ovirtsdk.Vm) {
 	vmService := vmsService.VmService(vm.MustId())
-	_, err := vmService.Remove().Send()
-	uninstaller.Logger.Infof("Removing VM %s : %s", vm.MustName(), "errors: %s", err)
+	err := uninstaller.retryImageLocks(fmt.Sprintf("VM %s", vm.MustName()), func() error {
+		_, err := vmService.Remove().Send()
+		return err
+	})
 	if err != nil {
-		uninstaller.Logger.Debugf("Failed removing VM %s : %s", vm.MustName(), err)
+		uninstaller.Logger.Debugf("Failed removing VM %s: %s", vm.MustName(), err)
+		return
 	}
+	uninstaller.Logger.Infof("Removed VM %s", vm.MustName())
 }
 
-func (uninstaller *ClusterUninstaller) removeTemplate(con *ovirtsdk.Connection) error {
-	if uninstaller.Metadata.Ovirt.RemoveTemplate {
-		search, err := con.SystemService().TemplatesService().
-			List().Search(fmt.Sprintf("name=%s", uninstaller.Metadata.InfraID)).Send()
+// removeDisks removes whatever disks the VM removal left behind; a
+// disk that is already gone is success.
+func (uninstaller *ClusterUninstaller) removeDisks(con *ovirtsdk.Connection, diskIDs []string) {
+	disksService := con.SystemService().DisksService()
+	for _, diskID := range diskIDs {
+		diskService := disksService.DiskService(diskID)
+		if _, err := diskService.Get().Send(); err != nil {
+			// Removed together with its VM.
+			continue
+		}
+		err := uninstaller.retryImageLocks(fmt.Sprintf("disk %s", diskID), func() error {
+			_, err := diskService.Remove().Send()
+			return err
+		})
 		if err != nil {
-			uninstaller.Logger.Errorf("Couldn't find a template with name %s", uninstaller.Metadata.InfraID)
-			return nil
+			uninstaller.Logger.Debugf("Failed removing disk %s: %s", diskID, err)
+			continue
 		}
-		if result, ok := search.Templates(); ok {
-			// the results can potentially return a list of template
-			// because the search uses wildcards
-			for _, tmp := range result.Slice() {
-				uninstaller.Logger.Infof("Removing Template %s", tmp.MustName())
-				service := con.SystemService().TemplatesService().TemplateService(tmp.MustId())
+		uninstaller.Logger.Infof("Removed disk %s", diskID)
+	}
+}
+
+// removeAffinityGroups removes the affinity groups the installer
+// created; oVirt cannot tag them, so the names come from the
+// metadata.
+func (uninstaller *ClusterUninstaller) removeAffinityGroups(con *ovirtsdk.Connection) error {
+	if uninstaller.Metadata.Ovirt == nil || len(uninstaller.Metadata.Ovirt.AffinityGroupNames) == 0 {
+		return nil
+	}
+	wanted := map[string]bool{}
+	for _, name := range uninstaller.Metadata.Ovirt.AffinityGroupNames {
+		wanted[name] = true
+	}
+
+	groupsService := con.SystemService().ClustersService().
+		ClusterService(uninstaller.Metadata.Ovirt.ClusterID).AffinityGroupsService()
+	groupsResponse, err := groupsService.List().Send()
+	if err != nil {
+		return err
+	}
+	for _, group := range groupsResponse.MustGroups().Slice() {
+		if !wanted[group.MustName()] {
+			continue
+		}
+		if _, err := groupsService.GroupService(group.MustId()).Remove().Send(); err != nil {
+			uninstaller.Logger.Debugf("Failed removing affinity group %s: %s", group.MustName(), err)
+			continue
+		}
+		uninstaller.Logger.Infof("Removed affinity group %s", group.MustName())
+	}
+	return nil
+}
+
+func (uninstaller *ClusterUninstaller) removeTemplate(con *ovirtsdk.Connection) error {
+	if !uninstaller.Metadata.Ovirt.RemoveTemplate {
+		return nil
+	}
+	// Older metadata did not record the template name; those
+	// installers named the template after the infra ID.
+	templateName := uninstaller.Metadata.InfraID
+	if uninstaller.Metadata.Ovirt.BaseImageName != "" {
+		templateName = uninstaller.Metadata.Ovirt.BaseImageName
+	}
+
+	search, err := con.SystemService().TemplatesService().
+		List().Search(fmt.Sprintf("name=%s", templateName)).Send()
+	if err != nil {
+		uninstaller.Logger.Errorf("Couldn't find a template with name %s", templateName)
+		return nil
+	}
+	if result, ok := search.Templates(); ok {
+		// the results can potentially return a list of template
+		// because the search uses wildcards
+		for _, tmp := range result.Slice() {
+			service := con.SystemService().TemplatesService().TemplateService(tmp.MustId())
+			err := uninstaller.retryImageLocks(fmt.Sprintf("template %s", tmp.MustName()), func() error {
 				_, err := service.Remove().Send()
-				if err != nil {
-					uninstaller.Logger.Errorf("Failed to remove Template %s %s", tmp.MustName(), tmp.MustId())
+				return err
+			})
+			if err != nil {
+				uninstaller.Logger.Errorf("Failed to remove Template %s %s", tmp.MustName(), tmp.MustId())
+				return err
+			}
+			uninstaller.Logger.Infof("Removed Template %s", tmp.MustName())
+		}
+	}
+
+	return uninstaller.removeUploadedImage(con, templateName)
+}
+
+// removeUploadedImage removes the disk image the installer uploaded
+// for the template; it carries the template's name as its alias.
+func (uninstaller *ClusterUninstaller) removeUploadedImage(con *ovirtsdk.Connection, alias string) error {
+	disksService := con.SystemService().DisksService()
+	disksResponse, err := disksService.List().Search(fmt.Sprintf("name=%s", alias)).Send()
+	if err != nil {
+		return err
+	}
+	for _, disk := range disksResponse.MustDisks().Slice() {
+		err := uninstaller.retryImageLocks(fmt.Sprintf("uploaded image %s", disk.MustId()), func() error {
+			_, err := disksService.DiskService(disk.MustId()).Remove().Send()
+			return err
+		})
+		if err != nil {
+			uninstaller.Logger.Debugf("Failed removing uploaded image %s: %s", disk.MustId(), err)
+			continue
+		}
+		uninstaller.Logger.Infof("Removed uploaded image %s", disk.MustId())
+	}
+	return nil
+}
+
+func (uninstaller *ClusterUninstaller) removeTag(con *ovirtsdk.Connection) error {
+	// finally remove the tag
+	tagsService := con.SystemService().TagsService()
+	tagsServiceListResponse, err := tagsService.List().Send()
+	if err != nil {
+		return err
+	}
+	if tagsServiceListResponse != nil {
+		for _, t := range tagsServiceListResponse.MustTags().Slice() {
+			if t.MustName() == uninstaller.Metadata.InfraID || t.MustName() == uninstaller.Metadata.InfraID+"-bootstrap" {
+				if _, err := tagsService.TagService(t.MustId()).Remove().Send(); err != nil {
+					uninstaller.Logger.Debugf("Failed removing tag %s: %s", t.MustName(), err)
 					return err
 				}
+				uninstaller.Logger.Infof("Removed tag %s", t.MustName())
 			}
 		}
 	}
 	return nil
 }
 
+// retryImageLocks runs fn, retrying while the engine reports the
+// object locked by a running image transfer or related operation.
+func (uninstaller *ClusterUninstaller) retryImageLocks(what string, fn func() error) error {
+	var err error
+	for attempt := 0; attempt < imageTransferRetries; attempt++ {
+		err = fn()
+		if err == nil {
+			return nil
+		}
+		message := strings.ToLower(err.Error())
+		if !strings.Contains(message, "locked") && !strings.Contains(message, "transfer") && !strings.Contains(message, "related operation") {
+			return err
+		}
+		uninstaller.Logger.Infof("Waiting for the image lock on %s to release: %s", what, err)
+		time.Sleep(imageTransferRetryDelay)
+	}
+	return err
+}
+
 // New returns oVirt Uninstaller from ClusterMetadata.
 func New(logger logrus.FieldLogger, metadata *types.ClusterMetadata) (providers.Destroyer, error) {
 	return &ClusterUninstaller{
@@ -0,0 +1,49 @@
+package providers
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/installer/pkg/metrics"
+)
+
+// PollUntilDone repeatedly invokes step until it reports done, backing
+// off exponentially between discovery rounds (with jitter) so the
+// destroyers stop hammering the cloud list APIs while waiting for
+// dependent deletions. The interval resets to the minimum whenever a
+// round makes progress, so completed deletions wake the next round up
+// immediately. The number of rounds is logged at the end so API-call
+// improvements stay measurable.
+//
+// step returns (done, progressed, err); a non-nil error aborts the
+// loop.
+func PollUntilDone(logger logrus.FieldLogger, minInterval, maxInterval time.Duration, step func() (done bool, progressed bool, err error)) error {
+	interval := minInterval
+	rounds := 0
+	for {
+		rounds++
+		done, progressed, err := step()
+		if err != nil {
+			logger.Debugf("Discovery finished with an error after %d round(s)", rounds)
+			return err
+		}
+		if done {
+			logger.Debugf("Discovery finished after %d round(s)", rounds)
+			metrics.Add("openshift_install_destroy_discovery_rounds_total", nil, float64(rounds), "Discovery rounds performed by the destroyers.")
+			return nil
+		}
+		if progressed {
+			interval = minInterval
+		} else {
+			interval *= 2
+			if interval > maxInterval {
+				interval = maxInterval
+			}
+		}
+		// Jitter by up to 20% to avoid synchronized bursts.
+		jitter := time.Duration(rand.Int63n(int64(interval) / 5))
+		time.Sleep(interval + jitter)
+	}
+}
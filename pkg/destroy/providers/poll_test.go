@@ -0,0 +1,27 @@
+package providers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPollUntilDoneStopsOnDone(t *testing.T) {
+	rounds := 0
+	err := PollUntilDone(logrus.StandardLogger(), time.Millisecond, 4*time.Millisecond, func() (bool, bool, error) {
+		rounds++
+		return rounds == 3, false, nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, rounds)
+}
+
+func TestPollUntilDoneAbortsOnError(t *testing.T) {
+	err := PollUntilDone(logrus.StandardLogger(), time.Millisecond, 4*time.Millisecond, func() (bool, bool, error) {
+		return false, false, errors.New("fatal")
+	})
+	assert.EqualError(t, err, "fatal")
+}
@@ -12,5 +12,12 @@ type Destroyer interface {
 	Run() error
 }
 
+// Identifier is implemented by destroyers that can list the resources
+// they would delete without deleting anything, so that leaked clusters
+// can be inventoried before being pruned.
+type Identifier interface {
+	Identify() error
+}
+
 // NewFunc is an interface for creating platform-specific destroyers.
 type NewFunc func(logger logrus.FieldLogger, metadata *types.ClusterMetadata) (Destroyer, error)
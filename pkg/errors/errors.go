@@ -0,0 +1,85 @@
+// Package errors defines exported error classes for common installer
+// failure modes so that wrapping tools (e.g. Hive) can branch on them
+// with errors.Is or errors.As instead of parsing error strings.
+package errors
+
+import (
+	"errors"
+	"regexp"
+)
+
+// Sentinel errors for the common installer failure classes.  Errors
+// classified with Classify match these with errors.Is while keeping
+// their original human-readable messages.
+var (
+	// ErrQuotaExceeded indicates a cloud-provider quota or limit was hit.
+	ErrQuotaExceeded = errors.New("quota exceeded")
+	// ErrPermissionDenied indicates the credentials lack a required permission.
+	ErrPermissionDenied = errors.New("permission denied")
+	// ErrDNSConflict indicates a DNS record or zone required by the
+	// installation already exists.
+	ErrDNSConflict = errors.New("dns conflict")
+	// ErrTimeoutWaitingForBootstrap indicates the bootstrap process did
+	// not complete within the allotted time.
+	ErrTimeoutWaitingForBootstrap = errors.New("timeout waiting for bootstrap")
+	// ErrInvalidInstallConfig indicates the install config failed validation.
+	ErrInvalidInstallConfig = errors.New("invalid install config")
+)
+
+// classified decorates an error with a failure class without changing
+// its message.
+type classified struct {
+	error
+	class error
+}
+
+// Is reports whether the decorated error belongs to the target class.
+func (c *classified) Is(target error) bool {
+	return target == c.class
+}
+
+// Unwrap returns the decorated error.
+func (c *classified) Unwrap() error {
+	return c.error
+}
+
+// Classify marks err as belonging to the given failure class.  The
+// returned error keeps err's message and unwraps to err, so both
+// errors.Is(err, class) and matching on the underlying error keep
+// working.
+func Classify(err error, class error) error {
+	if err == nil {
+		return nil
+	}
+	return &classified{error: err, class: class}
+}
+
+// classPatterns maps failure classes to message patterns produced by
+// the cloud providers and Terraform.  The patterns only need to catch
+// representative cases; callers that know the class should use
+// Classify directly.
+var classPatterns = []struct {
+	class   error
+	pattern *regexp.Regexp
+}{
+	{ErrQuotaExceeded, regexp.MustCompile(`(?i)quota.*exceeded|exceed.*quota|LimitExceeded|QUOTA_EXCEEDED|TooManyBuckets`)},
+	{ErrPermissionDenied, regexp.MustCompile(`(?i)AccessDenied|UnauthorizedOperation|AuthorizationFailed|Forbidden|status code: 403|permission denied`)},
+	{ErrDNSConflict, regexp.MustCompile(`(?i)(record set|dns record|hosted zone|managed zone).*(already exists|conflict)`)},
+}
+
+// ClassifyFromMessage inspects err's message for patterns of the known
+// failure classes and, when one matches, returns err classified
+// accordingly.  The message is left untouched.  Errors that match no
+// pattern are returned unchanged.
+func ClassifyFromMessage(err error) error {
+	if err == nil {
+		return nil
+	}
+	message := err.Error()
+	for _, candidate := range classPatterns {
+		if candidate.pattern.MatchString(message) {
+			return Classify(err, candidate.class)
+		}
+	}
+	return err
+}
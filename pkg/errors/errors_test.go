@@ -0,0 +1,81 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	pkgerrors "github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassify(t *testing.T) {
+	underlying := errors.New("Error: Error applying plan")
+	err := Classify(underlying, ErrQuotaExceeded)
+	assert.True(t, errors.Is(err, ErrQuotaExceeded))
+	assert.False(t, errors.Is(err, ErrPermissionDenied))
+	assert.Equal(t, underlying.Error(), err.Error())
+
+	wrapped := pkgerrors.Wrap(err, "failed to create cluster")
+	assert.True(t, errors.Is(wrapped, ErrQuotaExceeded))
+
+	assert.NoError(t, Classify(nil, ErrQuotaExceeded))
+}
+
+func TestClassifyFromMessage(t *testing.T) {
+	cases := []struct {
+		name     string
+		err      error
+		expected error
+	}{
+		{
+			name:     "aws instance quota",
+			err:      errors.New("Error launching source instance: InstanceLimitExceeded: Your quota allows for 0 more running instance(s). status code: 400"),
+			expected: ErrQuotaExceeded,
+		},
+		{
+			name:     "gcp quota",
+			err:      errors.New("googleapi: Error 403: Quota 'CPUS' exceeded. Limit: 24.0 in region us-east1., quotaExceeded"),
+			expected: ErrQuotaExceeded,
+		},
+		{
+			name:     "aws access denied",
+			err:      errors.New("AccessDenied: User: arn:aws:iam::1234:user/tester is not authorized to perform: iam:CreateRole"),
+			expected: ErrPermissionDenied,
+		},
+		{
+			name:     "azure authorization failed",
+			err:      errors.New("Code=\"AuthorizationFailed\" Message=\"The client does not have authorization to perform action\""),
+			expected: ErrPermissionDenied,
+		},
+		{
+			name:     "route53 record conflict",
+			err:      errors.New("InvalidChangeBatch: Tried to create resource record set api.example.com. but it already exists"),
+			expected: ErrDNSConflict,
+		},
+		{
+			name: "unclassified",
+			err:  errors.New("context deadline exceeded"),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			classified := ClassifyFromMessage(tc.err)
+			assert.Equal(t, tc.err.Error(), classified.Error())
+			if tc.expected != nil {
+				assert.True(t, errors.Is(classified, tc.expected))
+			} else {
+				for _, candidate := range classPatterns {
+					assert.False(t, errors.Is(classified, candidate.class))
+				}
+			}
+		})
+	}
+}
+
+func TestClassifyFromMessagePreservesWrapping(t *testing.T) {
+	underlying := fmt.Errorf("AccessDenied: status code: 403")
+	classified := ClassifyFromMessage(pkgerrors.Wrap(underlying, "failed to create cluster"))
+	assert.True(t, errors.Is(classified, ErrPermissionDenied))
+	assert.Equal(t, "failed to create cluster: AccessDenied: status code: 403", classified.Error())
+}
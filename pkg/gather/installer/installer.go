@@ -0,0 +1,117 @@
+// Package installer assembles a failure bundle from the installer's
+// own artifacts - terraform state, redacted tfvars, the installer log
+// and metadata.json - so support does not have to ask for the files
+// one by one after an infrastructure-stage failure.
+package installer
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/installer/pkg/terraform"
+)
+
+// Gather writes a log-bundle-<id>-installer.tar.gz into directory,
+// named consistently with the bootstrap gather bundle, and returns
+// its path. The tfvars files and the terraform state are redacted
+// before they are archived so ignition payloads and platform
+// credentials never land in the bundle.
+func Gather(directory string) (string, error) {
+	gatherID := time.Now().Format("20060102150405")
+	bundlePath := filepath.Join(directory, "log-bundle-"+gatherID+"-installer.tar.gz")
+
+	bundle, err := os.Create(bundlePath)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to create the failure bundle")
+	}
+	defer bundle.Close()
+	gzw := gzip.NewWriter(bundle)
+	defer gzw.Close()
+	tw := tar.NewWriter(gzw)
+	defer tw.Close()
+
+	for _, name := range []string{
+		"metadata.json",
+		".openshift_install.log",
+	} {
+		if err := addFile(tw, directory, name, nil); err != nil {
+			return "", err
+		}
+	}
+
+	// The state embeds the full bootstrap ignition (e.g. as the S3
+	// object content on AWS), so it gets the same redaction treatment
+	// as the tfvars.
+	if err := addFile(tw, directory, terraform.StateFileName, RedactTFState); err != nil {
+		return "", err
+	}
+
+	// The per-stage state backups taken before each destructive
+	// terraform operation.
+	backups, _ := filepath.Glob(filepath.Join(directory, terraform.BackupsDir, "*"))
+	for _, backup := range backups {
+		rel, err := filepath.Rel(directory, backup)
+		if err != nil {
+			continue
+		}
+		if err := addFile(tw, directory, rel, RedactTFState); err != nil {
+			return "", err
+		}
+	}
+
+	tfvars, _ := filepath.Glob(filepath.Join(directory, "terraform*.tfvars.json"))
+	for _, path := range tfvars {
+		if err := addFile(tw, directory, filepath.Base(path), RedactTFVars); err != nil {
+			return "", err
+		}
+	}
+
+	return bundlePath, nil
+}
+
+// addFile copies directory/name into the archive, passing the
+// contents through transform when non-nil. Missing files are skipped
+// silently - not every install reaches the stage that writes them.
+func addFile(tw *tar.Writer, directory, name string, transform func([]byte) ([]byte, error)) error {
+	path := filepath.Join(directory, name)
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return errors.Wrapf(err, "failed to stat %s", path)
+	}
+	if info.IsDir() {
+		return nil
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read %s", path)
+	}
+	if transform != nil {
+		data, err = transform(data)
+		if err != nil {
+			logrus.Warnf("Leaving %s out of the failure bundle: %v", name, err)
+			return nil
+		}
+	}
+
+	header := &tar.Header{
+		Name:    name,
+		Mode:    0600,
+		Size:    int64(len(data)),
+		ModTime: info.ModTime(),
+	}
+	if err := tw.WriteHeader(header); err != nil {
+		return errors.Wrapf(err, "failed to write the archive header for %s", name)
+	}
+	_, err = tw.Write(data)
+	return errors.Wrapf(err, "failed to archive %s", name)
+}
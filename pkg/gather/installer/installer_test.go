@@ -0,0 +1,130 @@
+package installer
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testPullSecret stands in for the pull secret that the ignition
+// payloads embed.
+const testPullSecret = "supersecret-auth-data"
+
+func TestRedactTFVars(t *testing.T) {
+	data := []byte(`{
+  "cluster_id": "test-x9k2p",
+  "ignition_bootstrap": "` + testPullSecret + `",
+  "ovirt_password": "hunter2",
+  "openstack_credentials_cacert": "---PEM---",
+  "machine_cidr": "10.0.0.0/16"
+}`)
+
+	redacted, err := RedactTFVars(data)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(redacted), testPullSecret)
+	assert.NotContains(t, string(redacted), "hunter2")
+	assert.NotContains(t, string(redacted), "---PEM---")
+	assert.Contains(t, string(redacted), `"cluster_id": "test-x9k2p"`)
+	assert.Contains(t, string(redacted), `"machine_cidr": "10.0.0.0/16"`)
+	assert.Contains(t, string(redacted), `"ignition_bootstrap": "REDACTED"`)
+
+	_, err = RedactTFVars([]byte("not json"))
+	assert.Error(t, err)
+}
+
+func TestRedactTFState(t *testing.T) {
+	data := []byte(`{
+  "version": 4,
+  "outputs": {},
+  "resources": [
+    {
+      "type": "aws_s3_bucket_object",
+      "instances": [
+        {"attributes": {"bucket": "test-x9k2p-bootstrap", "content": "` + testPullSecret + `"}}
+      ]
+    },
+    {
+      "type": "ovirt_vm",
+      "instances": [
+        {"attributes": {"name": "test-x9k2p-master-0", "initialization": [{"custom_script": "` + testPullSecret + `"}]}}
+      ]
+    }
+  ]
+}`)
+
+	redacted, err := RedactTFState(data)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(redacted), testPullSecret)
+	assert.Contains(t, string(redacted), "test-x9k2p-bootstrap")
+	assert.Contains(t, string(redacted), "test-x9k2p-master-0")
+
+	_, err = RedactTFState([]byte("not json"))
+	assert.Error(t, err)
+}
+
+func TestGather(t *testing.T) {
+	dir, err := ioutil.TempDir("", "installer-gather")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"terraform.tfstate":      `{"version": 4, "resources": [{"type": "aws_s3_bucket_object", "instances": [{"attributes": {"bucket": "test-x9k2p-bootstrap", "content": "` + testPullSecret + `"}}]}]}`,
+		"metadata.json":          `{"clusterName": "test"}`,
+		".openshift_install.log": "level=debug msg=something",
+		"terraform.tfvars.json":  `{"ignition_master": "` + testPullSecret + `", "cluster_id": "test-x9k2p"}`,
+	}
+	for name, content := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	bundlePath, err := Gather(dir)
+	assert.NoError(t, err)
+
+	archived := readBundle(t, bundlePath)
+	for name := range files {
+		assert.Contains(t, archived, name)
+	}
+	assert.NotContains(t, archived["terraform.tfvars.json"], testPullSecret)
+	assert.Contains(t, archived["terraform.tfvars.json"], "test-x9k2p")
+	assert.NotContains(t, archived["terraform.tfstate"], testPullSecret)
+	assert.Contains(t, archived["terraform.tfstate"], "test-x9k2p-bootstrap")
+}
+
+func readBundle(t *testing.T, path string) map[string]string {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gzr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr := tar.NewReader(gzr)
+	contents := map[string]string{}
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, err := ioutil.ReadAll(tr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		contents[header.Name] = string(body)
+	}
+	return contents
+}
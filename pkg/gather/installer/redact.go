@@ -0,0 +1,82 @@
+package installer
+
+import (
+	"encoding/json"
+	"regexp"
+
+	"github.com/pkg/errors"
+)
+
+// secretTFVarsKeys matches the tfvars keys whose values carry
+// credentials or credential-bearing payloads: the ignition configs
+// embed the pull secret and the cluster's private keys, and the
+// platform-specific files carry cloud passwords and tokens. Any new
+// tfvars key holding a secret must keep matching this pattern.
+var secretTFVarsKeys = regexp.MustCompile(`(?i)ignition|password|secret|token|credential|private_key|keyfile|cacert`)
+
+// secretTFStateKeys additionally matches the resource attributes the
+// terraform state uses to carry the same payloads: the bootstrap
+// ignition lands in the state as the S3 object's content, the oVirt
+// VMs embed it as custom_script and the cloud instances as
+// user_data.
+var secretTFStateKeys = regexp.MustCompile(`(?i)ignition|password|secret|token|credential|private_key|keyfile|cacert|^content$|user_data|custom_script`)
+
+// redactedValue replaces a secret so its former presence stays
+// visible in the bundle.
+const redactedValue = "REDACTED"
+
+// RedactTFVars returns the tfvars JSON with every secret-carrying
+// top-level value replaced, so the files can be attached to bug
+// reports. An error means the data could not be parsed and must not
+// be archived at all.
+func RedactTFVars(data []byte) ([]byte, error) {
+	vars := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &vars); err != nil {
+		return nil, errors.Wrap(err, "failed to parse tfvars")
+	}
+
+	redacted, err := json.Marshal(redactedValue)
+	if err != nil {
+		return nil, err
+	}
+	for key := range vars {
+		if secretTFVarsKeys.MatchString(key) {
+			vars[key] = redacted
+		}
+	}
+
+	return json.MarshalIndent(vars, "", "  ")
+}
+
+// RedactTFState returns the terraform state with every
+// secret-carrying attribute value replaced, walking the whole
+// document since the state nests attributes per resource instance.
+// An error means the data could not be parsed and must not be
+// archived at all.
+func RedactTFState(data []byte) ([]byte, error) {
+	var state interface{}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, errors.Wrap(err, "failed to parse terraform state")
+	}
+	redactValue(state)
+	return json.MarshalIndent(state, "", "  ")
+}
+
+// redactValue replaces the values of secret-carrying keys anywhere in
+// the document.
+func redactValue(value interface{}) {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		for key, nested := range typed {
+			if secretTFStateKeys.MatchString(key) {
+				typed[key] = redactedValue
+				continue
+			}
+			redactValue(nested)
+		}
+	case []interface{}:
+		for _, nested := range typed {
+			redactValue(nested)
+		}
+	}
+}
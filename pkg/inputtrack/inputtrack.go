@@ -0,0 +1,105 @@
+// Package inputtrack records every external input an installer run
+// consumes - files, environment overrides, cache hits - so runs can be
+// compared and support can ask for exactly the right artifacts.
+package inputtrack
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Input is one external input consumed by the run.
+type Input struct {
+	// Kind is "file", "env" or "cache".
+	Kind string `json:"kind"`
+	// Name is the file path, variable name or cache key.
+	Name string `json:"name"`
+	// SHA256 is the digest of the content. Secret values are recorded
+	// by hash only, like everything else, and flagged.
+	SHA256 string `json:"sha256,omitempty"`
+	// Secret marks inputs whose content must never be requested in
+	// clear text.
+	Secret bool `json:"secret,omitempty"`
+}
+
+var (
+	mutex  sync.Mutex
+	inputs = map[string]Input{}
+)
+
+func record(input Input) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	inputs[input.Kind+":"+input.Name] = input
+}
+
+// File records a file input, hashing its current content.
+func File(path string, secret bool) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return
+	}
+	record(Input{Kind: "file", Name: path, SHA256: fmt.Sprintf("%x", sha256.Sum256(data)), Secret: secret})
+}
+
+// Cache records a cache hit.
+func Cache(path string) {
+	File(path, false)
+	mutex.Lock()
+	defer mutex.Unlock()
+	if input, ok := inputs["file:"+path]; ok {
+		delete(inputs, "file:"+path)
+		input.Kind = "cache"
+		inputs["cache:"+path] = input
+	}
+}
+
+// WriteManifest writes inputs-manifest.json into the asset directory,
+// adding every OPENSHIFT_INSTALL_* environment override present at
+// write time.
+func WriteManifest(directory string) {
+	for _, entry := range os.Environ() {
+		parts := strings.SplitN(entry, "=", 2)
+		if !strings.HasPrefix(parts[0], "OPENSHIFT_INSTALL_") {
+			continue
+		}
+		secret := strings.Contains(parts[0], "SECRET") || strings.Contains(parts[0], "TOKEN") || strings.Contains(parts[0], "PASSWORD")
+		record(Input{
+			Kind:   "env",
+			Name:   parts[0],
+			SHA256: fmt.Sprintf("%x", sha256.Sum256([]byte(parts[1]))),
+			Secret: secret,
+		})
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	list := make([]Input, 0, len(inputs))
+	for _, input := range inputs {
+		list = append(list, input)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Kind != list[j].Kind {
+			return list[i].Kind < list[j].Kind
+		}
+		return list[i].Name < list[j].Name
+	})
+
+	data, err := json.MarshalIndent(list, "", "  ")
+	if err != nil {
+		logrus.Debugf("Failed to marshal the inputs manifest: %v", err)
+		return
+	}
+	if err := ioutil.WriteFile(filepath.Join(directory, "inputs-manifest.json"), data, 0644); err != nil {
+		logrus.Debugf("Failed to write the inputs manifest: %v", err)
+	}
+}
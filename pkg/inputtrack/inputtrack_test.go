@@ -0,0 +1,40 @@
+package inputtrack
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteManifest(t *testing.T) {
+	dir, err := ioutil.TempDir("", "inputtrack")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	input := filepath.Join(dir, "install-config.yaml")
+	assert.NoError(t, ioutil.WriteFile(input, []byte("apiVersion: v1\n"), 0644))
+	File(input, false)
+	os.Setenv("OPENSHIFT_INSTALL_TEST_SECRET", "hunter2")
+	defer os.Unsetenv("OPENSHIFT_INSTALL_TEST_SECRET")
+
+	WriteManifest(dir)
+
+	data, err := ioutil.ReadFile(filepath.Join(dir, "inputs-manifest.json"))
+	assert.NoError(t, err)
+	var list []Input
+	assert.NoError(t, json.Unmarshal(data, &list))
+
+	kinds := map[string]Input{}
+	for _, entry := range list {
+		kinds[entry.Kind+":"+entry.Name] = entry
+	}
+	assert.Contains(t, kinds, "file:"+input)
+	secret := kinds["env:OPENSHIFT_INSTALL_TEST_SECRET"]
+	assert.True(t, secret.Secret)
+	assert.NotEmpty(t, secret.SHA256)
+	assert.NotContains(t, string(data), "hunter2")
+}
@@ -0,0 +1,139 @@
+// Package installer exposes a stable programmatic facade over the
+// asset store, the terraform stages and the destroyers, so controllers
+// such as Hive can embed the installer instead of shelling out to the
+// binary and parsing its logs. The CLI itself drives the same facade,
+// so the two paths cannot diverge.
+package installer
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/installer/pkg/asset"
+	assetstore "github.com/openshift/installer/pkg/asset/store"
+	targetassets "github.com/openshift/installer/pkg/asset/targets"
+	"github.com/openshift/installer/pkg/destroy"
+	"github.com/openshift/installer/pkg/inputtrack"
+	"github.com/openshift/installer/pkg/metrics"
+)
+
+// EventType classifies progress events.
+type EventType string
+
+const (
+	// StageStarted marks the beginning of a named stage.
+	StageStarted EventType = "StageStarted"
+	// StageCompleted marks the successful end of a named stage.
+	StageCompleted EventType = "StageCompleted"
+	// StageFailed marks the failed end of a named stage.
+	StageFailed EventType = "StageFailed"
+)
+
+// Event reports progress of an installer operation. Stages include
+// the asset names being generated (terraform stage transitions show up
+// as the "Cluster" asset) and the wait loops driven by the caller.
+type Event struct {
+	Type      EventType
+	Stage     string
+	Message   string
+	Timestamp time.Time
+}
+
+// Installer drives installer operations against one asset directory.
+type Installer struct {
+	assetDir string
+}
+
+// New returns an Installer rooted at assetDir. When installConfig is
+// non-empty it is written as install-config.yaml into the directory,
+// replacing whatever was there.
+func New(assetDir string, installConfig []byte) (*Installer, error) {
+	if err := os.MkdirAll(assetDir, 0755); err != nil {
+		return nil, errors.Wrap(err, "failed to create the asset directory")
+	}
+	if len(installConfig) > 0 {
+		if err := ioutil.WriteFile(filepath.Join(assetDir, "install-config.yaml"), installConfig, 0644); err != nil {
+			return nil, errors.Wrap(err, "failed to write install-config.yaml")
+		}
+	}
+	return &Installer{assetDir: assetDir}, nil
+}
+
+// GenerateManifests generates the manifests target into the asset
+// directory.
+func (i *Installer) GenerateManifests(ctx context.Context) error {
+	return Generate(i.assetDir, nil, targetassets.Manifests...)
+}
+
+// CreateCluster generates the cluster target - terraform apply
+// included - reporting progress on events when non-nil. Waiting for
+// bootstrap and install completion stays with the caller, which knows
+// its own timeouts.
+func (i *Installer) CreateCluster(ctx context.Context, events chan<- Event) error {
+	return Generate(i.assetDir, events, targetassets.Cluster...)
+}
+
+// DestroyCluster destroys the cluster described by the directory's
+// metadata.json.
+func (i *Installer) DestroyCluster(ctx context.Context) error {
+	destroyer, err := destroy.New(logrus.StandardLogger(), i.assetDir)
+	if err != nil {
+		return errors.Wrap(err, "failed while preparing to destroy cluster")
+	}
+	return destroyer.Run()
+}
+
+// Generate fetches and persists the given target assets in order,
+// emitting per-asset stage events on events when non-nil. This is the
+// single generation path shared by the CLI and embedders.
+func Generate(directory string, events chan<- Event, targets ...asset.WritableAsset) error {
+	emit := func(eventType EventType, stage string, message string) {
+		if events == nil {
+			return
+		}
+		events <- Event{Type: eventType, Stage: stage, Message: message, Timestamp: time.Now()}
+	}
+
+	assetStore, err := assetstore.NewStore(directory)
+	if err != nil {
+		return errors.Wrap(err, "failed to create asset store")
+	}
+
+	inputtrack.File(filepath.Join(directory, "install-config.yaml"), false)
+	defer inputtrack.WriteManifest(directory)
+
+	for _, a := range targets {
+		emit(StageStarted, a.Name(), "")
+		stageStart := time.Now()
+		err := assetStore.Fetch(a, targets...)
+		metrics.Set("openshift_install_stage_duration_seconds", map[string]string{"stage": a.Name()}, time.Since(stageStart).Seconds(), "Duration of an installer stage.")
+		if err != nil {
+			err = errors.Wrapf(err, "failed to fetch %s", a.Name())
+		}
+
+		if err2 := asset.PersistToFile(a, directory); err2 != nil {
+			err2 = errors.Wrapf(err2, "failed to write asset (%s) to disk", a.Name())
+			if err != nil {
+				logrus.Error(err2)
+				emit(StageFailed, a.Name(), err.Error())
+				return err
+			}
+			emit(StageFailed, a.Name(), err2.Error())
+			return err2
+		}
+
+		if err != nil {
+			metrics.Add("openshift_install_failures_total", map[string]string{"phase": a.Name()}, 1, "Installer failures by the stage they occurred in.")
+			emit(StageFailed, a.Name(), err.Error())
+			return err
+		}
+		emit(StageCompleted, a.Name(), "")
+	}
+	return nil
+}
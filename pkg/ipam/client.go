@@ -0,0 +1,104 @@
+// Package ipam talks to an external IP address management service
+// (e.g. phpIPAM or NetBox behind a small shim) over HTTP to allocate
+// and release static addresses for the installer.
+package ipam
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/types"
+)
+
+const requestTimeout = 30 * time.Second
+
+// Allocation is a single address handed out by the IPAM service.  The
+// ID is recorded in the cluster metadata so the address can be
+// released on destroy.
+type Allocation struct {
+	ID      string `json:"id"`
+	Address string `json:"address"`
+}
+
+// Allocate requests count addresses from the given pool.
+func Allocate(ctx context.Context, config *types.IPAM, pool string, count int) ([]Allocation, error) {
+	body, err := json.Marshal(map[string]interface{}{
+		"pool":  pool,
+		"count": count,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, strings.TrimSuffix(config.Endpoint, "/")+"/allocations", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := do(ctx, config, req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to allocate %d address(es) from pool %q", count, pool)
+	}
+	defer resp.Body.Close()
+
+	var allocations []Allocation
+	if err := json.NewDecoder(resp.Body).Decode(&allocations); err != nil {
+		return nil, errors.Wrapf(err, "failed to decode allocation response for pool %q", pool)
+	}
+	if len(allocations) != count {
+		return nil, errors.Errorf("pool %q returned %d address(es), expected %d", pool, len(allocations), count)
+	}
+	return allocations, nil
+}
+
+// Release returns previously-allocated addresses to the IPAM service.
+func Release(ctx context.Context, config *types.IPAM, allocationIDs []string) error {
+	for _, id := range allocationIDs {
+		req, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("%s/allocations/%s", strings.TrimSuffix(config.Endpoint, "/"), id), nil)
+		if err != nil {
+			return err
+		}
+		resp, err := do(ctx, config, req)
+		if err != nil {
+			return errors.Wrapf(err, "failed to release allocation %q", id)
+		}
+		resp.Body.Close()
+	}
+	return nil
+}
+
+// do sends the request with the configured auth token and a bounded
+// timeout, turning non-2xx responses into errors.
+func do(ctx context.Context, config *types.IPAM, req *http.Request) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+	req = req.WithContext(ctx)
+
+	if config.AuthTokenFile != "" {
+		token, err := ioutil.ReadFile(config.AuthTokenFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read IPAM auth token")
+		}
+		req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		return nil, errors.Errorf("IPAM service returned %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return resp, nil
+}
@@ -0,0 +1,96 @@
+// Package metrics collects installer counters and gauges and writes
+// them as an OpenMetrics text file for CI aggregation. It is updated
+// at the same instrumentation points as the human-readable timing
+// summary; nothing is written unless a metrics file is configured.
+package metrics
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	mutex    sync.Mutex
+	filePath string
+	values   = map[string]float64{}
+	help     = map[string]string{}
+)
+
+// SetFile configures where WriteFile writes; empty disables metrics.
+func SetFile(path string) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	filePath = path
+}
+
+func key(name string, labels map[string]string) string {
+	if len(labels) == 0 {
+		return name
+	}
+	keys := make([]string, 0, len(labels))
+	for labelKey := range labels {
+		keys = append(keys, labelKey)
+	}
+	sort.Strings(keys)
+	parts := make([]string, 0, len(keys))
+	for _, labelKey := range keys {
+		parts = append(parts, fmt.Sprintf("%s=%q", labelKey, labels[labelKey]))
+	}
+	return fmt.Sprintf("%s{%s}", name, strings.Join(parts, ","))
+}
+
+// Set records a gauge sample.
+func Set(name string, labels map[string]string, value float64, helpText string) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	values[key(name, labels)] = value
+	help[name] = helpText
+}
+
+// Add increments a counter sample.
+func Add(name string, labels map[string]string, delta float64, helpText string) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	values[key(name, labels)] += delta
+	help[name] = helpText
+}
+
+// WriteFile writes the collected samples in OpenMetrics text format to
+// the configured file, if any.
+func WriteFile() {
+	mutex.Lock()
+	defer mutex.Unlock()
+	if filePath == "" {
+		return
+	}
+
+	names := make([]string, 0, len(values))
+	for sample := range values {
+		names = append(names, sample)
+	}
+	sort.Strings(names)
+
+	var builder strings.Builder
+	written := map[string]bool{}
+	for _, sample := range names {
+		metric := strings.SplitN(sample, "{", 2)[0]
+		if !written[metric] {
+			written[metric] = true
+			fmt.Fprintf(&builder, "# TYPE %s gauge\n", metric)
+			if text := help[metric]; text != "" {
+				fmt.Fprintf(&builder, "# HELP %s %s\n", metric, text)
+			}
+		}
+		fmt.Fprintf(&builder, "%s %g\n", sample, values[sample])
+	}
+	builder.WriteString("# EOF\n")
+
+	if err := ioutil.WriteFile(filePath, []byte(builder.String()), 0644); err != nil {
+		logrus.Warnf("Failed to write the metrics file %s: %v", filePath, err)
+	}
+}
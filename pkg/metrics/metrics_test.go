@@ -0,0 +1,30 @@
+package metrics
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "metrics")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "metrics.prom")
+	SetFile(path)
+	defer SetFile("")
+
+	Set("openshift_install_duration_seconds", map[string]string{"stage": "Manifests"}, 1.5, "Duration of an installer stage.")
+	Add("openshift_install_destroy_rounds_total", nil, 3, "Discovery rounds performed by the destroyers.")
+	WriteFile()
+
+	data, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	content := string(data)
+	assert.Contains(t, content, `openshift_install_duration_seconds{stage="Manifests"} 1.5`)
+	assert.Contains(t, content, "openshift_install_destroy_rounds_total 3")
+	assert.Contains(t, content, "# EOF")
+}
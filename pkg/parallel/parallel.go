@@ -0,0 +1,27 @@
+// Package parallel runs independent long-running installer steps
+// concurrently with error aggregation.
+package parallel
+
+import (
+	"sync"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+// Run executes the given functions concurrently and waits for all of
+// them, returning the aggregated errors. Callers are responsible for
+// only handing in genuinely independent work; the asset dependency
+// graph stays authoritative for everything else.
+func Run(funcs ...func() error) error {
+	var wg sync.WaitGroup
+	errs := make([]error, len(funcs))
+	for i, f := range funcs {
+		wg.Add(1)
+		go func(i int, f func() error) {
+			defer wg.Done()
+			errs[i] = f()
+		}(i, f)
+	}
+	wg.Wait()
+	return utilerrors.NewAggregate(errs)
+}
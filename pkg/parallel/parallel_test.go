@@ -0,0 +1,43 @@
+package parallel
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunOverlapsIndependentWork(t *testing.T) {
+	var mutex sync.Mutex
+	running := 0
+	peak := 0
+	work := func() error {
+		mutex.Lock()
+		running++
+		if running > peak {
+			peak = running
+		}
+		mutex.Unlock()
+		time.Sleep(50 * time.Millisecond)
+		mutex.Lock()
+		running--
+		mutex.Unlock()
+		return nil
+	}
+	assert.NoError(t, Run(work, work))
+	assert.Equal(t, 2, peak, "independent steps should overlap")
+}
+
+func TestRunAggregatesErrors(t *testing.T) {
+	err := Run(
+		func() error { return errors.New("first") },
+		func() error { return nil },
+		func() error { return errors.New("third") },
+	)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "first")
+		assert.Contains(t, err.Error(), "third")
+	}
+}
@@ -0,0 +1,48 @@
+package parallel
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// Progress reports how far a long-running transfer has come and
+// whether it finished. Errors abort the wait.
+type Progress func() (transferred int64, total int64, done bool, err error)
+
+// WaitForProgress polls a long-running transfer until it completes,
+// honoring ctx and enforcing an overall deadline that extends while
+// bytes are still flowing: every poll that moves the transferred count
+// forward resets the stall budget, so a slow-but-alive copy is not
+// killed while a stuck one fails quickly. The completion percentage is
+// logged each interval.
+func WaitForProgress(ctx context.Context, logger logrus.FieldLogger, interval, stallDeadline time.Duration, progress Progress) error {
+	lastTransferred := int64(-1)
+	lastMovement := time.Now()
+	for {
+		transferred, total, done, err := progress()
+		if err != nil {
+			return err
+		}
+		if done {
+			return nil
+		}
+		if transferred != lastTransferred {
+			lastTransferred = transferred
+			lastMovement = time.Now()
+		} else if time.Since(lastMovement) > stallDeadline {
+			return errors.Errorf("transfer stalled: no progress for %s (%d of %d bytes)", stallDeadline, transferred, total)
+		}
+		if total > 0 {
+			logger.Infof("Transfer progress: %d%% (%d of %d bytes)", transferred*100/total, transferred, total)
+		}
+
+		select {
+		case <-ctx.Done():
+			return errors.Wrap(ctx.Err(), "transfer canceled")
+		case <-time.After(interval):
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package parallel
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWaitForProgressCompletes(t *testing.T) {
+	transferred := int64(0)
+	err := WaitForProgress(context.Background(), logrus.StandardLogger(), time.Millisecond, 50*time.Millisecond, func() (int64, int64, bool, error) {
+		transferred += 25
+		return transferred, 100, transferred >= 100, nil
+	})
+	assert.NoError(t, err)
+}
+
+func TestWaitForProgressExtendsWhileFlowing(t *testing.T) {
+	// Progress trickles in slower than the stall deadline would allow
+	// if it were a fixed overall deadline, but every poll moves bytes.
+	transferred := int64(0)
+	start := time.Now()
+	err := WaitForProgress(context.Background(), logrus.StandardLogger(), 5*time.Millisecond, 8*time.Millisecond, func() (int64, int64, bool, error) {
+		transferred++
+		return transferred, 10, transferred >= 10, nil
+	})
+	assert.NoError(t, err)
+	assert.True(t, time.Since(start) > 8*time.Millisecond, "the stall deadline must extend while bytes flow")
+}
+
+func TestWaitForProgressFailsWhenStalled(t *testing.T) {
+	err := WaitForProgress(context.Background(), logrus.StandardLogger(), time.Millisecond, 10*time.Millisecond, func() (int64, int64, bool, error) {
+		return 42, 100, false, nil
+	})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "no progress")
+		assert.Contains(t, err.Error(), "42 of 100")
+	}
+}
+
+func TestWaitForProgressHonorsContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := WaitForProgress(ctx, logrus.StandardLogger(), 10*time.Millisecond, time.Second, func() (int64, int64, bool, error) {
+		return 0, 100, false, nil
+	})
+	assert.Error(t, err)
+}
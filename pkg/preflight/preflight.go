@@ -0,0 +1,78 @@
+// Package preflight records validation results so fleet tooling can
+// scrape which checks a config passed and with what values.
+package preflight
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// MetricsFileEnv names the file the openmetrics summary is written to.
+// The summary is only written when this is set.
+const MetricsFileEnv = "OPENSHIFT_INSTALL_PREFLIGHT_METRICS"
+
+type result struct {
+	id     string
+	passed bool
+	labels map[string]string
+}
+
+var (
+	mutex   sync.Mutex
+	results []result
+)
+
+// Record stores the outcome of a validation check. The id must be the
+// same stable identifier used by the check's skip mechanism (e.g.
+// "dns-validation" for OPENSHIFT_INSTALL_SKIP_DNS_VALIDATION) so
+// dashboards and skips stay consistent.
+func Record(id string, passed bool, labels map[string]string) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	results = append(results, result{id: id, passed: passed, labels: labels})
+}
+
+// WriteMetrics writes the recorded results in openmetrics text format
+// to the file named by MetricsFileEnv, when set.
+func WriteMetrics() {
+	path := os.Getenv(MetricsFileEnv)
+	if path == "" {
+		return
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	var builder strings.Builder
+	builder.WriteString("# TYPE openshift_install_preflight_result gauge\n")
+	builder.WriteString("# HELP openshift_install_preflight_result 1 when the named preflight check passed, 0 when it failed.\n")
+	for _, r := range results {
+		labels := []string{fmt.Sprintf("check=%q", r.id)}
+		keys := make([]string, 0, len(r.labels))
+		for key := range r.labels {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			labels = append(labels, fmt.Sprintf("%s=%q", key, r.labels[key]))
+		}
+		value := 0
+		if r.passed {
+			value = 1
+		}
+		fmt.Fprintf(&builder, "openshift_install_preflight_result{%s} %d\n", strings.Join(labels, ","), value)
+	}
+	builder.WriteString("# EOF\n")
+
+	if err := ioutil.WriteFile(path, []byte(builder.String()), 0644); err != nil {
+		logrus.Warnf("Failed to write preflight metrics to %s: %v", path, err)
+		return
+	}
+	logrus.Debugf("Wrote preflight metrics to %s", path)
+}
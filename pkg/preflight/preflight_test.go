@@ -0,0 +1,30 @@
+package preflight
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteMetrics(t *testing.T) {
+	dir, err := ioutil.TempDir("", "preflight-metrics")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "metrics.prom")
+	os.Setenv(MetricsFileEnv, path)
+	defer os.Unsetenv(MetricsFileEnv)
+
+	Record("install-config-validation", true, map[string]string{"platform": "aws", "region": "us-east-1"})
+	Record("quota-validation", false, nil)
+	WriteMetrics()
+
+	data, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	content := string(data)
+	assert.Contains(t, content, `openshift_install_preflight_result{check="install-config-validation",platform="aws",region="us-east-1"} 1`)
+	assert.Contains(t, content, `openshift_install_preflight_result{check="quota-validation"} 0`)
+	assert.Contains(t, content, "# EOF")
+}
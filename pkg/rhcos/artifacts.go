@@ -0,0 +1,58 @@
+package rhcos
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/types"
+)
+
+// Artifacts returns the RHCOS build version and the per-platform
+// artifacts recorded in the embedded build metadata for the given
+// architecture.
+func Artifacts(ctx context.Context, arch types.Architecture) (string, map[string]types.RHCOSArtifact, error) {
+	meta, err := fetchRHCOSBuild(ctx, arch)
+	if err != nil {
+		return "", nil, errors.Wrap(err, "failed to fetch RHCOS metadata")
+	}
+
+	base, err := url.Parse(meta.BaseURI)
+	if err != nil {
+		return "", nil, err
+	}
+
+	artifacts := map[string]types.RHCOSArtifact{}
+	if meta.Images.QEMU.Path != "" {
+		rel, err := url.Parse(meta.Images.QEMU.Path)
+		if err != nil {
+			return "", nil, err
+		}
+		artifacts["qemu"] = types.RHCOSArtifact{
+			URL:    base.ResolveReference(rel).String(),
+			SHA256: meta.Images.QEMU.SHA256,
+		}
+	}
+	if meta.Images.OpenStack.Path != "" {
+		rel, err := url.Parse(meta.Images.OpenStack.Path)
+		if err != nil {
+			return "", nil, err
+		}
+		artifacts["openstack"] = types.RHCOSArtifact{
+			URL:    base.ResolveReference(rel).String(),
+			SHA256: meta.Images.OpenStack.SHA256,
+		}
+	}
+	if meta.Azure.URL != "" {
+		artifacts["azure"] = types.RHCOSArtifact{URL: meta.Azure.URL}
+	}
+	if meta.GCP.Image != "" {
+		artifacts["gcp"] = types.RHCOSArtifact{URL: meta.GCP.Image}
+	}
+	for region, ami := range meta.AMIs {
+		artifacts["aws-"+region] = types.RHCOSArtifact{URL: ami.HVM}
+	}
+
+	return meta.OSTreeVersion, artifacts, nil
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"net/url"
 	"os"
 
 	"github.com/openshift/installer/data"
@@ -66,3 +67,14 @@ func fetchRHCOSBuild(ctx context.Context, arch types.Architecture) (*metadata, e
 
 	return meta, nil
 }
+
+// SHA256FromURL extracts the sha256 digest the build metadata attached
+// to an image URL as a query parameter, or returns "" when the URL
+// carries none.
+func SHA256FromURL(imageURL string) string {
+	parsed, err := url.Parse(imageURL)
+	if err != nil {
+		return ""
+	}
+	return parsed.Query().Get("sha256")
+}
@@ -0,0 +1,98 @@
+package terraform
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// BackupsDir is the directory under the asset dir where terraform
+// state snapshots accumulate.
+const BackupsDir = "tfstate-backups"
+
+// BackupState snapshots the given terraform state into
+// <dir>/tfstate-backups/<stage>-<timestamp>.tfstate, so an interrupted
+// apply leaves something to recover from. Failures are logged rather
+// than fatal: a missed snapshot must never fail the stage itself.
+func BackupState(dir string, stage string, stateData []byte) {
+	backupsDir := filepath.Join(dir, BackupsDir)
+	if err := os.MkdirAll(backupsDir, 0755); err != nil {
+		logrus.Warnf("Failed to create %s: %v", backupsDir, err)
+		return
+	}
+	name := filepath.Join(backupsDir, stage+"-"+time.Now().UTC().Format("20060102T150405Z")+".tfstate")
+	if err := ioutil.WriteFile(name, stateData, 0600); err != nil {
+		logrus.Warnf("Failed to write terraform state backup %s: %v", name, err)
+		return
+	}
+	logrus.Debugf("Backed up terraform state to %s", name)
+}
+
+// ListBackups returns the state backups under dir, newest first.
+func ListBackups(dir string) ([]string, error) {
+	entries, err := ioutil.ReadDir(filepath.Join(dir, BackupsDir))
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var backups []string
+	for _, entry := range entries {
+		if !entry.IsDir() && filepath.Ext(entry.Name()) == ".tfstate" {
+			backups = append(backups, filepath.Join(dir, BackupsDir, entry.Name()))
+		}
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(backups)))
+	return backups, nil
+}
+
+// NewestConsistentBackup returns the newest backup that parses as a
+// terraform state file.
+func NewestConsistentBackup(dir string) (string, error) {
+	backups, err := ListBackups(dir)
+	if err != nil {
+		return "", err
+	}
+	for _, backup := range backups {
+		if stateIsConsistent(backup) {
+			return backup, nil
+		}
+	}
+	return "", errors.New("no consistent terraform state backup found")
+}
+
+// RestoreBackup copies the backup over the primary state file.
+func RestoreBackup(dir string, backup string) error {
+	data, err := ioutil.ReadFile(backup)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read backup %s", backup)
+	}
+	if !stateIsConsistentData(data) {
+		return errors.Errorf("backup %s is not a consistent terraform state file", backup)
+	}
+	return ioutil.WriteFile(filepath.Join(dir, StateFileName), data, 0600)
+}
+
+func stateIsConsistent(path string) bool {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	return stateIsConsistentData(data)
+}
+
+func stateIsConsistentData(data []byte) bool {
+	state := struct {
+		Version int `json:"version"`
+	}{}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return false
+	}
+	return state.Version > 0
+}
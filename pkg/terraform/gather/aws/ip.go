@@ -46,3 +46,14 @@ func ControlPlaneIPs(tfs *terraform.State) ([]string, error) {
 	}
 	return masters, utilerrors.NewAggregate(errs)
 }
+
+// BastionIP returns the public address of the optional bastion host,
+// or "" when the cluster has none.
+func BastionIP(tfs *terraform.State) string {
+	br, err := terraform.LookupResource(tfs, "", "aws_instance", "bastion")
+	if err != nil || len(br.Instances) == 0 {
+		return ""
+	}
+	ip, _, _ := unstructured.NestedString(br.Instances[0].Attributes, "public_ip")
+	return ip
+}
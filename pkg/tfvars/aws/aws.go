@@ -15,6 +15,16 @@ import (
 type config struct {
 	AMI                     string            `json:"aws_ami"`
 	ExtraTags               map[string]string `json:"aws_extra_tags,omitempty"`
+	ExtraMachineCIDRs       []string          `json:"aws_extra_machine_cidrs,omitempty"`
+	MasterPlacementGroup    string            `json:"aws_master_placement_group,omitempty"`
+	MasterPlacementStrategy string            `json:"aws_master_placement_strategy,omitempty"`
+	IAMPermissionsBoundary  string            `json:"aws_iam_permissions_boundary,omitempty"`
+	IAMRoleTags             map[string]string `json:"aws_iam_role_tags,omitempty"`
+	BastionEnabled          bool              `json:"aws_bastion_enabled"`
+	BastionInstanceType     string            `json:"aws_bastion_instance_type,omitempty"`
+	BastionAllowedCIDRs     []string          `json:"aws_bastion_allowed_cidrs,omitempty"`
+	BastionSSHKey           string            `json:"aws_bastion_ssh_key,omitempty"`
+	UserProvisionedDNS      bool              `json:"aws_user_provisioned_dns"`
 	BootstrapInstanceType   string            `json:"aws_bootstrap_instance_type,omitempty"`
 	MasterInstanceType      string            `json:"aws_master_instance_type,omitempty"`
 	MasterAvailabilityZones []string          `json:"aws_master_availability_zones"`
@@ -30,7 +40,7 @@ type config struct {
 }
 
 // TFVars generates AWS-specific Terraform variables launching the cluster.
-func TFVars(vpc string, privateSubnets []string, publicSubnets []string, publish types.PublishingStrategy, masterConfigs []*v1beta1.AWSMachineProviderConfig, workerConfigs []*v1beta1.AWSMachineProviderConfig) ([]byte, error) {
+func TFVars(vpc string, privateSubnets []string, publicSubnets []string, publish types.PublishingStrategy, masterConfigs []*v1beta1.AWSMachineProviderConfig, workerConfigs []*v1beta1.AWSMachineProviderConfig, extraMachineCIDRs []string, masterPlacementGroup, masterPlacementStrategy, iamPermissionsBoundary string, iamRoleTags map[string]string, bastion *types.Bastion, sshKey string, userProvisionedDNS bool) ([]byte, error) {
 	masterConfig := masterConfigs[0]
 
 	tags := make(map[string]string, len(masterConfig.Tags))
@@ -79,6 +89,12 @@ func TFVars(vpc string, privateSubnets []string, publicSubnets []string, publish
 	cfg := &config{
 		Region:                  masterConfig.Placement.Region,
 		ExtraTags:               tags,
+		ExtraMachineCIDRs:       extraMachineCIDRs,
+		MasterPlacementGroup:    masterPlacementGroup,
+		MasterPlacementStrategy: masterPlacementStrategy,
+		IAMPermissionsBoundary:  iamPermissionsBoundary,
+		IAMRoleTags:             iamRoleTags,
+		UserProvisionedDNS:      userProvisionedDNS,
 		AMI:                     *masterConfig.AMI.ID,
 		MasterAvailabilityZones: masterAvailabilityZones,
 		WorkerAvailabilityZones: workerAvailabilityZones,
@@ -103,5 +119,21 @@ func TFVars(vpc string, privateSubnets []string, publicSubnets []string, publish
 		cfg.IOPS = *rootVolume.EBS.Iops
 	}
 
+	if bastion != nil {
+		cfg.BastionEnabled = true
+		cfg.BastionInstanceType = bastion.InstanceType
+		if cfg.BastionInstanceType == "" {
+			cfg.BastionInstanceType = "t3.micro"
+		}
+		cfg.BastionAllowedCIDRs = bastion.AllowedCIDRs
+		if len(cfg.BastionAllowedCIDRs) == 0 {
+			cfg.BastionAllowedCIDRs = []string{"0.0.0.0/0"}
+		}
+		cfg.BastionSSHKey = bastion.SSHKey
+		if cfg.BastionSSHKey == "" {
+			cfg.BastionSSHKey = sshKey
+		}
+	}
+
 	return json.MarshalIndent(cfg, "", "  ")
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/Azure/go-autorest/autorest/to"
 
 	"github.com/openshift/installer/pkg/types"
+	azuretypes "github.com/openshift/installer/pkg/types/azure"
 	"github.com/openshift/installer/pkg/types/azure/defaults"
 	azureprovider "sigs.k8s.io/cluster-api-provider-azure/pkg/apis/azureprovider/v1beta1"
 )
@@ -23,12 +24,21 @@ type Auth struct {
 type config struct {
 	Auth                        `json:",inline"`
 	ExtraTags                   map[string]string `json:"azure_extra_tags,omitempty"`
+	APIPublicIPPrefixID         string            `json:"azure_api_public_ip_prefix_id,omitempty"`
 	BootstrapInstanceType       string            `json:"azure_bootstrap_vm_type,omitempty"`
 	MasterInstanceType          string            `json:"azure_master_vm_type,omitempty"`
 	MasterAvailabilityZones     []string          `json:"azure_master_availability_zones"`
 	VolumeType                  string            `json:"azure_master_root_volume_type"`
 	VolumeSize                  int32             `json:"azure_master_root_volume_size"`
 	ImageURL                    string            `json:"azure_image_url,omitempty"`
+	ImagePublisher              string            `json:"azure_image_publisher,omitempty"`
+	ImageOffer                  string            `json:"azure_image_offer,omitempty"`
+	ImageSKU                    string            `json:"azure_image_sku,omitempty"`
+	ImageVersion                string            `json:"azure_image_version,omitempty"`
+	ImagePlanName               string            `json:"azure_image_plan_name,omitempty"`
+	UseIdentity                 bool              `json:"azure_use_identity"`
+	UserAssignedIdentityID      string            `json:"azure_user_assigned_identity_id,omitempty"`
+	ResourceGroupName           string            `json:"azure_resource_group_name,omitempty"`
 	Region                      string            `json:"azure_region,omitempty"`
 	BaseDomainResourceGroupName string            `json:"azure_base_domain_resource_group_name,omitempty"`
 	NetworkResourceGroupName    string            `json:"azure_network_resource_group_name"`
@@ -49,8 +59,13 @@ type TFVarsSources struct {
 	MasterConfigs               []*azureprovider.AzureMachineProviderSpec
 	WorkerConfigs               []*azureprovider.AzureMachineProviderSpec
 	ImageURL                    string
+	ImagePlanName               string
+	CloudName                   string
+	UserAssignedIdentityID      string
+	ResourceGroupName           string
 	PreexistingNetwork          bool
 	Publish                     types.PublishingStrategy
+	APIPublicIPPrefixID         string
 
 	MachineV4CIDRs []net.IPNet
 	MachineV6CIDRs []net.IPNet
@@ -67,6 +82,10 @@ func TFVars(sources TFVarsSources) ([]byte, error) {
 	for i, c := range sources.MasterConfigs {
 		masterAvailabilityZones[i] = to.String(c.Zone)
 	}
+	if sources.CloudName == azuretypes.StackCloud {
+		// Azure Stack Hub has no availability zones.
+		masterAvailabilityZones = nil
+	}
 
 	machineV4CIDRStrings, machineV6CIDRStrings := []string{}, []string{}
 	for _, ipnet := range sources.MachineV4CIDRs {
@@ -90,6 +109,14 @@ func TFVars(sources TFVarsSources) ([]byte, error) {
 		VolumeType:                  masterConfig.OSDisk.ManagedDisk.StorageAccountType,
 		VolumeSize:                  masterConfig.OSDisk.DiskSizeGB,
 		ImageURL:                    sources.ImageURL,
+		ImagePublisher:              masterConfig.Image.Publisher,
+		ImageOffer:                  masterConfig.Image.Offer,
+		ImageSKU:                    masterConfig.Image.SKU,
+		ImageVersion:                masterConfig.Image.Version,
+		ImagePlanName:               sources.ImagePlanName,
+		UseIdentity:                 sources.CloudName != azuretypes.StackCloud,
+		UserAssignedIdentityID:      sources.UserAssignedIdentityID,
+		ResourceGroupName:           sources.ResourceGroupName,
 		Private:                     sources.Publish == types.InternalPublishingStrategy,
 		BaseDomainResourceGroupName: sources.BaseDomainResourceGroupName,
 		NetworkResourceGroupName:    masterConfig.NetworkResourceGroup,
@@ -100,6 +127,7 @@ func TFVars(sources TFVarsSources) ([]byte, error) {
 		MachineV4CIDRs:              machineV4CIDRStrings,
 		MachineV6CIDRs:              machineV6CIDRStrings,
 		EmulateSingleStackIPv6:      emulateSingleStackIPv6,
+		APIPublicIPPrefixID:         sources.APIPublicIPPrefixID,
 	}
 
 	return json.MarshalIndent(cfg, "", "  ")
@@ -6,6 +6,7 @@ import (
 	gcpprovider "github.com/openshift/cluster-api-provider-gcp/pkg/apis/gcpprovider/v1beta1"
 
 	"github.com/openshift/installer/pkg/types"
+	gcptypes "github.com/openshift/installer/pkg/types/gcp"
 )
 
 // Auth is the collection of credentials that will be used by terrform.
@@ -16,19 +17,24 @@ type Auth struct {
 
 type config struct {
 	Auth                    `json:",inline"`
-	Region                  string   `json:"gcp_region,omitempty"`
-	BootstrapInstanceType   string   `json:"gcp_bootstrap_instance_type,omitempty"`
-	MasterInstanceType      string   `json:"gcp_master_instance_type,omitempty"`
-	MasterAvailabilityZones []string `json:"gcp_master_availability_zones"`
-	ImageURI                string   `json:"gcp_image_uri,omitempty"`
-	VolumeType              string   `json:"gcp_master_root_volume_type"`
-	VolumeSize              int64    `json:"gcp_master_root_volume_size"`
-	PublicZoneName          string   `json:"gcp_public_dns_zone_name,omitempty"`
-	PublishStrategy         string   `json:"gcp_publish_strategy,omitempty"`
-	PreexistingNetwork      bool     `json:"gcp_preexisting_network,omitempty"`
-	ClusterNetwork          string   `json:"gcp_cluster_network,omitempty"`
-	ControlPlaneSubnet      string   `json:"gcp_control_plane_subnet,omitempty"`
-	ComputeSubnet           string   `json:"gcp_compute_subnet,omitempty"`
+	Region                  string            `json:"gcp_region,omitempty"`
+	BootstrapInstanceType   string            `json:"gcp_bootstrap_instance_type,omitempty"`
+	MasterInstanceType      string            `json:"gcp_master_instance_type,omitempty"`
+	MasterAvailabilityZones []string          `json:"gcp_master_availability_zones"`
+	ImageURI                string            `json:"gcp_image_uri,omitempty"`
+	VolumeType              string            `json:"gcp_master_root_volume_type"`
+	VolumeSize              int64             `json:"gcp_master_root_volume_size"`
+	PublicZoneName          string            `json:"gcp_public_dns_zone_name,omitempty"`
+	PublishStrategy         string            `json:"gcp_publish_strategy,omitempty"`
+	PreexistingNetwork      bool              `json:"gcp_preexisting_network,omitempty"`
+	MasterNodeGroup         string            `json:"gcp_master_node_group,omitempty"`
+	SecureBoot              bool              `json:"gcp_secure_boot"`
+	VTPM                    bool              `json:"gcp_vtpm"`
+	IntegrityMonitoring     bool              `json:"gcp_integrity_monitoring"`
+	ExtraLabels             map[string]string `json:"gcp_extra_labels,omitempty"`
+	ClusterNetwork          string            `json:"gcp_cluster_network,omitempty"`
+	ControlPlaneSubnet      string            `json:"gcp_control_plane_subnet,omitempty"`
+	ComputeSubnet           string            `json:"gcp_compute_subnet,omitempty"`
 }
 
 // TFVarsSources contains the parameters to be converted into Terraform variables
@@ -40,6 +46,9 @@ type TFVarsSources struct {
 	PublicZoneName     string
 	PublishStrategy    types.PublishingStrategy
 	PreexistingNetwork bool
+	MasterNodeGroup    string
+	ControlPlanePool   *gcptypes.MachinePool
+	UserLabels         map[string]string
 }
 
 // TFVars generates gcp-specific Terraform variables launching the cluster.
@@ -65,6 +74,11 @@ func TFVars(sources TFVarsSources) ([]byte, error) {
 		ControlPlaneSubnet:      masterConfig.NetworkInterfaces[0].Subnetwork,
 		ComputeSubnet:           workerConfig.NetworkInterfaces[0].Subnetwork,
 		PreexistingNetwork:      sources.PreexistingNetwork,
+		MasterNodeGroup:         sources.MasterNodeGroup,
+		SecureBoot:              sources.ControlPlanePool != nil && sources.ControlPlanePool.SecureBoot,
+		VTPM:                    sources.ControlPlanePool != nil && sources.ControlPlanePool.VirtualizedTrustedPlatformModule,
+		IntegrityMonitoring:     sources.ControlPlanePool != nil && sources.ControlPlanePool.IntegrityMonitoring,
+		ExtraLabels:             sources.UserLabels,
 	}
 
 	return json.MarshalIndent(cfg, "", "  ")
@@ -15,6 +15,8 @@ import (
 	"github.com/h2non/filetype/matchers"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/installer/pkg/inputtrack"
 	"github.com/ulikunitz/xz"
 
 	"golang.org/x/sys/unix"
@@ -232,5 +234,10 @@ func DownloadFile(baseURL string, dataType string) (string, error) {
 func DownloadImageFile(baseURL string) (string, error) {
 	logrus.Infof("Obtaining RHCOS image file from '%v'", baseURL)
 
-	return DownloadFile(baseURL, imageDataType)
+	filePath, err := DownloadFile(baseURL, imageDataType)
+	if err == nil {
+		// Record the cached artifact as an input of this run.
+		inputtrack.Cache(filePath)
+	}
+	return filePath, err
 }
@@ -61,6 +61,10 @@ func uploadBootstrapConfig(cloud string, bootstrapIgn string, clusterID string)
 // We can't do it directly in Terraform, because Ignition provider suppors only 2.1 version, but
 // Security section was added in 2.2 only.
 
+// novaUserDataLimit is the size Nova accepts for user data; clouds can
+// lower it, but 64 KiB is the ubiquitous ceiling.
+const novaUserDataLimit = 65535
+
 // generateIgnitionShim is used to generate an ignition file that contains a user ca bundle
 // in its Security section.
 func generateIgnitionShim(userCA string, clusterID string, bootstrapConfigURL string, tokenID string) (string, error) {
@@ -157,9 +161,11 @@ func generateIgnitionShim(userCA string, clusterID string, bootstrapConfigURL st
 
 	// Check the size of the base64-rendered ignition shim isn't to big for nova
 	// https://docs.openstack.org/nova/latest/user/metadata.html#user-data
-	if len(base64.StdEncoding.EncodeToString(data)) > 65535 {
-		return "", fmt.Errorf("rendered bootstrap ignition shim exceeds the 64KB limit for nova user data -- try reducing the size of your CA cert bundle")
+	encodedSize := len(base64.StdEncoding.EncodeToString(data))
+	if encodedSize > novaUserDataLimit {
+		return "", fmt.Errorf("rendered bootstrap ignition shim is %d bytes, exceeding the %d-byte Nova user-data limit; the full ignition is already hosted in Glance, so reduce the size of your CA cert bundle", encodedSize, novaUserDataLimit)
 	}
+	logrus.Infof("Bootstrap ignition is hosted in Glance; the Nova user data carries a %d-byte shim (limit %d)", encodedSize, novaUserDataLimit)
 
 	return string(data), nil
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/gophercloud/gophercloud/openstack/identity/v3/tokens"
 	"github.com/gophercloud/gophercloud/openstack/imageservice/v2/images"
 	"github.com/gophercloud/utils/openstack/clientconfig"
+	"github.com/openshift/installer/pkg/parallel"
 	"github.com/openshift/installer/pkg/rhcos"
 	"github.com/openshift/installer/pkg/tfvars/internal/cache"
 	"github.com/pkg/errors"
@@ -16,36 +17,43 @@ import (
 )
 
 type config struct {
-	BaseImageName   string   `json:"openstack_base_image_name,omitempty"`
-	ExternalNetwork string   `json:"openstack_external_network,omitempty"`
-	Cloud           string   `json:"openstack_credentials_cloud,omitempty"`
-	FlavorName      string   `json:"openstack_master_flavor_name,omitempty"`
-	LbFloatingIP    string   `json:"openstack_lb_floating_ip,omitempty"`
-	APIVIP          string   `json:"openstack_api_int_ip,omitempty"`
-	DNSVIP          string   `json:"openstack_node_dns_ip,omitempty"`
-	IngressVIP      string   `json:"openstack_ingress_ip,omitempty"`
-	TrunkSupport    string   `json:"openstack_trunk_support,omitempty"`
-	OctaviaSupport  string   `json:"openstack_octavia_support,omitempty"`
-	RootVolumeSize  int      `json:"openstack_master_root_volume_size,omitempty"`
-	RootVolumeType  string   `json:"openstack_master_root_volume_type,omitempty"`
-	BootstrapShim   string   `json:"openstack_bootstrap_shim_ignition,omitempty"`
-	ExternalDNS     []string `json:"openstack_external_dns,omitempty"`
+	BaseImageName      string   `json:"openstack_base_image_name,omitempty"`
+	ExternalNetwork    string   `json:"openstack_external_network,omitempty"`
+	Cloud              string   `json:"openstack_credentials_cloud,omitempty"`
+	FlavorName         string   `json:"openstack_master_flavor_name,omitempty"`
+	LbFloatingIP       string   `json:"openstack_lb_floating_ip,omitempty"`
+	APIVIP             string   `json:"openstack_api_int_ip,omitempty"`
+	DNSVIP             string   `json:"openstack_node_dns_ip,omitempty"`
+	IngressVIP         string   `json:"openstack_ingress_ip,omitempty"`
+	TrunkSupport       string   `json:"openstack_trunk_support,omitempty"`
+	OctaviaSupport     string   `json:"openstack_octavia_support,omitempty"`
+	RootVolumeSize     int      `json:"openstack_master_root_volume_size,omitempty"`
+	RootVolumeType     string   `json:"openstack_master_root_volume_type,omitempty"`
+	BootstrapShim      string   `json:"openstack_bootstrap_shim_ignition,omitempty"`
+	MastersConfigDrive bool     `json:"openstack_master_config_drive"`
+	BaseImageSHA256    string   `json:"openstack_base_image_sha256,omitempty"`
+	MachinesSubnet     string   `json:"openstack_machines_subnet,omitempty"`
+	MasterExtraSGIDs   []string `json:"openstack_master_extra_sg_ids,omitempty"`
+	ExternalDNS        []string `json:"openstack_external_dns,omitempty"`
 }
 
 // TFVars generates OpenStack-specific Terraform variables.
-func TFVars(masterConfig *v1alpha1.OpenstackProviderSpec, cloud string, externalNetwork string, externalDNS []string, lbFloatingIP string, apiVIP string, dnsVIP string, ingressVIP string, trunkSupport string, octaviaSupport string, baseImage string, infraID string, userCA string, bootstrapIgn string) ([]byte, error) {
+func TFVars(masterConfig *v1alpha1.OpenstackProviderSpec, cloud string, masterExtraSGIDs []string, externalNetwork string, externalDNS []string, lbFloatingIP string, apiVIP string, dnsVIP string, ingressVIP string, trunkSupport string, octaviaSupport string, baseImage string, infraID string, userCA string, bootstrapIgn string, mastersConfigDrive bool, machinesSubnet string) ([]byte, error) {
 
 	cfg := &config{
-		ExternalNetwork: externalNetwork,
-		Cloud:           cloud,
-		FlavorName:      masterConfig.Flavor,
-		LbFloatingIP:    lbFloatingIP,
-		APIVIP:          apiVIP,
-		DNSVIP:          dnsVIP,
-		IngressVIP:      ingressVIP,
-		ExternalDNS:     externalDNS,
-		TrunkSupport:    trunkSupport,
-		OctaviaSupport:  octaviaSupport,
+		MastersConfigDrive: mastersConfigDrive,
+		MachinesSubnet:     machinesSubnet,
+		MasterExtraSGIDs:   masterExtraSGIDs,
+		ExternalNetwork:    externalNetwork,
+		Cloud:              cloud,
+		FlavorName:         masterConfig.Flavor,
+		LbFloatingIP:       lbFloatingIP,
+		APIVIP:             apiVIP,
+		DNSVIP:             dnsVIP,
+		IngressVIP:         ingressVIP,
+		ExternalDNS:        externalDNS,
+		TrunkSupport:       trunkSupport,
+		OctaviaSupport:     octaviaSupport,
 	}
 
 	// Normally baseImage contains a URL that we will use to create a new Glance image, but for testing
@@ -56,36 +64,41 @@ func TFVars(masterConfig *v1alpha1.OpenstackProviderSpec, cloud string, external
 	imageName, isURL := rhcos.GenerateOpenStackImageName(baseImage, infraID)
 	cfg.BaseImageName = imageName
 	if isURL {
-		// Valid URL -> use baseImage as a URL that will be used to create new Glance image with name "<infraID>-rhcos".
-		localFilePath, err := cache.DownloadImageFile(baseImage)
-		if err != nil {
-			return nil, err
-		}
-
-		err = uploadBaseImage(cloud, localFilePath, imageName, infraID)
-		if err != nil {
-			return nil, err
-		}
-	} else {
-		// Not a URL -> use baseImage value as an overridden Glance image name.
-		// Need to check if this image exists and there are no other images with this name.
-		err := validateOverriddenImageName(imageName, cloud)
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	glancePublicURL, err := getGlancePublicURL(cloud)
-	if err != nil {
-		return nil, err
-	}
-
-	configLocation, err := uploadBootstrapConfig(cloud, bootstrapIgn, infraID)
-	if err != nil {
-		return nil, err
-	}
-
-	tokenID, err := getAuthToken(cloud)
+		cfg.BaseImageSHA256 = rhcos.SHA256FromURL(baseImage)
+	}
+
+	// The base image download/upload routinely takes tens of minutes and
+	// is independent of the bootstrap config upload and auth lookups, so
+	// the long-running steps overlap.
+	var glancePublicURL, configLocation, tokenID string
+	err := parallel.Run(
+		func() error {
+			if isURL {
+				// Valid URL -> use baseImage as a URL that will be used to create new Glance image with name "<infraID>-rhcos".
+				localFilePath, err := cache.DownloadImageFile(baseImage)
+				if err != nil {
+					return err
+				}
+				return uploadBaseImage(cloud, localFilePath, imageName, infraID)
+			}
+			// Not a URL -> use baseImage value as an overridden Glance image name.
+			// Need to check if this image exists and there are no other images with this name.
+			return validateOverriddenImageName(imageName, cloud)
+		},
+		func() error {
+			var err error
+			glancePublicURL, err = getGlancePublicURL(cloud)
+			if err != nil {
+				return err
+			}
+			configLocation, err = uploadBootstrapConfig(cloud, bootstrapIgn, infraID)
+			if err != nil {
+				return err
+			}
+			tokenID, err = getAuthToken(cloud)
+			return err
+		},
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -1,6 +1,7 @@
 package openstack
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"time"
@@ -12,6 +13,8 @@ import (
 	"github.com/gophercloud/utils/openstack/clientconfig"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/installer/pkg/parallel"
 )
 
 // uploadBaseImage creates a new image in Glance and uploads the RHCOS image there
@@ -70,25 +73,30 @@ func uploadBaseImage(cloud string, localFilePath string, imageName string, clust
 		}
 		logrus.Debugf("Image import started.")
 
-		// Image import is an asynchronous operation, so we have to wait until the image becomes "active"
-		const numRetries = 5000
-		const timeSleepSeconds = 15
-		for i := 0; i < numRetries; i++ {
+		// Image import is an asynchronous operation; poll until the
+		// image becomes "active", aborting once glance stops making
+		// progress instead of looping for hours against a wedged
+		// import.
+		err = parallel.WaitForProgress(context.Background(), logrus.StandardLogger(), 15*time.Second, 30*time.Minute, func() (int64, int64, bool, error) {
 			getRes, err := images.Get(conn, img.ID).Extract()
 			if err != nil {
-				return err
+				return 0, 0, false, err
 			}
 
 			// More information about Glance Image Status transitioning
 			// https://docs.openstack.org/glance/latest/user/statuses.html
-			if getRes.Status == images.ImageStatusActive {
-				// Import succeed
-				break
-			} else if getRes.Status == images.ImageStatusQueued || getRes.Status == images.ImageStatusDeleted {
-				// Import failed
-				return errors.New("RHCOS image import failed")
+			switch getRes.Status {
+			case images.ImageStatusActive:
+				// Import succeeded.
+				return getRes.SizeBytes, getRes.SizeBytes, true, nil
+			case images.ImageStatusQueued, images.ImageStatusDeleted:
+				// Import failed.
+				return 0, 0, false, errors.New("RHCOS image import failed")
 			}
-			time.Sleep(timeSleepSeconds * time.Second)
+			return getRes.SizeBytes, 0, false, nil
+		})
+		if err != nil {
+			return err
 		}
 
 		logrus.Debugf("Image import finished.")
@@ -18,6 +18,7 @@ type config struct {
 	NetworkName            string `json:"ovirt_network_name,omitempty"`
 	BaseImageName          string `json:"openstack_base_image_name,omitempty"`
 	BaseImageLocalFilePath string `json:"openstack_base_image_local_file_path,omitempty"`
+	BaseImageSHA256        string `json:"ovirt_base_image_sha256,omitempty"`
 }
 
 // TFVars generates ovirt-specific Terraform variables.
@@ -46,6 +47,10 @@ func TFVars(
 	imageName, isURL := rhcos.GenerateOpenStackImageName(baseImage, infraID)
 	cfg.BaseImageName = imageName
 	if isURL {
+		// The download verifies the sha256 carried on the image URL
+		// while streaming; the digest is also exposed to terraform so
+		// terraform-side transfers can verify it again.
+		cfg.BaseImageSHA256 = rhcos.SHA256FromURL(baseImage)
 		imageFilePath, err := cache.DownloadImageFile(baseImage)
 		if err != nil {
 			return nil, err
@@ -4,6 +4,7 @@ package tfvars
 import (
 	"encoding/json"
 	"net"
+	"strconv"
 	"strings"
 )
 
@@ -15,6 +16,8 @@ type config struct {
 	// entry from there instead.
 	DeprecatedMachineCIDR string `json:"machine_cidr"`
 	Masters               int    `json:"master_count,omitempty"`
+	McsPort               string `json:"mcs_port,omitempty"`
+	APIExternalPort       string `json:"api_external_port,omitempty"`
 
 	UseIPv4 bool `json:"use_ipv4"`
 	UseIPv6 bool `json:"use_ipv6"`
@@ -24,7 +27,7 @@ type config struct {
 }
 
 // TFVars generates terraform.tfvar JSON for launching the cluster.
-func TFVars(clusterID string, clusterDomain string, baseDomain string, deprecatedMachineCIDR *net.IPNet, useIPv4, useIPv6 bool, bootstrapIgn string, masterIgn string, masterCount int) ([]byte, error) {
+func TFVars(clusterID string, clusterDomain string, baseDomain string, deprecatedMachineCIDR *net.IPNet, useIPv4, useIPv6 bool, bootstrapIgn string, masterIgn string, masterCount int, mcsPort int32, apiExternalPort int32) ([]byte, error) {
 	config := &config{
 		ClusterID:             clusterID,
 		ClusterDomain:         strings.TrimSuffix(clusterDomain, "."),
@@ -33,6 +36,8 @@ func TFVars(clusterID string, clusterDomain string, baseDomain string, deprecate
 		UseIPv4:               useIPv4,
 		UseIPv6:               useIPv6,
 		Masters:               masterCount,
+		McsPort:               strconv.Itoa(int(mcsPort)),
+		APIExternalPort:       strconv.Itoa(int(apiExternalPort)),
 		IgnitionBootstrap:     bootstrapIgn,
 		IgnitionMaster:        masterIgn,
 	}
@@ -7,19 +7,21 @@ import (
 )
 
 type config struct {
-	VSphereURL        string `json:"vsphere_url"`
-	VSphereUsername   string `json:"vsphere_username"`
-	VSpherePassword   string `json:"vsphere_password"`
-	MemoryMiB         int64  `json:"vsphere_control_plane_memory_mib"`
-	DiskGiB           int32  `json:"vsphere_control_plane_disk_gib"`
-	NumCPUs           int32  `json:"vsphere_control_plane_num_cpus"`
-	NumCoresPerSocket int32  `json:"vsphere_control_plane_cores_per_socket"`
-	Cluster           string `json:"vsphere_cluster"`
-	Datacenter        string `json:"vsphere_datacenter"`
-	Datastore         string `json:"vsphere_datastore"`
-	Folder            string `json:"vsphere_folder"`
-	Network           string `json:"vsphere_network"`
-	Template          string `json:"vsphere_template"`
+	VSphereURL         string   `json:"vsphere_url"`
+	VSphereUsername    string   `json:"vsphere_username"`
+	VSpherePassword    string   `json:"vsphere_password"`
+	MemoryMiB          int64    `json:"vsphere_control_plane_memory_mib"`
+	DiskGiB            int32    `json:"vsphere_control_plane_disk_gib"`
+	NumCPUs            int32    `json:"vsphere_control_plane_num_cpus"`
+	NumCoresPerSocket  int32    `json:"vsphere_control_plane_cores_per_socket"`
+	Cluster            string   `json:"vsphere_cluster"`
+	Datacenter         string   `json:"vsphere_datacenter"`
+	Datastore          string   `json:"vsphere_datastore"`
+	Folder             string   `json:"vsphere_folder"`
+	ResourcePool       string   `json:"vsphere_resource_pool"`
+	AdditionalNetworks []string `json:"vsphere_additional_networks"`
+	Network            string   `json:"vsphere_network"`
+	Template           string   `json:"vsphere_template"`
 }
 
 // TFVarsSources contains the parameters to be converted into Terraform variables
@@ -30,25 +32,37 @@ type TFVarsSources struct {
 	Cluster             string
 }
 
-//TFVars generate vSphere-specific Terraform variables
+// TFVars generate vSphere-specific Terraform variables
 func TFVars(sources TFVarsSources) ([]byte, error) {
 	controlPlaneConfig := sources.ControlPlaneConfigs[0]
 
 	cfg := &config{
-		VSphereURL:        controlPlaneConfig.Workspace.Server,
-		VSphereUsername:   sources.Username,
-		VSpherePassword:   sources.Password,
-		MemoryMiB:         controlPlaneConfig.MemoryMiB,
-		DiskGiB:           controlPlaneConfig.DiskGiB,
-		NumCPUs:           controlPlaneConfig.NumCPUs,
-		NumCoresPerSocket: controlPlaneConfig.NumCoresPerSocket,
-		Cluster:           sources.Cluster,
-		Datacenter:        controlPlaneConfig.Workspace.Datacenter,
-		Datastore:         controlPlaneConfig.Workspace.Datastore,
-		Folder:            controlPlaneConfig.Workspace.Folder,
-		Network:           controlPlaneConfig.Network.Devices[0].NetworkName,
-		Template:          controlPlaneConfig.Template,
+		VSphereURL:         controlPlaneConfig.Workspace.Server,
+		VSphereUsername:    sources.Username,
+		VSpherePassword:    sources.Password,
+		MemoryMiB:          controlPlaneConfig.MemoryMiB,
+		DiskGiB:            controlPlaneConfig.DiskGiB,
+		NumCPUs:            controlPlaneConfig.NumCPUs,
+		NumCoresPerSocket:  controlPlaneConfig.NumCoresPerSocket,
+		Cluster:            sources.Cluster,
+		Datacenter:         controlPlaneConfig.Workspace.Datacenter,
+		Datastore:          controlPlaneConfig.Workspace.Datastore,
+		Folder:             controlPlaneConfig.Workspace.Folder,
+		ResourcePool:       controlPlaneConfig.Workspace.ResourcePool,
+		Network:            controlPlaneConfig.Network.Devices[0].NetworkName,
+		AdditionalNetworks: additionalNetworkNames(controlPlaneConfig),
+		Template:           controlPlaneConfig.Template,
 	}
 
 	return json.MarshalIndent(cfg, "", "  ")
 }
+
+// additionalNetworkNames returns the portgroups of every NIC after the
+// primary one.
+func additionalNetworkNames(config *vsphereapis.VSphereMachineProviderSpec) []string {
+	names := []string{}
+	for _, device := range config.Network.Devices[1:] {
+		names = append(names, device.NetworkName)
+	}
+	return names
+}
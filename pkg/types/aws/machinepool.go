@@ -6,12 +6,68 @@ type MachinePool struct {
 	// Zones is list of availability zones that can be used.
 	Zones []string `json:"zones,omitempty"`
 
+	// Networks is a list of additional CIDR ranges (e.g. Local Zone or
+	// Wavelength subnets) the pool's machines live in, outside the
+	// cluster-wide machine network. Node-to-node security group rules
+	// include these ranges.
+	// +optional
+	Networks []string `json:"networks,omitempty"`
+
+	// Subnets is a list of existing subnet IDs the pool's machines are
+	// pinned to, overriding the cluster-wide subnet selection (e.g. for
+	// a DMZ worker pool). The subnets must belong to the cluster's VPC
+	// and cover the pool's zones. Leave unset to use the default
+	// placement.
+	// +optional
+	Subnets []string `json:"subnets,omitempty"`
+
 	// InstanceType defines the ec2 instance type.
 	// eg. m4-large
 	InstanceType string `json:"type"`
 
 	// EC2RootVolume defines the root volume for EC2 instances in the machine pool.
 	EC2RootVolume `json:"rootVolume"`
+
+	// MetadataService tunes the instance metadata service.
+	// +optional
+	MetadataService *MetadataService `json:"metadataService,omitempty"`
+
+	// Placement places the pool's instances into a placement group, for
+	// latency-sensitive workloads.
+	// +optional
+	Placement *Placement `json:"placement,omitempty"`
+}
+
+// MetadataService tunes the EC2 instance metadata service. It is only
+// honored on the control plane pool: the bundled machine provider has
+// no field to carry it for compute machinesets yet.
+type MetadataService struct {
+	// HopLimit is the IMDS PUT response hop limit; hostNetwork pods
+	// need 2 to reach IMDS through the node. Defaults to the AWS
+	// default of 1.
+	// +optional
+	HopLimit int64 `json:"hopLimit,omitempty"`
+
+	// InstanceMetadataTags exposes the instance's tags through the
+	// metadata service: "enabled" or "disabled" (default). Not
+	// supported yet - neither the bundled terraform provider nor the
+	// bundled AWS SDK can express it - and rejected by validation so
+	// the intent is not silently dropped.
+	// +optional
+	InstanceMetadataTags string `json:"instanceMetadataTags,omitempty"`
+}
+
+// Placement describes an EC2 placement group.
+type Placement struct {
+	// GroupName names an existing placement group to use. When empty
+	// and a strategy is set, the installer creates the group.
+	// +optional
+	GroupName string `json:"groupName,omitempty"`
+
+	// Strategy is the placement strategy of a created group: "cluster",
+	// "partition" or "spread".
+	// +optional
+	Strategy string `json:"strategy,omitempty"`
 }
 
 // Set sets the values from `required` to `a`.
@@ -24,10 +80,26 @@ func (a *MachinePool) Set(required *MachinePool) {
 		a.Zones = required.Zones
 	}
 
+	if len(required.Networks) > 0 {
+		a.Networks = required.Networks
+	}
+
+	if len(required.Subnets) > 0 {
+		a.Subnets = required.Subnets
+	}
+
 	if required.InstanceType != "" {
 		a.InstanceType = required.InstanceType
 	}
 
+	if required.MetadataService != nil {
+		a.MetadataService = required.MetadataService
+	}
+
+	if required.Placement != nil {
+		a.Placement = required.Placement
+	}
+
 	if required.EC2RootVolume.IOPS != 0 {
 		a.EC2RootVolume.IOPS = required.EC2RootVolume.IOPS
 	}
@@ -9,4 +9,14 @@ type Metadata struct {
 	// resource matches the map if all of the key/value pairs are in its
 	// tags.  A resource matches Identifier if it matches any of the maps.
 	Identifier []map[string]string `json:"identifier"`
+
+	// UserProvidedNetwork records that the cluster was installed into a
+	// pre-existing VPC, so destroy must never touch shared network
+	// infrastructure.
+	UserProvidedNetwork bool `json:"userProvidedNetwork,omitempty"`
+
+	// BastionIP is the public address of the installer-provisioned
+	// bastion host, recorded after the apply so gather and support
+	// flows work without the terraform state.
+	BastionIP string `json:"bastionIP,omitempty"`
 }
@@ -26,4 +26,42 @@ type Platform struct {
 	// platform configuration.
 	// +optional
 	DefaultMachinePlatform *MachinePool `json:"defaultMachinePlatform,omitempty"`
+
+	// UserProvisionedDNS, when "Enabled", makes the installer skip
+	// creating the private hosted zone and the internal api-int/etcd
+	// records; a post-install report lists the records a user-managed
+	// resolver must serve instead.
+	// +optional
+	UserProvisionedDNS string `json:"userProvisionedDNS,omitempty"`
+
+	// IAMPermissionsBoundary is the ARN of the policy attached as the
+	// permissions boundary to every IAM role the installer creates.
+	// +optional
+	IAMPermissionsBoundary string `json:"iamPermissionsBoundary,omitempty"`
+
+	// IAMTags are additional tags applied to the IAM roles and
+	// instance profiles the installer creates, on top of the standard
+	// cluster tags.
+	// +optional
+	IAMTags map[string]string `json:"iamTags,omitempty"`
+
+	// BastionHost is the address of an SSH bastion that can reach the
+	// cluster machines, used by gather when the cluster has no
+	// externally reachable addresses.
+	// +optional
+	BastionHost string `json:"bastionHost,omitempty"`
+
+	// AMIEncryption configures copying the RHCOS AMI into the account
+	// with EBS encryption before any machines boot from it.
+	// +optional
+	AMIEncryption *AMIEncryption `json:"amiEncryption,omitempty"`
+}
+
+// AMIEncryption holds the settings for the encrypted copy of the RHCOS
+// AMI that machines boot from.
+type AMIEncryption struct {
+	// KMSKeyID is the ID, ARN or alias of the customer-managed KMS key
+	// used to encrypt the EBS snapshots of the copied AMI. The key
+	// policy must allow the account's EC2 service to use the key.
+	KMSKeyID string `json:"kmsKeyID"`
 }
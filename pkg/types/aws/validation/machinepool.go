@@ -2,13 +2,18 @@ package validation
 
 import (
 	"fmt"
+	"net"
 	"strings"
 
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	"github.com/openshift/installer/pkg/types/aws"
+	"github.com/openshift/installer/pkg/validate"
 )
 
+// maxEBSVolumeSizeGB is the largest root volume EBS supports, in GiB.
+const maxEBSVolumeSizeGB = 16384
+
 // ValidateMachinePool checks that the specified machine pool is valid.
 func ValidateMachinePool(platform *aws.Platform, p *aws.MachinePool, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
@@ -18,11 +23,51 @@ func ValidateMachinePool(platform *aws.Platform, p *aws.MachinePool, fldPath *fi
 		}
 	}
 
+	for i, network := range p.Networks {
+		if _, _, err := net.ParseCIDR(network); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("networks").Index(i), network, "must be a valid CIDR"))
+		}
+	}
+
 	if p.IOPS < 0 {
 		allErrs = append(allErrs, field.Invalid(fldPath.Child("iops"), p.IOPS, "Storage IOPS must be positive"))
 	}
-	if p.Size < 0 {
-		allErrs = append(allErrs, field.Invalid(fldPath.Child("size"), p.Size, "Storage size must be positive"))
+	if p.MetadataService != nil {
+		metadataPath := fldPath.Child("metadataService")
+		if p.MetadataService.HopLimit != 0 && (p.MetadataService.HopLimit < 1 || p.MetadataService.HopLimit > 64) {
+			allErrs = append(allErrs, field.Invalid(metadataPath.Child("hopLimit"), p.MetadataService.HopLimit, "the hop limit must be between 1 and 64"))
+		}
+		if p.MetadataService.InstanceMetadataTags != "" {
+			// Neither the bundled terraform provider nor the bundled
+			// AWS SDK can set instance metadata tags; reject instead
+			// of silently dropping the intent.
+			allErrs = append(allErrs, field.Invalid(metadataPath.Child("instanceMetadataTags"), p.MetadataService.InstanceMetadataTags, "instance metadata tags are not supported by the bundled tooling yet"))
+		}
+	}
+	if p.Placement != nil {
+		placementPath := fldPath.Child("placement")
+		switch p.Placement.Strategy {
+		case "", "cluster", "partition", "spread":
+		default:
+			allErrs = append(allErrs, field.NotSupported(placementPath.Child("strategy"), p.Placement.Strategy, []string{"cluster", "partition", "spread"}))
+		}
+		if p.Placement.GroupName == "" && p.Placement.Strategy == "" {
+			allErrs = append(allErrs, field.Required(placementPath.Child("strategy"), "a strategy is required when no existing group is named"))
+		}
+		if p.Placement.Strategy == "cluster" {
+			// Burstable families cannot join cluster placement groups.
+			for _, prefix := range []string{"t2.", "t3.", "t3a."} {
+				if strings.HasPrefix(p.InstanceType, prefix) {
+					allErrs = append(allErrs, field.Invalid(placementPath.Child("strategy"), p.Placement.Strategy,
+						fmt.Sprintf("instance family of %s does not support the cluster placement strategy", p.InstanceType)))
+				}
+			}
+		}
+	}
+	if p.Size != 0 {
+		if err := validate.DiskSizeGB(int64(p.Size), maxEBSVolumeSizeGB); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("size"), p.Size, err.Error()))
+		}
 	}
 	return allErrs
 }
@@ -54,7 +54,7 @@ func TestValidateMachinePool(t *testing.T) {
 			name: "valid size",
 			pool: &aws.MachinePool{
 				EC2RootVolume: aws.EC2RootVolume{
-					Size: 10,
+					Size: 120,
 				},
 			},
 		},
@@ -65,7 +65,25 @@ func TestValidateMachinePool(t *testing.T) {
 					Size: -10,
 				},
 			},
-			expected: `^test-path\.size: Invalid value: -10: Storage size must be positive$`,
+			expected: `^test-path\.size: Invalid value: -10: disk size must be a positive integer$`,
+		},
+		{
+			name: "size below RHCOS minimum",
+			pool: &aws.MachinePool{
+				EC2RootVolume: aws.EC2RootVolume{
+					Size: 10,
+				},
+			},
+			expected: `^test-path\.size: Invalid value: 10: disk size 10 GB is below the 16 GB minimum required by RHCOS$`,
+		},
+		{
+			name: "size above EBS maximum",
+			pool: &aws.MachinePool{
+				EC2RootVolume: aws.EC2RootVolume{
+					Size: 20000,
+				},
+			},
+			expected: `^test-path\.size: Invalid value: 20000: disk size 20000 GB exceeds the platform maximum of 16384 GB$`,
 		},
 	}
 	for _, tc := range cases {
@@ -2,7 +2,9 @@ package validation
 
 import (
 	"sort"
+	"strings"
 
+	"github.com/sirupsen/logrus"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	"github.com/openshift/installer/pkg/types/aws"
@@ -21,17 +23,17 @@ var (
 		"ap-southeast-1": "Singapore",
 		"ap-southeast-2": "Sydney",
 		"ca-central-1":   "Central",
-		//"cn-north-1":     "Beijing",
-		//"cn-northwest-1": "Ningxia",
-		"eu-central-1": "Frankfurt",
-		"eu-north-1":   "Stockholm",
-		"eu-west-1":    "Ireland",
-		"eu-west-2":    "London",
-		"eu-west-3":    "Paris",
-		"me-south-1":   "Bahrain",
-		"sa-east-1":    "São Paulo",
-		"us-east-1":    "N. Virginia",
-		"us-east-2":    "Ohio",
+		"cn-north-1":     "Beijing",
+		"cn-northwest-1": "Ningxia",
+		"eu-central-1":   "Frankfurt",
+		"eu-north-1":     "Stockholm",
+		"eu-west-1":      "Ireland",
+		"eu-west-2":      "London",
+		"eu-west-3":      "Paris",
+		"me-south-1":     "Bahrain",
+		"sa-east-1":      "São Paulo",
+		"us-east-1":      "N. Virginia",
+		"us-east-2":      "Ohio",
 		//"us-gov-east-1":  "AWS GovCloud (US-East)",
 		//"us-gov-west-1":  "AWS GovCloud (US-West)",
 		"us-west-1": "N. California",
@@ -59,5 +61,21 @@ func ValidatePlatform(p *aws.Platform, fldPath *field.Path) field.ErrorList {
 	if p.DefaultMachinePlatform != nil {
 		allErrs = append(allErrs, ValidateMachinePool(p, p.DefaultMachinePlatform, fldPath.Child("defaultMachinePlatform"))...)
 	}
+	switch p.UserProvisionedDNS {
+	case "", "Disabled":
+	case "Enabled":
+		logrus.Warn("userProvisionedDNS is Enabled: the installer will not create the private hosted zone; api-int and the etcd records must resolve through your resolver before bootstrap (see dns-records-needed.json after ignition generation)")
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("userProvisionedDNS"), p.UserProvisionedDNS, []string{"Enabled", "Disabled"}))
+	}
+	if strings.HasPrefix(p.Region, "cn-") {
+		if p.AMIID == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("amiID"), "the aws-cn partition has no published RHCOS AMIs; provide the AMI copied into your account"))
+		}
+		logrus.Warn("Installing into the aws-cn partition: the base domain's hosted zone must already exist in the China account, and all ARNs use the aws-cn partition")
+	}
+	if p.AMIEncryption != nil && p.AMIEncryption.KMSKeyID == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("amiEncryption", "kmsKeyID"), "must specify the KMS key used to encrypt the copied AMI"))
+	}
 	return allErrs
 }
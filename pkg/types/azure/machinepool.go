@@ -11,8 +11,37 @@ type MachinePool struct {
 	// eg. Standard_DS_V2
 	InstanceType string `json:"type"`
 
+	// Subnet is the name of an existing subnet in the cluster's virtual
+	// network the pool's machines are pinned to, overriding the default
+	// compute subnet. Requires a pre-existing virtual network. Leave
+	// unset to use the default placement.
+	// +optional
+	Subnet string `json:"subnet,omitempty"`
+
 	// OSDisk defines the storage for instance.
 	OSDisk `json:"osDisk"`
+
+	// OSImage defines a marketplace image to boot from instead of the
+	// uploaded RHCOS VHD, for subscriptions that forbid creating
+	// images. The image must be RHCOS-compatible.
+	// +optional
+	OSImage *OSImage `json:"osImage,omitempty"`
+}
+
+// OSImage is an Azure marketplace image reference.
+type OSImage struct {
+	// Publisher is the image publisher.
+	Publisher string `json:"publisher"`
+	// Offer is the image offer.
+	Offer string `json:"offer"`
+	// SKU is the image SKU.
+	SKU string `json:"sku"`
+	// Version is the image version.
+	Version string `json:"version"`
+	// Plan is the purchase plan name for third-party images whose
+	// marketplace terms must be accepted before they can be used.
+	// +optional
+	Plan string `json:"plan,omitempty"`
 }
 
 // OSDisk defines the disk for machines on Azure.
@@ -35,7 +64,15 @@ func (a *MachinePool) Set(required *MachinePool) {
 		a.InstanceType = required.InstanceType
 	}
 
+	if required.Subnet != "" {
+		a.Subnet = required.Subnet
+	}
+
 	if required.OSDisk.DiskSizeGB != 0 {
 		a.OSDisk.DiskSizeGB = required.OSDisk.DiskSizeGB
 	}
+
+	if required.OSImage != nil {
+		a.OSImage = required.OSImage
+	}
 }
@@ -3,4 +3,8 @@ package azure
 // Metadata contains Azure metadata (e.g. for uninstalling the cluster).
 type Metadata struct {
 	Region string `json:"region"`
+
+	// ResourceGroupName is set when the cluster was installed into a
+	// user-owned resource group, which destroy must not delete.
+	ResourceGroupName string `json:"resourceGroupName,omitempty"`
 }
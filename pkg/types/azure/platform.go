@@ -2,6 +2,19 @@ package azure
 
 import "strings"
 
+const (
+	// PublicCloud is the default Azure cloud environment.
+	PublicCloud = "AzurePublicCloud"
+	// StackCloud is the Azure Stack Hub cloud environment.
+	StackCloud = "AzureStackCloud"
+)
+
+// IsAzureStack returns whether the cluster targets an Azure Stack Hub
+// instance.
+func (p *Platform) IsAzureStack() bool {
+	return p.CloudName == StackCloud
+}
+
 // Platform stores all the global configuration that all machinesets
 // use.
 type Platform struct {
@@ -28,6 +41,42 @@ type Platform struct {
 
 	// ComputeSubnet specifies an existing subnet for use by compute nodes
 	ComputeSubnet string `json:"computeSubnet,omitempty"`
+
+	// BastionHost is the address of an SSH bastion that can reach the
+	// cluster machines, used by gather when the cluster has no
+	// externally reachable addresses.
+	// +optional
+	BastionHost string `json:"bastionHost,omitempty"`
+
+	// CloudName is the Azure cloud environment the cluster is deployed
+	// to, e.g. AzurePublicCloud (the default) or AzureStackCloud.
+	// +optional
+	CloudName string `json:"cloudName,omitempty"`
+
+	// ARMEndpoint is the Azure Resource Manager endpoint of the Azure
+	// Stack Hub instance. Required when cloudName is AzureStackCloud.
+	// +optional
+	ARMEndpoint string `json:"armEndpoint,omitempty"`
+
+	// ResourceGroupName names a pre-created resource group the cluster
+	// is installed into instead of the <infraID>-rg group the
+	// installer would create. The group must be in the install region
+	// and destroy deletes only the cluster's resources inside it, not
+	// the group itself.
+	// +optional
+	ResourceGroupName string `json:"resourceGroupName,omitempty"`
+
+	// Identity configures the managed identity assigned to the cluster
+	// VMs instead of the one the installer would create.
+	// +optional
+	Identity *Identity `json:"identity,omitempty"`
+
+	// APIPublicIPPrefixID specifies the resource ID of an existing public
+	// IP prefix from which the public IP of the API load balancer should
+	// be allocated. The prefix itself is never created or deleted by the
+	// installer.
+	// +optional
+	APIPublicIPPrefixID string `json:"apiPublicIPPrefixID,omitempty"`
 }
 
 //SetBaseDomain parses the baseDomainID and sets the related fields on azure.Platform
@@ -36,3 +85,12 @@ func (p *Platform) SetBaseDomain(baseDomainID string) error {
 	p.BaseDomainResourceGroupName = parts[4]
 	return nil
 }
+
+// Identity references an existing user-assigned managed identity.
+type Identity struct {
+	// UserAssignedIdentityID is the resource ID of an existing
+	// user-assigned managed identity assigned to the cluster VMs. The
+	// identity must have at least Contributor on the cluster resource
+	// group; the installer neither creates nor deletes it.
+	UserAssignedIdentityID string `json:"userAssignedIdentityID"`
+}
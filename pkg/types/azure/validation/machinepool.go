@@ -1,16 +1,31 @@
 package validation
 
 import (
+	"strings"
+
 	"github.com/openshift/installer/pkg/types/azure"
+	"github.com/openshift/installer/pkg/validate"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
+// maxAzureDiskSizeGB is the largest OS managed disk Azure supports, in GB.
+const maxAzureDiskSizeGB = 32767
+
 // ValidateMachinePool checks that the specified machine pool is valid.
 func ValidateMachinePool(p *azure.MachinePool, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
-	if p.OSDisk.DiskSizeGB < 0 {
-		allErrs = append(allErrs, field.Invalid(fldPath.Child("diskSizeGB"), p.OSDisk.DiskSizeGB, "Storage DiskSizeGB must be positive"))
+	if strings.Contains(strings.ToLower(p.InstanceType), "ps_v") || strings.Contains(strings.ToLower(p.InstanceType), "pds_v") || strings.Contains(strings.ToLower(p.InstanceType), "pls_v") {
+		// The *ps/pds/pls_v* series are Azure's arm64 families; this
+		// installer only publishes an amd64 boot image, so the
+		// per-pool image selection cannot satisfy the pool.
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("type"), p.InstanceType, "this instance series is arm64; no arm64 RHCOS image is available for this installer, use an amd64 instance type"))
+	}
+
+	if p.OSDisk.DiskSizeGB != 0 {
+		if err := validate.DiskSizeGB(int64(p.OSDisk.DiskSizeGB), maxAzureDiskSizeGB); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("diskSizeGB"), p.OSDisk.DiskSizeGB, err.Error()))
+		}
 	}
 	return allErrs
 }
@@ -33,7 +33,7 @@ func TestValidateMachinePool(t *testing.T) {
 					DiskSizeGB: -120,
 				},
 			},
-			expected: `^test-path\.diskSizeGB: Invalid value: -120: Storage DiskSizeGB must be positive$`,
+			expected: `^test-path\.diskSizeGB: Invalid value: -120: disk size must be a positive integer$`,
 		},
 	}
 	for _, tc := range cases {
@@ -47,3 +47,11 @@ func TestValidateMachinePool(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateMachinePoolArchitectureConsistency(t *testing.T) {
+	err := ValidateMachinePool(&azure.MachinePool{InstanceType: "Standard_D4ps_v5"}, field.NewPath("test-path")).ToAggregate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "arm64")
+
+	assert.NoError(t, ValidateMachinePool(&azure.MachinePool{InstanceType: "Standard_D4s_v3"}, field.NewPath("test-path")).ToAggregate())
+}
@@ -1,6 +1,9 @@
 package validation
 
 import (
+	"strings"
+
+	"github.com/sirupsen/logrus"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	"github.com/openshift/installer/pkg/types"
@@ -18,6 +21,28 @@ func ValidatePlatform(p *azure.Platform, publish types.PublishingStrategy, fldPa
 			allErrs = append(allErrs, field.Required(fldPath.Child("baseDomainResourceGroupName"), "baseDomainResourceGroupName is the resource group name where the azure dns zone is deployed"))
 		}
 	}
+	switch p.CloudName {
+	case "", azure.PublicCloud:
+	case azure.StackCloud:
+		if p.ARMEndpoint == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("armEndpoint"), "armEndpoint is required when cloudName is AzureStackCloud"))
+		}
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("cloudName"), p.CloudName, []string{azure.PublicCloud, azure.StackCloud}))
+	}
+	if p.Identity != nil {
+		identityPath := fldPath.Child("identity", "userAssignedIdentityID")
+		if p.Identity.UserAssignedIdentityID == "" {
+			allErrs = append(allErrs, field.Required(identityPath, "the resource ID of the user-assigned identity is required"))
+		} else if !strings.Contains(strings.ToLower(p.Identity.UserAssignedIdentityID), "/userassignedidentities/") {
+			allErrs = append(allErrs, field.Invalid(identityPath, p.Identity.UserAssignedIdentityID, "must be a user-assigned identity resource ID"))
+		} else {
+			logrus.Warn("The user-assigned identity must have at least Contributor on the cluster resource group; the installer does not verify or manage its role assignments")
+		}
+	}
+	if p.APIPublicIPPrefixID != "" && publish == types.InternalPublishingStrategy {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("apiPublicIPPrefixID"), p.APIPublicIPPrefixID, "cannot use a public IP prefix when publish is set to Internal"))
+	}
 	if p.DefaultMachinePlatform != nil {
 		allErrs = append(allErrs, ValidateMachinePool(p.DefaultMachinePlatform, fldPath.Child("defaultMachinePlatform"))...)
 	}
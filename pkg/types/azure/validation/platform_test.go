@@ -78,6 +78,15 @@ func TestValidatePlatform(t *testing.T) {
 			},
 			valid: false,
 		},
+		{
+			name: "valid public IP prefix",
+			platform: &azure.Platform{
+				Region:                      "eastus",
+				BaseDomainResourceGroupName: "group",
+				APIPublicIPPrefixID:         "/subscriptions/sub/resourceGroups/group/providers/Microsoft.Network/publicIPPrefixes/prefix",
+			},
+			valid: true,
+		},
 		{
 			name: "missing network resource group",
 			platform: &azure.Platform{
@@ -100,3 +109,13 @@ func TestValidatePlatform(t *testing.T) {
 		})
 	}
 }
+
+func TestValidatePlatformInternalPublishing(t *testing.T) {
+	platform := &azure.Platform{
+		Region:              "eastus",
+		APIPublicIPPrefixID: "/subscriptions/sub/resourceGroups/group/providers/Microsoft.Network/publicIPPrefixes/prefix",
+	}
+	err := ValidatePlatform(platform, types.InternalPublishingStrategy, field.NewPath("test-path")).ToAggregate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "apiPublicIPPrefixID")
+}
@@ -79,6 +79,12 @@ type Platform struct {
 	// +optional
 	DefaultMachinePlatform *MachinePool `json:"defaultMachinePlatform,omitempty"`
 
+	// DNSForwarders are the upstream resolvers the node-hosted CoreDNS
+	// forwards to instead of the DHCP-provided resolv.conf entries.
+	// CoreDNS accepts at most 3 forwarders.
+	// +optional
+	DNSForwarders []string `json:"dnsForwarders,omitempty"`
+
 	// APIVIP is the VIP to use for internal API communication
 	APIVIP string `json:"apiVIP"`
 
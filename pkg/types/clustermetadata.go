@@ -21,6 +21,15 @@ type ClusterMetadata struct {
 	// infraID is an ID that is used to identify cloud resources created by the installer.
 	InfraID                 string `json:"infraID"`
 	ClusterPlatformMetadata `json:",inline"`
+	// Provenance records the exact release image, RHCOS artifacts and
+	// installer binary used to create the cluster.
+	Provenance *ClusterProvenance `json:"provenance,omitempty"`
+	// IPAM carries the external IPAM configuration and the IDs of the
+	// addresses allocated from it, so destroy can release them.
+	IPAM *IPAMMetadata `json:"ipam,omitempty"`
+	// Publish records the publishing strategy the cluster was
+	// installed with.
+	Publish PublishingStrategy `json:"publish,omitempty"`
 }
 
 // ClusterPlatformMetadata contains metadata for platfrom.
@@ -8,6 +8,106 @@ type MachinePool struct {
 	// InstanceType defines the GCP instance type.
 	// eg. n1-standard-4
 	InstanceType string `json:"type"`
+
+	// SoleTenancy schedules the pool's machines onto sole-tenant node
+	// groups, e.g. for licensing reasons.
+	// +optional
+	SoleTenancy *SoleTenancy `json:"soleTenancy,omitempty"`
+
+	// OSDisk defines the storage for the instances.
+	// +optional
+	OSDisk `json:"osDisk"`
+
+	// SecureBoot enables Shielded VM secure boot. The boot image must
+	// support UEFI.
+	// +optional
+	SecureBoot bool `json:"secureBoot,omitempty"`
+
+	// VirtualizedTrustedPlatformModule enables the Shielded VM vTPM.
+	// +optional
+	VirtualizedTrustedPlatformModule bool `json:"virtualizedTrustedPlatformModule,omitempty"`
+
+	// IntegrityMonitoring enables Shielded VM integrity monitoring;
+	// requires the vTPM.
+	// +optional
+	IntegrityMonitoring bool `json:"integrityMonitoring,omitempty"`
+
+	// ConfidentialCompute runs the machines as confidential VMs. The
+	// bundled terraform provider cannot express confidential VMs yet,
+	// so validation rejects the option; the field settles the config
+	// shape ahead of provider support.
+	// +optional
+	ConfidentialCompute bool `json:"confidentialCompute,omitempty"`
+
+	// GuestAccelerators attaches accelerators (GPUs) to the pool's
+	// machines. Accelerators require onHostMaintenance: Terminate.
+	// The bundled machine provider cannot express accelerators yet,
+	// so validation rejects them on every pool; the field settles the
+	// config shape ahead of provider support.
+	// +optional
+	GuestAccelerators []GuestAccelerator `json:"guestAccelerators,omitempty"`
+
+	// OnHostMaintenance is the instance behavior during host
+	// maintenance events: "Migrate" (default) or "Terminate".
+	// +optional
+	OnHostMaintenance string `json:"onHostMaintenance,omitempty"`
+}
+
+// GuestAccelerator describes an accelerator attached to an instance.
+type GuestAccelerator struct {
+	// Type is the accelerator type, e.g. nvidia-tesla-t4. It must be
+	// available in every zone the pool uses.
+	Type string `json:"type"`
+
+	// Count is the number of accelerators per machine.
+	Count int64 `json:"count"`
+}
+
+// OSDisk defines the disk for machines on GCP.
+type OSDisk struct {
+	// DiskType is the persistent disk type: pd-standard, pd-ssd,
+	// pd-balanced or pd-extreme.
+	// +optional
+	DiskType string `json:"diskType,omitempty"`
+
+	// DiskSizeGB is the size of the disk in GB.
+	// +optional
+	DiskSizeGB int64 `json:"diskSizeGB,omitempty"`
+
+	// ProvisionedIOPS is the provisioned IOPS for pd-extreme disks.
+	// +optional
+	ProvisionedIOPS int64 `json:"provisionedIOPS,omitempty"`
+
+	// Regional replicates the control plane's disks across two zones
+	// of the region, where the disk type supports it.
+	// +optional
+	Regional bool `json:"regional,omitempty"`
+}
+
+// SoleTenancy describes the sole-tenant node groups a pool's machines
+// are pinned to.
+type SoleTenancy struct {
+	// NodeGroup is the name of the sole-tenant node group. It must
+	// exist in every zone the pool uses.
+	NodeGroup string `json:"nodeGroup,omitempty"`
+
+	// NodeAffinityLabels pins machines by node affinity labels instead
+	// of a named group. The bundled tooling cannot express affinity
+	// labels yet, so validation rejects them; the field settles the
+	// config shape ahead of support.
+	// +optional
+	NodeAffinityLabels map[string]string `json:"nodeAffinityLabels,omitempty"`
+
+	// RestartPolicy is the maintenance restart behavior: "MIGRATE" is
+	// not available on sole-tenant nodes, so "TERMINATE" (default) is
+	// usually wanted.
+	// +optional
+	RestartPolicy string `json:"restartPolicy,omitempty"`
+
+	// CPUOvercommit enables CPU overcommit on the node group's
+	// machines: "enabled" or "none" (default).
+	// +optional
+	CPUOvercommit string `json:"cpuOvercommit,omitempty"`
 }
 
 // Set sets the values from `required` to `a`.
@@ -23,4 +123,41 @@ func (a *MachinePool) Set(required *MachinePool) {
 	if required.InstanceType != "" {
 		a.InstanceType = required.InstanceType
 	}
+
+	if required.SoleTenancy != nil {
+		a.SoleTenancy = required.SoleTenancy
+	}
+
+	if required.OSDisk.DiskType != "" {
+		a.OSDisk.DiskType = required.OSDisk.DiskType
+	}
+	if required.OSDisk.DiskSizeGB != 0 {
+		a.OSDisk.DiskSizeGB = required.OSDisk.DiskSizeGB
+	}
+	if required.OSDisk.ProvisionedIOPS != 0 {
+		a.OSDisk.ProvisionedIOPS = required.OSDisk.ProvisionedIOPS
+	}
+	if required.OSDisk.Regional {
+		a.OSDisk.Regional = true
+	}
+
+	if required.SecureBoot {
+		a.SecureBoot = true
+	}
+	if required.VirtualizedTrustedPlatformModule {
+		a.VirtualizedTrustedPlatformModule = true
+	}
+	if required.IntegrityMonitoring {
+		a.IntegrityMonitoring = true
+	}
+	if required.ConfidentialCompute {
+		a.ConfidentialCompute = true
+	}
+
+	if len(required.GuestAccelerators) > 0 {
+		a.GuestAccelerators = required.GuestAccelerators
+	}
+	if required.OnHostMaintenance != "" {
+		a.OnHostMaintenance = required.OnHostMaintenance
+	}
 }
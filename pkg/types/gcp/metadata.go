@@ -4,4 +4,9 @@ package gcp
 type Metadata struct {
 	Region    string `json:"region"`
 	ProjectID string `json:"projectID"`
+
+	// UserProvidedNetwork records that the cluster was installed into a
+	// pre-existing network, so destroy must never touch shared network
+	// infrastructure.
+	UserProvidedNetwork bool `json:"userProvidedNetwork,omitempty"`
 }
@@ -3,6 +3,19 @@ package gcp
 // Platform stores all the global configuration that all machinesets
 // use.
 type Platform struct {
+	// UserLabels are applied to every labelable resource the installer
+	// creates (instances, disks, images, the ignition bucket) and to
+	// the machineset provider specs, on top of the cluster ownership
+	// label.
+	// +optional
+	UserLabels map[string]string `json:"userLabels,omitempty"`
+
+	// BastionHost is the address of an SSH bastion that can reach the
+	// cluster machines, used by gather when the cluster has no
+	// externally reachable addresses.
+	// +optional
+	BastionHost string `json:"bastionHost,omitempty"`
+
 	// ProjectID is the the project that will be used for the cluster.
 	ProjectID string `json:"projectID"`
 
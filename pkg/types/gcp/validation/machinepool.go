@@ -2,9 +2,11 @@ package validation
 
 import (
 	"fmt"
+	"github.com/sirupsen/logrus"
 	"strings"
 
 	"github.com/openshift/installer/pkg/types/gcp"
+	"github.com/openshift/installer/pkg/validate"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
@@ -17,5 +19,85 @@ func ValidateMachinePool(platform *gcp.Platform, p *gcp.MachinePool, fldPath *fi
 		}
 	}
 
+	if strings.HasPrefix(p.InstanceType, "t2a-") {
+		// t2a is GCP's arm64 family; this installer only ships an
+		// amd64 boot image, so the per-pool image selection cannot
+		// satisfy the pool.
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("type"), p.InstanceType, "t2a machine types are arm64; no arm64 RHCOS image is available for this installer, use an amd64 machine type"))
+	}
+
+	switch p.OSDisk.DiskType {
+	case "", "pd-standard", "pd-ssd", "pd-balanced":
+		if p.OSDisk.ProvisionedIOPS != 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("osDisk", "provisionedIOPS"), p.OSDisk.ProvisionedIOPS, "provisioned IOPS is only configurable for pd-extreme disks"))
+		}
+	case "pd-extreme":
+		if p.OSDisk.ProvisionedIOPS != 0 && (p.OSDisk.ProvisionedIOPS < 2500 || p.OSDisk.ProvisionedIOPS > 120000) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("osDisk", "provisionedIOPS"), p.OSDisk.ProvisionedIOPS, "provisioned IOPS for pd-extreme must be between 2500 and 120000"))
+		}
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("osDisk", "diskType"), p.OSDisk.DiskType, []string{"pd-standard", "pd-ssd", "pd-balanced", "pd-extreme"}))
+	}
+	if p.OSDisk.DiskSizeGB != 0 {
+		if err := validate.DiskSizeGB(p.OSDisk.DiskSizeGB, 65536); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("osDisk", "diskSizeGB"), p.OSDisk.DiskSizeGB, err.Error()))
+		}
+	}
+	if p.OSDisk.Regional && p.OSDisk.DiskType == "pd-extreme" {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("osDisk", "regional"), p.OSDisk.Regional, "regional persistent disks do not support pd-extreme"))
+	}
+	if p.SecureBoot {
+		logrus.Warn("Secure boot requires the boot image to support UEFI; make sure the RHCOS image in use does")
+	}
+	if p.IntegrityMonitoring && !p.VirtualizedTrustedPlatformModule {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("integrityMonitoring"), p.IntegrityMonitoring, "integrity monitoring requires the virtualized trusted platform module"))
+	}
+	if p.ConfidentialCompute {
+		// The bundled terraform provider predates
+		// confidential_instance_config; without it the machines would
+		// come up as ordinary VMs, so reject instead of silently
+		// dropping the intent.
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("confidentialCompute"), p.ConfidentialCompute, "confidential VMs are not supported by the bundled terraform provider yet"))
+	}
+	switch p.OnHostMaintenance {
+	case "", "Migrate", "Terminate":
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("onHostMaintenance"), p.OnHostMaintenance, []string{"Migrate", "Terminate"}))
+	}
+	for i, accelerator := range p.GuestAccelerators {
+		acceleratorPath := fldPath.Child("guestAccelerators").Index(i)
+		if accelerator.Type == "" {
+			allErrs = append(allErrs, field.Required(acceleratorPath.Child("type"), "an accelerator type is required"))
+		}
+		if accelerator.Count < 1 {
+			allErrs = append(allErrs, field.Invalid(acceleratorPath.Child("count"), accelerator.Count, "the accelerator count must be at least 1"))
+		}
+	}
+	if len(p.GuestAccelerators) > 0 && p.OnHostMaintenance != "Terminate" {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("onHostMaintenance"), p.OnHostMaintenance, "accelerators cannot live-migrate; onHostMaintenance must be Terminate"))
+	}
+	if p.SoleTenancy != nil {
+		tenancyPath := fldPath.Child("soleTenancy")
+		if len(p.SoleTenancy.NodeAffinityLabels) > 0 {
+			// Nothing plumbs affinity labels into terraform or the
+			// machinesets yet; a pool pinned only by labels would
+			// silently schedule on shared hosts.
+			allErrs = append(allErrs, field.Invalid(tenancyPath.Child("nodeAffinityLabels"), p.SoleTenancy.NodeAffinityLabels, "node affinity labels are not supported by the bundled tooling yet; pin the pool to a named node group"))
+		}
+		if p.SoleTenancy.NodeGroup == "" {
+			allErrs = append(allErrs, field.Required(tenancyPath.Child("nodeGroup"), "a node group is required"))
+		}
+		switch p.SoleTenancy.RestartPolicy {
+		case "", "TERMINATE":
+		default:
+			allErrs = append(allErrs, field.NotSupported(tenancyPath.Child("restartPolicy"), p.SoleTenancy.RestartPolicy, []string{"TERMINATE"}))
+		}
+		switch p.SoleTenancy.CPUOvercommit {
+		case "", "none", "enabled":
+		default:
+			allErrs = append(allErrs, field.NotSupported(tenancyPath.Child("cpuOvercommit"), p.SoleTenancy.CPUOvercommit, []string{"none", "enabled"}))
+		}
+	}
+
 	return allErrs
 }
@@ -45,3 +45,12 @@ func TestValidateMachinePool(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateMachinePoolArchitectureConsistency(t *testing.T) {
+	platform := &gcp.Platform{Region: "us-east1"}
+	err := ValidateMachinePool(platform, &gcp.MachinePool{InstanceType: "t2a-standard-4"}, field.NewPath("test-path")).ToAggregate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "arm64")
+
+	assert.NoError(t, ValidateMachinePool(platform, &gcp.MachinePool{InstanceType: "n1-standard-4"}, field.NewPath("test-path")).ToAggregate())
+}
@@ -1,7 +1,9 @@
 package validation
 
 import (
+	"regexp"
 	"sort"
+	"strings"
 
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
@@ -50,6 +52,7 @@ var (
 // ValidatePlatform checks that the specified platform is valid.
 func ValidatePlatform(p *gcp.Platform, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
+	allErrs = append(allErrs, validateUserLabels(p.UserLabels, fldPath.Child("userLabels"))...)
 	if _, ok := Regions[p.Region]; !ok {
 		allErrs = append(allErrs, field.NotSupported(fldPath.Child("region"), p.Region, validRegionValues))
 	}
@@ -70,3 +73,33 @@ func ValidatePlatform(p *gcp.Platform, fldPath *field.Path) field.ErrorList {
 
 	return allErrs
 }
+
+// gcpLabelRegexp matches valid GCP label keys and values: lowercase
+// letters, digits, underscores and dashes, keys starting with a
+// letter.
+var (
+	gcpLabelKeyRegexp   = regexp.MustCompile(`^[a-z][a-z0-9_-]{0,62}$`)
+	gcpLabelValueRegexp = regexp.MustCompile(`^[a-z0-9_-]{0,63}$`)
+)
+
+// validateUserLabels enforces the GCP label character and count
+// limits and keeps the kubernetes-io namespace reserved.
+func validateUserLabels(labels map[string]string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if len(labels) > 32 {
+		allErrs = append(allErrs, field.TooMany(fldPath, len(labels), 32))
+	}
+	for key, value := range labels {
+		keyPath := fldPath.Key(key)
+		if !gcpLabelKeyRegexp.MatchString(key) {
+			allErrs = append(allErrs, field.Invalid(keyPath, key, "label keys must start with a lowercase letter and contain only lowercase letters, digits, underscores and dashes (max 63 characters)"))
+		}
+		if !gcpLabelValueRegexp.MatchString(value) {
+			allErrs = append(allErrs, field.Invalid(keyPath, value, "label values may contain only lowercase letters, digits, underscores and dashes (max 63 characters)"))
+		}
+		if strings.HasPrefix(key, "kubernetes-io") {
+			allErrs = append(allErrs, field.Invalid(keyPath, key, "the kubernetes-io label namespace is reserved for the installer"))
+		}
+	}
+	return allErrs
+}
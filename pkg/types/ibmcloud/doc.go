@@ -0,0 +1,9 @@
+// Package ibmcloud contains IBM Cloud-specific structures for
+// installer configuration and management.
+//
+// The platform is not wired into the install flow yet: there are no
+// terraform modules, machineset generators or destroyers for it in
+// this tree. The types exist so install-configs targeting IBM Cloud
+// VPC can already express resource group and pre-existing network
+// selection while the rest of the platform support lands.
+package ibmcloud
@@ -0,0 +1,29 @@
+package ibmcloud
+
+// Name is the name for the IBM Cloud platform.
+const Name = "ibmcloud"
+
+// Platform stores the global configuration used by all IBM Cloud VPC
+// machinesets.
+type Platform struct {
+	// Region specifies the IBM Cloud region where the cluster will be
+	// created.
+	Region string `json:"region"`
+
+	// ResourceGroupName is the name of an existing resource group the
+	// cluster's resources are created under. The API key must have
+	// access to it.
+	// +optional
+	ResourceGroupName string `json:"resourceGroupName,omitempty"`
+
+	// VPCName is the name of a pre-existing VPC to deploy into instead
+	// of creating one. It must exist in the chosen region.
+	// +optional
+	VPCName string `json:"vpcName,omitempty"`
+
+	// Subnets are the IDs of existing subnets (spanning the requested
+	// zones) the machines attach to when deploying into a pre-existing
+	// VPC.
+	// +optional
+	Subnets []string `json:"subnets,omitempty"`
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/openshift/installer/pkg/types/azure"
 	"github.com/openshift/installer/pkg/types/baremetal"
 	"github.com/openshift/installer/pkg/types/gcp"
+	"github.com/openshift/installer/pkg/types/ibmcloud"
 	"github.com/openshift/installer/pkg/types/libvirt"
 	"github.com/openshift/installer/pkg/types/none"
 	"github.com/openshift/installer/pkg/types/openstack"
@@ -52,8 +53,35 @@ const (
 	ExternalPublishingStrategy PublishingStrategy = "External"
 	// InternalPublishingStrategy exposes the endpoints for the cluster to the private network only.
 	InternalPublishingStrategy PublishingStrategy = "Internal"
+	// MixedPublishingStrategy exposes the ingress endpoints to the
+	// Internet while keeping the Kubernetes API private. The value is
+	// accepted so the field shape is settled, but no platform
+	// implements it yet; validation rejects it until one does.
+	MixedPublishingStrategy PublishingStrategy = "Mixed"
 )
 
+// platformPublishingStrategies records which publishing strategies
+// each platform's infrastructure automation actually implements.
+// Platforms absent from the map (and any platform for Mixed) only
+// support External; the none and baremetal platforms create no cloud
+// endpoints themselves, so either strategy is honest there.
+var platformPublishingStrategies = map[string][]PublishingStrategy{
+	aws.Name:       {ExternalPublishingStrategy, InternalPublishingStrategy},
+	azure.Name:     {ExternalPublishingStrategy, InternalPublishingStrategy},
+	gcp.Name:       {ExternalPublishingStrategy, InternalPublishingStrategy},
+	baremetal.Name: {ExternalPublishingStrategy, InternalPublishingStrategy},
+	none.Name:      {ExternalPublishingStrategy, InternalPublishingStrategy},
+}
+
+// PublishingStrategies returns the publishing strategies the named
+// platform supports.
+func PublishingStrategies(platform string) []PublishingStrategy {
+	if strategies, ok := platformPublishingStrategies[platform]; ok {
+		return strategies
+	}
+	return []PublishingStrategy{ExternalPublishingStrategy}
+}
+
 // InstallConfig is the configuration for an OpenShift install.
 type InstallConfig struct {
 	// +optional
@@ -99,10 +127,24 @@ type InstallConfig struct {
 	// +optional
 	Proxy *Proxy `json:"proxy,omitempty"`
 
+	// ImageRegistries configures which registries the cluster's
+	// container runtimes may pull from. The policy is rendered into
+	// the cluster Image config during manifest generation, so it is
+	// active before any workload runs.
+	// +optional
+	ImageRegistries *ImageRegistries `json:"imageRegistries,omitempty"`
+
 	// ImageContentSources lists sources/repositories for the release-image content.
 	// +optional
 	ImageContentSources []ImageContentSource `json:"imageContentSources,omitempty"`
 
+	// AllowMirrorByTags lets the generated registries.conf resolve
+	// mirrors by tag as well as by digest. Only by-digest pulls are
+	// guaranteed to match the mirrored content; enable this only when
+	// the mirror is kept consistent with the sources.
+	// +optional
+	AllowMirrorByTags bool `json:"allowMirrorByTags,omitempty"`
+
 	// Publish controls how the user facing endpoints of the cluster like the Kubernetes API, OpenShift routes etc. are exposed.
 	// When no strategy is specified, the strategy is `External`.
 	// +optional
@@ -110,13 +152,164 @@ type InstallConfig struct {
 
 	// FIPS configures https://www.nist.gov/itl/fips-general-information
 	FIPS bool `json:"fips,omitempty"`
+
+	// IPAM configures an external IP address management service that
+	// static addresses and VIPs are allocated from when they are not
+	// specified manually.
+	// +optional
+	IPAM *IPAM `json:"ipam,omitempty"`
+
+	// APIServer tunes the Kubernetes API server from the first
+	// rollout.
+	// +optional
+	APIServer *APIServerConfig `json:"apiServer,omitempty"`
+
+	// Encryption configures etcd encryption at rest from the first
+	// revision of the API server.
+	// +optional
+	Encryption *EncryptionConfig `json:"encryption,omitempty"`
+
+	// AppsDomain is the domain the default ingress controller serves
+	// routes under, when it must differ from apps.<cluster domain>
+	// (e.g. api on an internal domain with apps on an external one).
+	// The zone for this domain must exist and be manageable by the
+	// ingress operator's DNS credentials. When unset the default
+	// apps.<cluster domain> is used.
+	// +optional
+	AppsDomain string `json:"appsDomain,omitempty"`
+
+	// Bastion optionally provisions a small jump host in a public
+	// subnet so internal clusters stay reachable for wait-for and
+	// gather. Supported on AWS today.
+	// +optional
+	Bastion *Bastion `json:"bastion,omitempty"`
+
+	// ExperimentalBootstrapTimeouts tunes the timeouts of the
+	// bootstrap control plane for platforms with slow disks.
+	// Experimental.
+	// +optional
+	ExperimentalBootstrapTimeouts *BootstrapTimeouts `json:"experimentalBootstrapTimeouts,omitempty"`
+
+	// ExperimentalExternalSigners maps CA common names (e.g.
+	// "root-ca") to external signer binaries that hold the CA private
+	// key in a KMS/HSM. The installer sends a PEM CSR on the binary's
+	// stdin and expects a PEM certificate on stdout; the CA key never
+	// materializes locally. Experimental.
+	// +optional
+	ExperimentalExternalSigners map[string]string `json:"experimentalExternalSigners,omitempty"`
+
+	// APIExternalPort is the port the Kubernetes API is published on at
+	// the external load balancer, for networks that only allow e.g.
+	// 443 outbound. The nodes keep listening on 6443; the load
+	// balancer maps the external port to it, so the option is only
+	// available on platforms whose load balancer can translate ports
+	// (aws, azure) and on none, where the user's load balancer does
+	// the mapping. The internal api-int endpoint stays on 6443. When
+	// unset, 6443 is used.
+	// +optional
+	APIExternalPort int32 `json:"apiExternalPort,omitempty"`
+
+	// MachineConfigServerPort is the port the pointer ignition configs
+	// fetch their content from, for networks that do not allow the
+	// default. The load balancer maps it to the machine config
+	// server's own port, which stays at 22623, so the option is only
+	// available on platforms whose load balancer can translate ports
+	// (aws, azure) and on none, where the user's load balancer does
+	// the mapping. When unset, the default of 22623 is used. This
+	// setting is immutable once the cluster is installed.
+	// +optional
+	MachineConfigServerPort int32 `json:"machineConfigServerPort,omitempty"`
+}
+
+// APIServerConfig tunes the Kubernetes API server at install time.
+type APIServerConfig struct {
+	// AuditProfile selects the API audit profile: Default,
+	// WriteRequestBodies, AllRequestBodies or None.
+	// +optional
+	AuditProfile string `json:"auditProfile,omitempty"`
+
+	// ServiceNodePortRange overrides the node port range, e.g.
+	// "30000-32767".
+	// +optional
+	ServiceNodePortRange string `json:"serviceNodePortRange,omitempty"`
 }
 
+// Bastion configures an optional jump host created with the cluster.
+type Bastion struct {
+	// InstanceType sizes the bastion instance; a platform-appropriate
+	// small default is used when empty.
+	// +optional
+	InstanceType string `json:"instanceType,omitempty"`
+
+	// AllowedCIDRs restrict who may SSH to the bastion. Defaults to
+	// 0.0.0.0/0.
+	// +optional
+	AllowedCIDRs []string `json:"allowedCIDRs,omitempty"`
+
+	// SSHKey is the public key installed for the core user on the
+	// bastion; the cluster-wide sshKey is used when empty.
+	// +optional
+	SSHKey string `json:"sshKey,omitempty"`
+}
+
+// BootstrapTimeouts tunes the bootstrap control plane's internal
+// timeouts, in seconds.
+type BootstrapTimeouts struct {
+	// EtcdBootstrapSeconds bounds the etcd cluster bootstrap.
+	// +optional
+	EtcdBootstrapSeconds int `json:"etcdBootstrapSeconds,omitempty"`
+	// APIServerReadySeconds bounds the wait for the bootstrap API
+	// server to come up.
+	// +optional
+	APIServerReadySeconds int `json:"apiServerReadySeconds,omitempty"`
+	// CVOBootstrapSeconds bounds the cluster-version operator's
+	// bootstrap completion.
+	// +optional
+	CVOBootstrapSeconds int `json:"cvoBootstrapSeconds,omitempty"`
+}
+
+// EncryptionConfig configures etcd encryption at rest.
+type EncryptionConfig struct {
+	// Type is the encryption provider: "aescbc" or "aesgcm".
+	Type string `json:"type"`
+}
+
+// DefaultMachineConfigServerPort is the port the machine config server
+// listens on unless overridden in the install config.
+const DefaultMachineConfigServerPort int32 = 22623
+
 // ClusterDomain returns the DNS domain that all records for a cluster must belong to.
 func (c *InstallConfig) ClusterDomain() string {
 	return fmt.Sprintf("%s.%s", c.ObjectMeta.Name, c.BaseDomain)
 }
 
+// AppsDefaultDomain returns the domain the default ingress controller
+// serves routes under, honoring the appsDomain override.
+func (c *InstallConfig) AppsDefaultDomain() string {
+	if c.AppsDomain != "" {
+		return c.AppsDomain
+	}
+	return fmt.Sprintf("apps.%s", c.ClusterDomain())
+}
+
+// APIPort returns the externally published Kubernetes API port,
+// applying the default when the install config does not override it.
+func (c *InstallConfig) APIPort() int32 {
+	if c.APIExternalPort != 0 {
+		return c.APIExternalPort
+	}
+	return 6443
+}
+
+// MCSPort returns the port the machine config server listens on,
+// applying the default when the install config does not override it.
+func (c *InstallConfig) MCSPort() int32 {
+	if c.MachineConfigServerPort != 0 {
+		return c.MachineConfigServerPort
+	}
+	return DefaultMachineConfigServerPort
+}
+
 // Platform is the configuration for the specific platform upon which to perform
 // the installation. Only one of the platform configuration should be set.
 type Platform struct {
@@ -136,6 +329,13 @@ type Platform struct {
 	// +optional
 	GCP *gcp.Platform `json:"gcp,omitempty"`
 
+	// IBMCloud is the configuration used when installing on IBM Cloud
+	// VPC. The section is parsed and validated so configurations can
+	// be authored ahead of time, but installing is not implemented
+	// yet and is rejected by validation.
+	// +optional
+	IBMCloud *ibmcloud.Platform `json:"ibmcloud,omitempty"`
+
 	// Libvirt is the configuration used when installing on libvirt.
 	// +optional
 	Libvirt *libvirt.Platform `json:"libvirt,omitempty"`
@@ -172,6 +372,8 @@ func (p *Platform) Name() string {
 		return baremetal.Name
 	case p.GCP != nil:
 		return gcp.Name
+	case p.IBMCloud != nil:
+		return ibmcloud.Name
 	case p.Libvirt != nil:
 		return libvirt.Name
 	case p.None != nil:
@@ -213,6 +415,13 @@ type Networking struct {
 	// NOTE: currently only one entry is supported.
 	ServiceNetwork []ipnet.IPNet `json:"serviceNetwork,omitempty"`
 
+	// NodeLocalDNSCache renders machine configs that run a node-local
+	// caching resolver (dnsmasq behind NetworkManager) in front of the
+	// platform's upstream servers, for environments whose resolvers
+	// rate-limit queries.
+	// +optional
+	NodeLocalDNSCache bool `json:"nodeLocalDNSCache,omitempty"`
+
 	// Deprected types, scheduled to be removed
 
 	// Deprecated name for MachineCIDRs. If set, MachineCIDRs must
@@ -268,6 +477,42 @@ type Proxy struct {
 	// NoProxy is a comma-separated list of domains and CIDRs for which the proxy should not be used.
 	// +optional
 	NoProxy string `json:"noProxy,omitempty"`
+
+	// ClientCertificate is a PEM client certificate presented to
+	// proxies that require mutual TLS. It is written to the bootstrap
+	// host for CRI-O, podman and the bootstrap services, and stored as
+	// a secret for the cluster proxy configuration.
+	// +optional
+	ClientCertificate string `json:"clientCertificate,omitempty"`
+
+	// ClientKey is the PEM private key paired with ClientCertificate.
+	// +optional
+	ClientKey string `json:"clientKey,omitempty"`
+}
+
+// ImageRegistries maps to the cluster Image config's registrySources
+// and additionalTrustedCA.
+type ImageRegistries struct {
+	// AllowedRegistries limits image pulls to the listed registries.
+	// Mutually exclusive with blockedRegistries.
+	// +optional
+	AllowedRegistries []string `json:"allowedRegistries,omitempty"`
+
+	// BlockedRegistries forbids image pulls from the listed
+	// registries. Mutually exclusive with allowedRegistries.
+	// +optional
+	BlockedRegistries []string `json:"blockedRegistries,omitempty"`
+
+	// InsecureRegistries lists registries which do not have a valid
+	// TLS certificate or only support HTTP connections.
+	// +optional
+	InsecureRegistries []string `json:"insecureRegistries,omitempty"`
+
+	// AdditionalTrustedCA is a PEM-encoded certificate bundle trusted
+	// for image registry TLS, rendered into a configmap the Image
+	// config references.
+	// +optional
+	AdditionalTrustedCA string `json:"additionalTrustedCA,omitempty"`
 }
 
 // ImageContentSource defines a list of sources/repositories that can be used to pull content.
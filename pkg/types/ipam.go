@@ -0,0 +1,29 @@
+package types
+
+// IPAM configures an optional external IP address management service
+// that the installer queries for static addresses and VIPs instead of
+// requiring them to be written into the install config by hand.
+// Addresses that are specified manually always take precedence over
+// allocations from the service.
+type IPAM struct {
+	// Endpoint is the base URL of the IPAM service's HTTP API.
+	Endpoint string `json:"endpoint"`
+
+	// AuthTokenFile is the path to a file containing a bearer token
+	// presented to the IPAM service. Optional.
+	// +optional
+	AuthTokenFile string `json:"authTokenFile,omitempty"`
+
+	// Pools maps the address roles the installer allocates ("api",
+	// "ingress", "dns") to pool identifiers in the IPAM service.
+	Pools map[string]string `json:"pools,omitempty"`
+}
+
+// IPAMMetadata records the external IPAM configuration and the
+// allocations made from it (e.g. for releasing them on destroy).
+type IPAMMetadata struct {
+	Config *IPAM `json:"config"`
+	// AllocationIDs are the IDs the IPAM service returned for the
+	// addresses allocated during installation.
+	AllocationIDs []string `json:"allocationIDs,omitempty"`
+}
@@ -3,4 +3,8 @@ package libvirt
 // Metadata contains libvirt metadata (e.g. for uninstalling the cluster).
 type Metadata struct {
 	URI string `json:"uri"`
+
+	// AdditionalURIs are the other hypervisors the cluster's machines
+	// were spread across; destroy visits all of them.
+	AdditionalURIs []string `json:"additionalURIs,omitempty"`
 }
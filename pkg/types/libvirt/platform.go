@@ -20,4 +20,12 @@ type Platform struct {
 	// Network
 	// +optional
 	Network *Network `json:"network,omitempty"`
+
+	// AdditionalURIs are libvirtd connections on other hypervisors
+	// sharing the cluster network. Worker machinesets are spread
+	// round-robin across the primary URI and these, one machineset per
+	// host; the control plane and the bootstrap stay on the primary
+	// URI.
+	// +optional
+	AdditionalURIs []string `json:"additionalURIs,omitempty"`
 }
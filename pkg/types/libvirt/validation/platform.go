@@ -13,6 +13,14 @@ func ValidatePlatform(p *libvirt.Platform, fldPath *field.Path) field.ErrorList
 	if err := validate.URI(p.URI); err != nil {
 		allErrs = append(allErrs, field.Invalid(fldPath.Child("uri"), p.URI, err.Error()))
 	}
+	for i, uri := range p.AdditionalURIs {
+		if err := validate.URI(uri); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("additionalURIs").Index(i), uri, err.Error()))
+		}
+		if uri == p.URI {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("additionalURIs").Index(i), uri))
+		}
+	}
 	if p.DefaultMachinePlatform != nil {
 		allErrs = append(allErrs, ValidateMachinePool(p.DefaultMachinePlatform, fldPath.Child("defaultMachinePlatform"))...)
 	}
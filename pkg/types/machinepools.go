@@ -1,6 +1,12 @@
 package types
 
 import (
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+
+	corev1 "k8s.io/api/core/v1"
+
 	"github.com/openshift/installer/pkg/types/aws"
 	"github.com/openshift/installer/pkg/types/azure"
 	"github.com/openshift/installer/pkg/types/baremetal"
@@ -29,6 +35,21 @@ const (
 	ArchitectureAMD64 = "amd64"
 )
 
+// ReplicaDistributionStrategy controls how a pool's replicas are spread
+// across zones when they do not divide evenly.
+type ReplicaDistributionStrategy string
+
+const (
+	// ReplicaDistributionBalanced puts the remainder in the first
+	// zones, in order. This is the default.
+	ReplicaDistributionBalanced ReplicaDistributionStrategy = "balanced"
+	// ReplicaDistributionSpreadRemainderRandom picks the zones that
+	// receive the remainder deterministically from the cluster ID, so
+	// capacity does not always concentrate in the alphabetically-first
+	// zones while manifests stay reproducible.
+	ReplicaDistributionSpreadRemainderRandom ReplicaDistributionStrategy = "spread-remainder-random"
+)
+
 // MachinePool is a pool of machines to be installed.
 type MachinePool struct {
 	// Name is the name of the machine pool.
@@ -51,6 +72,95 @@ type MachinePool struct {
 	// Architecture is the instruction set architecture of the machine pool.
 	// Defaults to amd64.
 	Architecture Architecture `json:"architecture,omitempty"`
+
+	// KernelArguments are appended to the kernel command line of the
+	// pool's machines through a generated MachineConfig, e.g. for
+	// tuning like mitigations=off.
+	// +optional
+	KernelArguments []string `json:"kernelArguments,omitempty"`
+
+	// Labels are additional node labels applied to the pool's nodes
+	// through the generated MachineSets, e.g. for hardware profiles.
+	// Only compute pools may set labels.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Taints are additional node taints applied to the pool's nodes
+	// through the generated MachineSets. Only compute pools may set
+	// taints.
+	// +optional
+	Taints []corev1.Taint `json:"taints,omitempty"`
+
+	// PrePullReleaseImages renders a systemd unit into the pool's
+	// machine configs that pre-pulls the release payload images on
+	// first boot, rate-limited and non-fatal, to speed up node
+	// readiness over slow links.
+	// +optional
+	PrePullReleaseImages bool `json:"prePullReleaseImages,omitempty"`
+
+	// CreateMachineSets controls whether the installer generates the
+	// default machinesets for this pool. Set it to false when compute
+	// is managed entirely by day-2 automation; the worker user-data
+	// secret and machine-api prerequisites are still created.
+	// +optional
+	CreateMachineSets *bool `json:"createMachineSets,omitempty"`
+
+	// ReplicaDistribution selects how replicas are spread across zones
+	// when they do not divide evenly. Ignored when ZoneReplicas is set.
+	// +optional
+	ReplicaDistribution ReplicaDistributionStrategy `json:"replicaDistribution,omitempty"`
+
+	// ZoneReplicas explicitly pins the replica count per zone. The
+	// counts must sum to Replicas and every key must be one of the
+	// pool's configured zones.
+	// +optional
+	ZoneReplicas map[string]int64 `json:"zoneReplicas,omitempty"`
+}
+
+// ZoneDistribution returns the replica count for each of the given
+// zones, in order, honoring the pool's distribution strategy. The
+// clusterID seeds the deterministic shuffle used by the
+// spread-remainder-random strategy so generated manifests are
+// reproducible for a given cluster.
+func (p *MachinePool) ZoneDistribution(clusterID string, zones []string) []int64 {
+	counts := make([]int64, len(zones))
+	if len(zones) == 0 {
+		return counts
+	}
+
+	if len(p.ZoneReplicas) > 0 {
+		for idx, zone := range zones {
+			counts[idx] = p.ZoneReplicas[zone]
+		}
+		return counts
+	}
+
+	total := int64(0)
+	if p.Replicas != nil {
+		total = *p.Replicas
+	}
+	numZones := int64(len(zones))
+	for idx := range zones {
+		counts[idx] = total / numZones
+	}
+	remainder := total % numZones
+
+	order := make([]int, len(zones))
+	for idx := range order {
+		order[idx] = idx
+	}
+	if p.ReplicaDistribution == ReplicaDistributionSpreadRemainderRandom {
+		hash := fnv.New64a()
+		fmt.Fprintf(hash, "%s/%s", clusterID, p.Name)
+		random := rand.New(rand.NewSource(int64(hash.Sum64())))
+		random.Shuffle(len(order), func(i, j int) {
+			order[i], order[j] = order[j], order[i]
+		})
+	}
+	for i := int64(0); i < remainder; i++ {
+		counts[order[i]]++
+	}
+	return counts
 }
 
 // MachinePoolPlatform is the platform-specific configuration for a machine
@@ -0,0 +1,87 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/pointer"
+)
+
+func TestZoneDistribution(t *testing.T) {
+	zones := []string{"a", "b", "c"}
+	cases := []struct {
+		name     string
+		pool     *MachinePool
+		expected []int64
+	}{
+		{
+			name:     "balanced with remainder",
+			pool:     &MachinePool{Name: "worker", Replicas: pointer.Int64Ptr(5)},
+			expected: []int64{2, 2, 1},
+		},
+		{
+			name:     "balanced even",
+			pool:     &MachinePool{Name: "worker", Replicas: pointer.Int64Ptr(6)},
+			expected: []int64{2, 2, 2},
+		},
+		{
+			name: "explicit zone replicas",
+			pool: &MachinePool{
+				Name:         "worker",
+				Replicas:     pointer.Int64Ptr(5),
+				ZoneReplicas: map[string]int64{"a": 1, "b": 1, "c": 3},
+			},
+			expected: []int64{1, 1, 3},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.pool.ZoneDistribution("test-12345", zones))
+		})
+	}
+}
+
+func TestZoneDistributionSpreadRemainderRandomIsDeterministic(t *testing.T) {
+	zones := []string{"a", "b", "c"}
+	pool := &MachinePool{
+		Name:                "worker",
+		Replicas:            pointer.Int64Ptr(4),
+		ReplicaDistribution: ReplicaDistributionSpreadRemainderRandom,
+	}
+	first := pool.ZoneDistribution("test-12345", zones)
+	total := int64(0)
+	for _, count := range first {
+		total += count
+	}
+	assert.Equal(t, int64(4), total)
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, first, pool.ZoneDistribution("test-12345", zones))
+	}
+}
+
+func TestZoneDistributionMatrix(t *testing.T) {
+	allZones := []string{"a", "b", "c", "d", "e", "f"}
+	for replicas := int64(0); replicas <= 10; replicas++ {
+		for zoneCount := 1; zoneCount <= len(allZones); zoneCount++ {
+			zones := allZones[:zoneCount]
+			pool := &MachinePool{Name: "worker", Replicas: pointer.Int64Ptr(replicas)}
+			counts := pool.ZoneDistribution("test-12345", zones)
+
+			total := int64(0)
+			min, max := counts[0], counts[0]
+			for _, count := range counts {
+				total += count
+				if count < min {
+					min = count
+				}
+				if count > max {
+					max = count
+				}
+			}
+			assert.Equal(t, replicas, total, "replicas=%d zones=%d", replicas, zoneCount)
+			assert.True(t, max-min <= 1, "distribution must be balanced: replicas=%d zones=%d counts=%v", replicas, zoneCount, counts)
+			// Deterministic for a given cluster ID.
+			assert.Equal(t, counts, pool.ZoneDistribution("test-12345", zones))
+		}
+	}
+}
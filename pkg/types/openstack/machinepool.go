@@ -7,6 +7,13 @@ type MachinePool struct {
 	// eg. m1.large
 	FlavorName string `json:"type"`
 
+	// AdditionalSecurityGroupIDs are IDs of existing security groups
+	// attached to the pool's ports on top of the installer-managed
+	// master/worker groups, which are always created and attached
+	// first. Only the installer-created groups are deleted on destroy.
+	// +optional
+	AdditionalSecurityGroupIDs []string `json:"additionalSecurityGroupIDs,omitempty"`
+
 	// RootVolume defines the root volume for instances in the machine pool.
 	// The instances use ephemeral disks if not set.
 	// +optional
@@ -19,6 +26,10 @@ func (o *MachinePool) Set(required *MachinePool) {
 		return
 	}
 
+	if len(required.AdditionalSecurityGroupIDs) > 0 {
+		o.AdditionalSecurityGroupIDs = required.AdditionalSecurityGroupIDs
+	}
+
 	if required.FlavorName != "" {
 		o.FlavorName = required.FlavorName
 	}
@@ -17,8 +17,17 @@ type Platform struct {
 	Cloud string `json:"cloud"`
 
 	// ExternalNetwork is name of the external network in your OpenStack cluster.
+	// Leave it empty together with machinesSubnet for provider-network
+	// installs: no router or floating IPs are created and the API and
+	// ingress VIPs are plain ports on the provider network.
 	ExternalNetwork string `json:"externalNetwork"`
 
+	// MachinesSubnet is the ID of an existing subnet (e.g. on a
+	// provider network routable from outside) the machines attach to
+	// instead of an installer-created network.
+	// +optional
+	MachinesSubnet string `json:"machinesSubnet,omitempty"`
+
 	// FlavorName is the name of the compute flavor to use for instances in this cluster.
 	FlavorName string `json:"computeFlavor"`
 
@@ -39,6 +48,12 @@ type Platform struct {
 	// cluster supports Octavia Loadbalancing.
 	OctaviaSupport string `json:"octaviaSupport"`
 
+	// DNSForwarders are the upstream resolvers the node-hosted CoreDNS
+	// forwards to instead of the DHCP-provided resolv.conf entries.
+	// CoreDNS accepts at most 3 forwarders.
+	// +optional
+	DNSForwarders []string `json:"dnsForwarders,omitempty"`
+
 	// ClusterOSImage is either a URL to override the default OS image
 	// for cluster nodes or an existing Glance image name.
 	// +optional
@@ -4,19 +4,26 @@ import (
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	"github.com/openshift/installer/pkg/types/openstack"
+	"github.com/openshift/installer/pkg/validate"
 )
 
 // ValidateMachinePool checks that the specified machine pool is valid.
 func ValidateMachinePool(p *openstack.MachinePool, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
+	for i, groupID := range p.AdditionalSecurityGroupIDs {
+		if err := validate.UUID(groupID); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("additionalSecurityGroupIDs").Index(i), groupID, "security group IDs must be UUIDs"))
+		}
+	}
+
 	// Validate Root Volumes
 	if p.RootVolume != nil {
 		if p.RootVolume.Type == "" {
 			allErrs = append(allErrs, field.Invalid(fldPath.Child("rootVolume").Child("type"), p.RootVolume.Type, "Volume type must be specified to use root volumes"))
 		}
-		if p.RootVolume.Size <= 0 {
-			allErrs = append(allErrs, field.Invalid(fldPath.Child("rootVolume").Child("size"), p.RootVolume.Size, "Volume size must be greater than zero to use root volumes"))
+		if err := validate.DiskSizeGB(int64(p.RootVolume.Size), 0); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("rootVolume").Child("size"), p.RootVolume.Size, err.Error()))
 		}
 	}
 
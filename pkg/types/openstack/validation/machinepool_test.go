@@ -27,7 +27,7 @@ func TestValidateMachinePool(t *testing.T) {
 					Type: "default",
 				},
 			},
-			expected: `^test-path\.rootVolume\.size: Invalid value: -10: Volume size must be greater than zero to use root volumes$`,
+			expected: `^test-path\.rootVolume\.size: Invalid value: -10: disk size must be a positive integer$`,
 		},
 		{
 			name: "missing size",
@@ -36,25 +36,35 @@ func TestValidateMachinePool(t *testing.T) {
 					Type: "default",
 				},
 			},
-			expected: `^test-path\.rootVolume\.size: Invalid value: 0: Volume size must be greater than zero to use root volumes$`,
+			expected: `^test-path\.rootVolume\.size: Invalid value: 0: disk size must be a positive integer$`,
 		},
 		{
 			name: "missing type",
 			pool: &openstack.MachinePool{
 				RootVolume: &openstack.RootVolume{
-					Size: 10,
+					Size: 120,
 				},
 			},
 			expected: `^test-path\.rootVolume\.type: Invalid value: "": Volume type must be specified to use root volumes$`,
 		},
 		{
-			name: "valid root volume",
+			name: "size below RHCOS minimum",
 			pool: &openstack.MachinePool{
 				RootVolume: &openstack.RootVolume{
 					Size: 10,
 					Type: "default",
 				},
 			},
+			expected: `^test-path\.rootVolume\.size: Invalid value: 10: disk size 10 GB is below the 16 GB minimum required by RHCOS$`,
+		},
+		{
+			name: "valid root volume",
+			pool: &openstack.MachinePool{
+				RootVolume: &openstack.RootVolume{
+					Size: 120,
+					Type: "default",
+				},
+			},
 		},
 	}
 	for _, tc := range cases {
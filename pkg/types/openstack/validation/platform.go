@@ -20,11 +20,25 @@ func ValidatePlatform(p *openstack.Platform, n *types.Networking, fldPath *field
 	} else if !isValidValue(p.Cloud, validClouds) {
 		allErrs = append(allErrs, field.NotSupported(fldPath.Child("cloud"), p.Cloud, validClouds))
 	} else {
-		validNetworks, err := fetcher.GetNetworkNames(p.Cloud)
-		if err != nil {
-			allErrs = append(allErrs, field.InternalError(fldPath.Child("externalNetwork"), errors.New("could not retrieve valid networks")))
-		} else if !isValidValue(p.ExternalNetwork, validNetworks) {
-			allErrs = append(allErrs, field.NotSupported(fldPath.Child("externalNetwork"), p.ExternalNetwork, validNetworks))
+		if p.ExternalNetwork == "" && p.MachinesSubnet != "" {
+			// Provider-network install: the machine subnet is directly
+			// routable, no router or floating IPs are created. The
+			// subnet must have free addresses for the VIPs and every
+			// node; the installer cannot count them without Neutron
+			// admin, so exhaustion still surfaces at port creation.
+			logrus.Infof("No external network: installing onto provider-network subnet %s without a router or floating IPs", p.MachinesSubnet)
+		} else {
+			validNetworks, err := fetcher.GetNetworkNames(p.Cloud)
+			if err != nil {
+				allErrs = append(allErrs, field.InternalError(fldPath.Child("externalNetwork"), errors.New("could not retrieve valid networks")))
+			} else if !isValidValue(p.ExternalNetwork, validNetworks) {
+				allErrs = append(allErrs, field.NotSupported(fldPath.Child("externalNetwork"), p.ExternalNetwork, validNetworks))
+			}
+		}
+		if p.MachinesSubnet != "" {
+			if err := validate.UUID(p.MachinesSubnet); err != nil {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("machinesSubnet"), p.MachinesSubnet, "must be a subnet UUID"))
+			}
 		}
 		validFlavors, err := fetcher.GetFlavorNames(p.Cloud)
 		if err != nil {
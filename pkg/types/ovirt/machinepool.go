@@ -3,6 +3,26 @@ package ovirt
 // MachinePool stores the configuration for a machine pool installed
 // on ovirt.
 type MachinePool struct {
+	// AutoPinningPolicy sets the CPU/NUMA auto-pinning policy applied
+	// to the pool's VMs: "none" (default), "resize_and_pin". Neither
+	// the bundled terraform provider nor the machine provider spec
+	// can express the policy yet, so any value other than "none" is
+	// rejected by validation; the field settles the config shape
+	// ahead of provider support.
+	// +optional
+	AutoPinningPolicy string `json:"autoPinningPolicy,omitempty"`
+
+	// Hugepages is the size of a VM's hugepages to back its memory, in
+	// KiB. Only 2048 and 1048576 are supported. Like
+	// autoPinningPolicy, the bundled tooling cannot express it yet
+	// and validation rejects non-zero values.
+	// +optional
+	Hugepages int32 `json:"hugepages,omitempty"`
+
+	// AffinityGroupsNames names the affinity groups (defined on the
+	// platform) the pool's VMs join.
+	// +optional
+	AffinityGroupsNames []string `json:"affinityGroupsNames,omitempty"`
 }
 
 // Set sets the values from `required` to `a`.
@@ -10,4 +30,13 @@ func (l *MachinePool) Set(required *MachinePool) {
 	if required == nil || l == nil {
 		return
 	}
+	if required.AutoPinningPolicy != "" {
+		l.AutoPinningPolicy = required.AutoPinningPolicy
+	}
+	if required.Hugepages != 0 {
+		l.Hugepages = required.Hugepages
+	}
+	if len(required.AffinityGroupsNames) > 0 {
+		l.AffinityGroupsNames = required.AffinityGroupsNames
+	}
 }
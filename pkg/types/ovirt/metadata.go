@@ -4,4 +4,11 @@ package ovirt
 type Metadata struct {
 	ClusterID      string `json:"cluster_id"`
 	RemoveTemplate bool   `json:"remove_template"`
+	// BaseImageName is the name of the template (and its uploaded
+	// disk image) the installer created, when it did.
+	BaseImageName string `json:"base_image_name,omitempty"`
+	// AffinityGroupNames are the affinity groups the installer
+	// created in the oVirt cluster; groups cannot be tagged, so the
+	// names travel in the metadata for destroy.
+	AffinityGroupNames []string `json:"affinity_group_names,omitempty"`
 }
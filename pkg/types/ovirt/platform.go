@@ -23,4 +23,38 @@ type Platform struct {
 	// +optional
 	// Default will set the image field to the latest RHCOS image.
 	DefaultMachinePlatform *MachinePool `json:"defaultMachinePlatform,omitempty"`
+	// TemplateName names an existing RHCOS template to boot from,
+	// skipping the automatic upload of the qcow2 artifact and template
+	// creation. When empty the installer uploads the image (sparse,
+	// checksum-verified) and creates a template named after the infra
+	// ID, which destroy cleans up with the rest of the cluster.
+	// +optional
+	TemplateName string `json:"templateName,omitempty"`
+
+	// AffinityGroups is a list of affinity groups the installer creates
+	// in the oVirt cluster before any VMs, so the control plane can be
+	// spread (or pinned) across hosts.
+	// +optional
+	AffinityGroups []AffinityGroup `json:"affinityGroups,omitempty"`
+}
+
+// AffinityGroup defines an oVirt affinity group to create.
+type AffinityGroup struct {
+	// Name of the affinity group.
+	Name string `json:"name"`
+	// Priority of the affinity group, higher wins when rules
+	// conflict. The bundled oVirt SDK cannot set a group's priority,
+	// so a non-zero value only produces a warning today.
+	Priority int `json:"priority"`
+	// Positive makes the group keep its VMs together; the default
+	// (false) is an anti-affinity group that spreads them.
+	// +optional
+	Positive bool `json:"positive,omitempty"`
+	// Enforcing makes the scheduler fail VMs that cannot satisfy the
+	// group instead of merely preferring to satisfy it.
+	// +optional
+	Enforcing bool `json:"enforcing,omitempty"`
+	// Description of the affinity group.
+	// +optional
+	Description string `json:"description,omitempty"`
 }
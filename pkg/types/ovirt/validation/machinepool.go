@@ -6,7 +6,41 @@ import (
 	"github.com/openshift/installer/pkg/types/ovirt"
 )
 
+var validAutoPinningPolicies = []string{"none", "resize_and_pin"}
+
+var validHugepages = []int32{2048, 1048576}
+
 // ValidateMachinePool checks that the specified machine pool is valid.
 func ValidateMachinePool(p *ovirt.MachinePool, fldPath *field.Path) field.ErrorList {
-	return field.ErrorList{}
+	allErrs := field.ErrorList{}
+	if p.AutoPinningPolicy != "" {
+		valid := false
+		for _, policy := range validAutoPinningPolicies {
+			if p.AutoPinningPolicy == policy {
+				valid = true
+			}
+		}
+		if !valid {
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("autoPinningPolicy"), p.AutoPinningPolicy, validAutoPinningPolicies))
+		} else if p.AutoPinningPolicy != "none" {
+			// Neither the bundled terraform provider nor the machine
+			// provider spec can carry the policy to the VMs; reject
+			// instead of silently dropping the intent.
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("autoPinningPolicy"), p.AutoPinningPolicy, "auto-pinning is not supported by the bundled tooling yet"))
+		}
+	}
+	if p.Hugepages != 0 {
+		valid := false
+		for _, size := range validHugepages {
+			if p.Hugepages == size {
+				valid = true
+			}
+		}
+		if !valid {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("hugepages"), p.Hugepages, "hugepages size must be 2048 or 1048576 KiB"))
+		} else {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("hugepages"), p.Hugepages, "hugepages are not supported by the bundled tooling yet"))
+		}
+	}
+	return allErrs
 }
@@ -28,5 +28,19 @@ func ValidatePlatform(p *ovirt.Platform, fldPath *field.Path) field.ErrorList {
 	if p.DefaultMachinePlatform != nil {
 		allErrs = append(allErrs, ValidateMachinePool(p.DefaultMachinePlatform, fldPath.Child("defaultMachinePlatform"))...)
 	}
+	seenGroups := map[string]bool{}
+	for idx, group := range p.AffinityGroups {
+		groupPath := fldPath.Child("affinityGroups").Index(idx)
+		if group.Name == "" {
+			allErrs = append(allErrs, field.Required(groupPath.Child("name"), "affinity group name is required"))
+		}
+		if seenGroups[group.Name] {
+			allErrs = append(allErrs, field.Duplicate(groupPath.Child("name"), group.Name))
+		}
+		seenGroups[group.Name] = true
+		if group.Priority < 0 || group.Priority > 100 {
+			allErrs = append(allErrs, field.Invalid(groupPath.Child("priority"), group.Priority, "priority must be between 0 and 100"))
+		}
+	}
 	return allErrs
 }
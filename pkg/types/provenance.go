@@ -0,0 +1,34 @@
+package types
+
+// RHCOSArtifact records the location and digest of a single RHCOS build
+// artifact used during the installation.
+type RHCOSArtifact struct {
+	// URL is the location the artifact was published to, if any.
+	URL string `json:"url,omitempty"`
+	// SHA256 is the digest of the artifact as recorded in the embedded
+	// RHCOS build metadata.
+	SHA256 string `json:"sha256,omitempty"`
+}
+
+// ClusterProvenance records the exact inputs that produced a cluster:
+// the release image actually resolved, the RHCOS artifacts referenced
+// by the embedded build metadata, and the installer binary itself.
+type ClusterProvenance struct {
+	// InstallerVersion is the version of the installer binary.
+	InstallerVersion string `json:"installerVersion"`
+	// InstallerCommit is the commit hash from which the installer was built.
+	InstallerCommit string `json:"installerCommit,omitempty"`
+	// ReleaseImage is the release-image pull spec the installer resolved.
+	ReleaseImage string `json:"releaseImage"`
+	// OSImage is the RHCOS image the machines boot from, after any
+	// platform-specific resolution (e.g. AMI ID, image URL or SKU).
+	OSImage string `json:"osImage,omitempty"`
+	// BootstrapOSImage is the image the bootstrap machine boots from when
+	// it differs from OSImage.
+	BootstrapOSImage string `json:"bootstrapOSImage,omitempty"`
+	// RHCOSVersion is the RHCOS build version from the embedded metadata.
+	RHCOSVersion string `json:"rhcosVersion,omitempty"`
+	// RHCOSArtifacts lists the per-platform RHCOS artifacts with their
+	// digests, keyed by platform (and region for AMIs).
+	RHCOSArtifacts map[string]RHCOSArtifact `json:"rhcosArtifacts,omitempty"`
+}
@@ -1,14 +1,19 @@
 package validation
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"net"
 	"os"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	dockerref "github.com/containers/image/docker/reference"
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	"github.com/openshift/installer/pkg/ipnet"
@@ -23,6 +28,7 @@ import (
 	gcpvalidation "github.com/openshift/installer/pkg/types/gcp/validation"
 	"github.com/openshift/installer/pkg/types/libvirt"
 	libvirtvalidation "github.com/openshift/installer/pkg/types/libvirt/validation"
+	"github.com/openshift/installer/pkg/types/none"
 	"github.com/openshift/installer/pkg/types/openstack"
 	openstackvalidation "github.com/openshift/installer/pkg/types/openstack/validation"
 	"github.com/openshift/installer/pkg/types/vsphere"
@@ -99,10 +105,145 @@ func ValidateInstallConfig(c *types.InstallConfig, openStackValidValuesFetcher o
 		allErrs = append(allErrs, validateProxy(c.Proxy, field.NewPath("proxy"))...)
 	}
 	allErrs = append(allErrs, validateImageContentSources(c.ImageContentSources, field.NewPath("imageContentSources"))...)
+	if c.ImageRegistries != nil {
+		allErrs = append(allErrs, validateImageRegistries(c, field.NewPath("imageRegistries"))...)
+	}
 	if _, ok := validPublishingStrategies[c.Publish]; !ok {
 		allErrs = append(allErrs, field.NotSupported(field.NewPath("publish"), c.Publish, validPublishingStrategyValues))
+	} else if c.Platform.Name() != "" {
+		allErrs = append(allErrs, validatePublishingStrategy(c.Publish, c.Platform.Name(), field.NewPath("publish"))...)
+	}
+	if c.Platform.AWS != nil && c.Platform.AWS.DefaultMachinePlatform != nil && c.Platform.AWS.DefaultMachinePlatform.MetadataService != nil {
+		// The machine provider cannot configure the metadata service
+		// on compute machines; only the control plane honors the
+		// default.
+		logrus.Warn("platform.aws.defaultMachinePlatform.metadataService is only applied to the control plane and bootstrap instances; compute machines keep the AWS defaults")
+	}
+	if c.Bastion != nil && c.Platform.AWS == nil {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("bastion"), "<bastion>", fmt.Sprintf("the bastion host is only implemented on aws; the %q platform does not provision one yet", c.Platform.Name())))
+	}
+	allErrs = append(allErrs, validateMachineConfigServerPort(c, field.NewPath("machineConfigServerPort"))...)
+	if c.APIExternalPort != 0 {
+		switch {
+		case c.APIExternalPort < 1 || c.APIExternalPort > 65535:
+			allErrs = append(allErrs, field.Invalid(field.NewPath("apiExternalPort"), c.APIExternalPort, "port must be between 1 and 65535"))
+		case c.APIExternalPort == 80:
+			allErrs = append(allErrs, field.Invalid(field.NewPath("apiExternalPort"), c.APIExternalPort, "port collides with ingress routes published on the load balancer"))
+		case c.APIExternalPort == c.MCSPort():
+			allErrs = append(allErrs, field.Invalid(field.NewPath("apiExternalPort"), c.APIExternalPort, "port collides with the machine config server"))
+		}
+		if c.APIExternalPort != 6443 {
+			// GCP forwarding rules and the on-prem VIPs deliver
+			// traffic to the same port on the instances, where only
+			// 6443 listens.
+			switch c.Platform.Name() {
+			case aws.Name, azure.Name, none.Name:
+			default:
+				allErrs = append(allErrs, field.Invalid(field.NewPath("apiExternalPort"), c.APIExternalPort, fmt.Sprintf("the %q platform's load balancing cannot map the port to the API server; only aws, azure and none support a custom port", c.Platform.Name())))
+			}
+		}
+	}
+	if c.ExperimentalBootstrapTimeouts != nil {
+		timeoutsPath := field.NewPath("experimentalBootstrapTimeouts")
+		for name, value := range map[string]int{
+			"etcdBootstrapSeconds":  c.ExperimentalBootstrapTimeouts.EtcdBootstrapSeconds,
+			"apiServerReadySeconds": c.ExperimentalBootstrapTimeouts.APIServerReadySeconds,
+			"cvoBootstrapSeconds":   c.ExperimentalBootstrapTimeouts.CVOBootstrapSeconds,
+		} {
+			if value != 0 && (value < 60 || value > 7200) {
+				allErrs = append(allErrs, field.Invalid(timeoutsPath.Child(name), value, "timeout must be between 60 and 7200 seconds"))
+			}
+		}
+	}
+	allErrs = append(allErrs, validateDNSForwarders(c)...)
+	if c.APIServer != nil {
+		apiPath := field.NewPath("apiServer")
+		switch c.APIServer.AuditProfile {
+		case "", "Default", "WriteRequestBodies", "AllRequestBodies", "None":
+		default:
+			allErrs = append(allErrs, field.NotSupported(apiPath.Child("auditProfile"), c.APIServer.AuditProfile, []string{"Default", "WriteRequestBodies", "AllRequestBodies", "None"}))
+		}
+		if c.APIServer.ServiceNodePortRange != "" {
+			var min, max int
+			if _, err := fmt.Sscanf(c.APIServer.ServiceNodePortRange, "%d-%d", &min, &max); err != nil {
+				allErrs = append(allErrs, field.Invalid(apiPath.Child("serviceNodePortRange"), c.APIServer.ServiceNodePortRange, "must be of the form <min>-<max>"))
+			} else if min < 1024 || max > 65535 || min >= max {
+				allErrs = append(allErrs, field.Invalid(apiPath.Child("serviceNodePortRange"), c.APIServer.ServiceNodePortRange, "the range must be within 1024-65535 with min below max"))
+			} else if min <= 10259 {
+				allErrs = append(allErrs, field.Invalid(apiPath.Child("serviceNodePortRange"), c.APIServer.ServiceNodePortRange, "the range overlaps ports used by default cluster listeners; start it above 10259"))
+			}
+		}
+	}
+	if c.Encryption != nil {
+		switch c.Encryption.Type {
+		case "aescbc":
+		case "aesgcm":
+			if c.FIPS {
+				allErrs = append(allErrs, field.Invalid(field.NewPath("encryption", "type"), c.Encryption.Type, "aesgcm is not permitted with fips enabled; use aescbc"))
+			}
+		default:
+			allErrs = append(allErrs, field.NotSupported(field.NewPath("encryption", "type"), c.Encryption.Type, []string{"aescbc", "aesgcm"}))
+		}
+	}
+	if c.AppsDomain != "" {
+		if err := validate.DomainName(c.AppsDomain, true); err != nil {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("appsDomain"), c.AppsDomain, err.Error()))
+		} else if !strings.Contains(c.AppsDomain, ".") {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("appsDomain"), c.AppsDomain, "appsDomain must be a fully-qualified subdomain"))
+		}
 	}
+	if c.Networking != nil && c.Networking.NodeLocalDNSCache {
+		switch {
+		case c.Platform.BareMetal != nil, c.Platform.OpenStack != nil, c.Platform.Ovirt != nil, c.Platform.VSphere != nil:
+			allErrs = append(allErrs, field.Invalid(field.NewPath("networking", "nodeLocalDNSCache"), true,
+				fmt.Sprintf("the %s platform runs its own on-host DNS; a node-local cache would conflict with it", c.Platform.Name())))
+		}
+	}
+
+	return allErrs
+}
+
+// reservedClusterPorts are ports that other cluster listeners bind, so
+// the machine config server must not be moved onto them.
+var reservedClusterPorts = map[int32]string{
+	22:    "ssh",
+	53:    "dns",
+	80:    "ingress http",
+	443:   "ingress https",
+	2379:  "etcd client",
+	2380:  "etcd peer",
+	6443:  "Kubernetes API",
+	10250: "kubelet",
+	10257: "kube-controller-manager",
+	10259: "kube-scheduler",
+}
 
+func validateMachineConfigServerPort(c *types.InstallConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	port := c.MachineConfigServerPort
+	if port == 0 {
+		return allErrs
+	}
+	if port < 1024 || port > 65535 {
+		allErrs = append(allErrs, field.Invalid(fldPath, port, "port must be between 1024 and 65535"))
+	}
+	if listener, ok := reservedClusterPorts[port]; ok {
+		allErrs = append(allErrs, field.Invalid(fldPath, port, fmt.Sprintf("port collides with the %s listener", listener)))
+	}
+	if port != types.DefaultMachineConfigServerPort {
+		// The machine config server itself stays on 22623; only load
+		// balancers that can map a frontend port to a different
+		// backend port can offer a custom port. GCP forwarding rules
+		// and the on-prem VIPs pass the port through unchanged.
+		switch c.Platform.Name() {
+		case aws.Name, azure.Name, none.Name:
+		default:
+			allErrs = append(allErrs, field.Invalid(fldPath, port, fmt.Sprintf("the %q platform's load balancing cannot map the port to the machine config server; only aws, azure and none support a custom port", c.Platform.Name())))
+		}
+	}
+	if len(allErrs) == 0 && port != types.DefaultMachineConfigServerPort {
+		logrus.Warnf("The machine config server port is immutable once the cluster is installed; port %d will be used for the lifetime of the cluster", port)
+	}
 	return allErrs
 }
 
@@ -224,6 +365,68 @@ func validateNetworkingIPVersion(n *types.Networking, p *types.Platform) field.E
 		// we should have a validation error for no specified machineNetwork, serviceNetwork, or clusterNetwork
 	}
 
+	if hasIPv6 && n.NetworkType != "OpenShiftSDN" && n.NetworkType != "OVNKubernetes" {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("networking", "networkType"), n.NetworkType, "OVNKubernetes is required when any IPv6 network is present"))
+	}
+	if hasIPv6 && p.Azure != nil {
+		if azureIPv6UnsupportedRegions[p.Azure.Region] {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("platform", "azure", "region"), p.Azure.Region, "this region does not support IPv6 on Standard load balancers"))
+		}
+	}
+	if hasIPv4 && hasIPv6 {
+		allErrs = append(allErrs, validatePrimaryFamilyOrdering(n)...)
+	}
+
+	return allErrs
+}
+
+// azureIPv6UnsupportedRegions are regions whose Standard load
+// balancers do not offer IPv6 frontends.
+var azureIPv6UnsupportedRegions = map[string]bool{
+	"chinaeast":        true,
+	"chinaeast2":       true,
+	"chinanorth":       true,
+	"chinanorth2":      true,
+	"germanycentral":   true,
+	"germanynortheast": true,
+	"usgovarizona":     true,
+	"usgoviowa":        true,
+	"usgovtexas":       true,
+	"usgovvirginia":    true,
+}
+
+// validatePrimaryFamilyOrdering checks that the machine, cluster and
+// service network lists agree on which IP family comes first, since a
+// dual-stack cluster whose lists disagree on the primary family comes
+// up broken.
+func validatePrimaryFamilyOrdering(n *types.Networking) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	primary := ""
+	note := func(fldPath *field.Path, index int, cidr string, ip net.IP) {
+		family := "IPv4"
+		if ip.To4() == nil {
+			family = "IPv6"
+		}
+		if primary == "" {
+			primary = family
+			return
+		}
+		if family != primary {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(index), cidr,
+				fmt.Sprintf("the first entry is %s but the machine network's first entry is %s; all network lists must use the same primary IP family", family, primary)))
+		}
+	}
+
+	if len(n.MachineNetwork) > 0 {
+		note(field.NewPath("networking", "machineNetwork"), 0, n.MachineNetwork[0].CIDR.String(), n.MachineNetwork[0].CIDR.IP)
+	}
+	if len(n.ClusterNetwork) > 0 {
+		note(field.NewPath("networking", "clusterNetwork"), 0, n.ClusterNetwork[0].CIDR.String(), n.ClusterNetwork[0].CIDR.IP)
+	}
+	if len(n.ServiceNetwork) > 0 {
+		note(field.NewPath("networking", "serviceNetwork"), 0, n.ServiceNetwork[0].String(), n.ServiceNetwork[0].IP)
+	}
 	return allErrs
 }
 
@@ -299,14 +502,41 @@ func validateClusterNetwork(n *types.Networking, cn *types.ClusterNetworkEntry,
 	if cn.HostPrefix < 0 {
 		allErrs = append(allErrs, field.Invalid(fldPath.Child("hostPrefix"), cn.HostPrefix, "hostPrefix must be positive"))
 	}
-	if ones, _ := cn.CIDR.Mask.Size(); cn.HostPrefix < int32(ones) {
+	if ones, bits := cn.CIDR.Mask.Size(); cn.HostPrefix < int32(ones) {
 		allErrs = append(allErrs, field.Invalid(fldPath.Child("hostPrefix"), cn.HostPrefix, "cluster network host subnetwork prefix must not be larger size than CIDR "+cn.CIDR.String()))
+	} else if cn.HostPrefix > int32(bits) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("hostPrefix"), cn.HostPrefix, fmt.Sprintf("cluster network %d (%s) hostPrefix exceeds the %d bits of its address family", idx, cn.CIDR.String(), bits)))
 	}
 	return allErrs
 }
 
 func validateControlPlane(platform *types.Platform, pool *types.MachinePool, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
+	if len(pool.Labels) > 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("labels"), pool.Labels, "node labels are not supported on the control plane pool"))
+	}
+	if len(pool.Taints) > 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("taints"), pool.Taints, "node taints are not supported on the control plane pool"))
+	}
+	if pool.Platform.AWS != nil && pool.Platform.AWS.Placement != nil && pool.Platform.AWS.Placement.Strategy == "spread" && pool.Replicas != nil {
+		zones := len(pool.Platform.AWS.Zones)
+		if zones == 0 {
+			zones = 1
+		}
+		if *pool.Replicas > int64(zones*7) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("platform", "aws", "placement"), *pool.Replicas,
+				fmt.Sprintf("spread placement groups hold at most 7 instances per availability zone (%d zones)", zones)))
+		}
+	}
+	if pool.Platform.AWS != nil && (len(pool.Platform.AWS.Subnets) > 0 || len(pool.Platform.AWS.Networks) > 0) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("platform", "aws"), pool.Platform.AWS, "the control plane must stay within the machine network; per-pool subnets and networks are only supported on compute pools"))
+	}
+	if pool.Platform.AWS != nil && awsGPUInstanceType(pool.Platform.AWS.InstanceType) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("platform", "aws", "type"), pool.Platform.AWS.InstanceType, "GPU instance types are not supported on the control plane pool"))
+	}
+	if pool.Platform.GCP != nil && len(pool.Platform.GCP.GuestAccelerators) > 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("platform", "gcp", "guestAccelerators"), pool.Platform.GCP.GuestAccelerators, "accelerators are not supported on the control plane pool"))
+	}
 	if pool.Name != masterPoolName {
 		allErrs = append(allErrs, field.NotSupported(fldPath.Child("name"), pool.Name, []string{masterPoolName}))
 	}
@@ -317,6 +547,17 @@ func validateControlPlane(platform *types.Platform, pool *types.MachinePool, fld
 	return allErrs
 }
 
+// awsGPUInstanceType reports whether the named EC2 instance type
+// belongs to one of the GPU families.
+func awsGPUInstanceType(instanceType string) bool {
+	for _, prefix := range []string{"p2.", "p3.", "p3dn.", "p4d.", "g3.", "g3s.", "g4dn.", "g5."} {
+		if strings.HasPrefix(instanceType, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
 func validateCompute(platform *types.Platform, control *types.MachinePool, pools []types.MachinePool, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 	poolNames := map[string]bool{}
@@ -332,6 +573,35 @@ func validateCompute(platform *types.Platform, control *types.MachinePool, pools
 		if control != nil && control.Architecture != p.Architecture {
 			allErrs = append(allErrs, field.Invalid(poolFldPath.Child("architecture"), p.Architecture, "heteregeneous multi-arch is not supported; compute pool architecture must match control plane"))
 		}
+		if p.Platform.AWS != nil && p.Platform.AWS.MetadataService != nil {
+			allErrs = append(allErrs, field.Invalid(poolFldPath.Child("platform", "aws", "metadataService"), p.Platform.AWS.MetadataService, "the machine provider cannot configure the metadata service on compute machines yet; the setting is only supported on the control plane pool"))
+		}
+		if p.Platform.GCP != nil && (p.Platform.GCP.SecureBoot || p.Platform.GCP.VirtualizedTrustedPlatformModule || p.Platform.GCP.IntegrityMonitoring) {
+			// Shielded VM options are wired into the control plane's
+			// terraform only; compute machinesets carry them as
+			// annotations no controller consumes yet.
+			logrus.Warnf("compute[%d]: shielded VM options are only effective for the control plane; compute machinesets record them as annotations the machine controller does not consume yet", i)
+		}
+		if p.Platform.AWS != nil && p.Platform.AWS.Placement != nil {
+			// Terraform only places the control plane; the aws machine
+			// provider spec has no placement-group field, so a compute
+			// pool's group travels as an annotation no controller
+			// consumes yet.
+			logrus.Warnf("compute[%d]: placement groups are only effective for the control plane; compute machinesets record the group as an annotation the machine controller does not consume yet", i)
+		}
+		if p.Platform.GCP != nil && p.Platform.GCP.SoleTenancy != nil {
+			// Terraform only places the control plane onto node
+			// groups; the machine provider spec has no field for it,
+			// so a compute pool's pinning travels as an annotation no
+			// controller consumes yet.
+			logrus.Warnf("compute[%d]: sole-tenancy pinning is only effective for the control plane; compute machinesets record it as an annotation the machine controller does not consume yet", i)
+		}
+		if p.Platform.GCP != nil && len(p.Platform.GCP.GuestAccelerators) > 0 {
+			// The bundled gcp machine provider spec has no
+			// accelerator fields; accepting the pool would produce
+			// machines without GPUs, which is worse than refusing.
+			allErrs = append(allErrs, field.Invalid(poolFldPath.Child("platform", "gcp", "guestAccelerators"), p.Platform.GCP.GuestAccelerators, "the bundled machine provider cannot attach accelerators yet; remove the accelerators or create the machinesets out of band"))
+		}
 		allErrs = append(allErrs, ValidateMachinePool(platform, &p, poolFldPath)...)
 	}
 	return allErrs
@@ -340,6 +610,11 @@ func validateCompute(platform *types.Platform, control *types.MachinePool, pools
 func validatePlatform(platform *types.Platform, fldPath *field.Path, openStackValidValuesFetcher openstackvalidation.ValidValuesFetcher, network *types.Networking, c *types.InstallConfig) field.ErrorList {
 	allErrs := field.ErrorList{}
 	activePlatform := platform.Name()
+	if platform.IBMCloud != nil {
+		// The types exist so configurations can be authored and
+		// validated ahead of time; nothing provisions IBM Cloud yet.
+		return append(allErrs, field.Invalid(fldPath.Child("ibmcloud"), "<platform>", "IBM Cloud VPC support is not implemented yet; the section is accepted for ahead-of-time validation only"))
+	}
 	platforms := make([]string, len(types.PlatformNames))
 	copy(platforms, types.PlatformNames)
 	platforms = append(platforms, types.HiddenPlatformNames...)
@@ -387,6 +662,25 @@ func validatePlatform(platform *types.Platform, fldPath *field.Path, openStackVa
 func validateProxy(p *types.Proxy, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
+	if (p.ClientCertificate == "") != (p.ClientKey == "") {
+		allErrs = append(allErrs, field.Required(fldPath.Child("clientKey"), "clientCertificate and clientKey must be provided together"))
+	} else if p.ClientCertificate != "" {
+		certificate, err := tls.X509KeyPair([]byte(p.ClientCertificate), []byte(p.ClientKey))
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("clientCertificate"), "<certificate>", fmt.Sprintf("client certificate and key do not pair: %v", err)))
+		} else if parsed, err := x509.ParseCertificate(certificate.Certificate[0]); err == nil {
+			if time.Now().After(parsed.NotAfter) {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("clientCertificate"), "<certificate>", fmt.Sprintf("client certificate expired on %s", parsed.NotAfter.Format(time.RFC3339))))
+			}
+			// Be explicit about the limitation: the pair is only
+			// distributed (to /etc/pki/proxy-client on the bootstrap
+			// host and the openshift-config/proxy-client-cert
+			// secret); no OpenShift component presents it to the
+			// proxy yet.
+			logrus.Warn("No OpenShift component supports mTLS proxies yet; the client certificate is only distributed to /etc/pki/proxy-client on the bootstrap host and the openshift-config/proxy-client-cert secret for custom services to consume")
+		}
+	}
+
 	if p.HTTPProxy == "" && p.HTTPSProxy == "" {
 		allErrs = append(allErrs, field.Required(fldPath, "must include httpProxy or httpsProxy"))
 	}
@@ -447,6 +741,7 @@ var (
 	validPublishingStrategies = map[types.PublishingStrategy]struct{}{
 		types.ExternalPublishingStrategy: {},
 		types.InternalPublishingStrategy: {},
+		types.MixedPublishingStrategy:    {},
 	}
 
 	validPublishingStrategyValues = func() []string {
@@ -458,3 +753,121 @@ var (
 		return v
 	}()
 )
+
+// validateImageRegistries checks the registry pull policy: the
+// allowed and blocked lists are mutually exclusive, every entry must
+// be a plausible registry hostname, and a blocked registry that only
+// appears as the source of an imageContentSources mirror is fine -
+// the runtimes pull from the mirror - so it only warns.
+func validateImageRegistries(c *types.InstallConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	registries := c.ImageRegistries
+
+	if len(registries.AllowedRegistries) > 0 && len(registries.BlockedRegistries) > 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("blockedRegistries"), registries.BlockedRegistries, "only one of allowedRegistries and blockedRegistries may be set"))
+	}
+
+	for listName, list := range map[string][]string{
+		"allowedRegistries":  registries.AllowedRegistries,
+		"blockedRegistries":  registries.BlockedRegistries,
+		"insecureRegistries": registries.InsecureRegistries,
+	} {
+		for i, registry := range list {
+			if err := validateRegistryHostname(registry); err != nil {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child(listName).Index(i), registry, err.Error()))
+			}
+		}
+	}
+
+	if registries.AdditionalTrustedCA != "" {
+		if err := validate.CABundle(registries.AdditionalTrustedCA); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("additionalTrustedCA"), registries.AdditionalTrustedCA, err.Error()))
+		}
+	}
+
+	mirrored := map[string]bool{}
+	for _, group := range c.ImageContentSources {
+		if idx := strings.Index(group.Source, "/"); idx > 0 {
+			mirrored[group.Source[:idx]] = true
+		}
+	}
+	for _, registry := range registries.BlockedRegistries {
+		if mirrored[registry] {
+			logrus.Warnf("Blocked registry %s is the source of an imageContentSources mirror; pulls will be served from the mirror", registry)
+		}
+	}
+
+	return allErrs
+}
+
+// validateRegistryHostname checks a registry entry: a hostname or
+// "*.wildcard", optionally with a port.
+func validateRegistryHostname(registry string) error {
+	if registry == "" {
+		return errors.New("the registry hostname must not be empty")
+	}
+	host := registry
+	if idx := strings.LastIndex(host, ":"); idx >= 0 {
+		port := host[idx+1:]
+		if n, err := strconv.Atoi(port); err == nil {
+			if n < 1 || n > 65535 {
+				return errors.New("the registry port must be between 1 and 65535")
+			}
+			host = host[:idx]
+		}
+	}
+	host = strings.TrimPrefix(host, "*.")
+	return validate.DomainName(host, false)
+}
+
+// validatePublishingStrategy checks the chosen publishing strategy
+// against what the platform's infrastructure automation implements,
+// so an unsupported strategy fails validation instead of silently
+// creating public endpoints anyway.
+func validatePublishingStrategy(publish types.PublishingStrategy, platform string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	supported := types.PublishingStrategies(platform)
+	for _, s := range supported {
+		if s == publish {
+			return allErrs
+		}
+	}
+	if publish == types.MixedPublishingStrategy {
+		allErrs = append(allErrs, field.Invalid(fldPath, publish, "the Mixed publishing strategy is not implemented by any platform yet"))
+		return allErrs
+	}
+	values := make([]string, 0, len(supported))
+	for _, s := range supported {
+		values = append(values, string(s))
+	}
+	sort.Strings(values)
+	allErrs = append(allErrs, field.Invalid(fldPath, publish, fmt.Sprintf("the %q platform only supports the following publishing strategies: %s", platform, strings.Join(values, ", "))))
+	return allErrs
+}
+
+// validateDNSForwarders checks the on-prem platforms' CoreDNS
+// forwarder lists: IP syntax and CoreDNS's limit of 3 upstreams.
+func validateDNSForwarders(c *types.InstallConfig) field.ErrorList {
+	allErrs := field.ErrorList{}
+	var forwarders []string
+	var fldPath *field.Path
+	switch {
+	case c.Platform.BareMetal != nil:
+		forwarders, fldPath = c.Platform.BareMetal.DNSForwarders, field.NewPath("platform", "baremetal", "dnsForwarders")
+	case c.Platform.VSphere != nil:
+		forwarders, fldPath = c.Platform.VSphere.DNSForwarders, field.NewPath("platform", "vsphere", "dnsForwarders")
+	case c.Platform.OpenStack != nil:
+		forwarders, fldPath = c.Platform.OpenStack.DNSForwarders, field.NewPath("platform", "openstack", "dnsForwarders")
+	default:
+		return allErrs
+	}
+	if len(forwarders) > 3 {
+		allErrs = append(allErrs, field.TooMany(fldPath, len(forwarders), 3))
+	}
+	for i, forwarder := range forwarders {
+		if err := validate.IP(forwarder); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), forwarder, err.Error()))
+		}
+	}
+	return allErrs
+}
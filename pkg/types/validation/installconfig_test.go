@@ -218,6 +218,52 @@ func TestValidateInstallConfig(t *testing.T) {
 			}(),
 			expectedError: `^metadata.name: Invalid value: "bad-name-": a DNS-1123 subdomain must consist of lower case alphanumeric characters, '-' or '\.', and must start and end with an alphanumeric character \(e\.g\. 'example\.com', regex used for validation is '\[a-z0-9]\(\[-a-z0-9]\*\[a-z0-9]\)\?\(\\\.\[a-z0-9]\(\[-a-z0-9]\*\[a-z0-9]\)\?\)\*'\)$`,
 		},
+		{
+			name: "valid machine config server port",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.MachineConfigServerPort = 32623
+				return c
+			}(),
+		},
+		{
+			name: "machine config server port collides with API",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.MachineConfigServerPort = 6443
+				return c
+			}(),
+			expectedError: `^machineConfigServerPort: Invalid value: 6443: port collides with the Kubernetes API listener$`,
+		},
+		{
+			name: "external API port on a platform without port mapping",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Platform = types.Platform{GCP: validGCPPlatform()}
+				c.APIExternalPort = 443
+				return c
+			}(),
+			expectedError: `^apiExternalPort: Invalid value: 443: the \"gcp\" platform's load balancing cannot map the port to the API server; only aws, azure and none support a custom port$`,
+		},
+		{
+			name: "machine config server port on a platform without port mapping",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Platform = types.Platform{GCP: validGCPPlatform()}
+				c.MachineConfigServerPort = 32623
+				return c
+			}(),
+			expectedError: `^machineConfigServerPort: Invalid value: 32623: the \"gcp\" platform's load balancing cannot map the port to the machine config server; only aws, azure and none support a custom port$`,
+		},
+		{
+			name: "machine config server port out of range",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.MachineConfigServerPort = 123
+				return c
+			}(),
+			expectedError: `^machineConfigServerPort: Invalid value: 123: port must be between 1024 and 65535$`,
+		},
 		{
 			name: "invalid ssh key",
 			installConfig: func() *types.InstallConfig {
@@ -470,7 +516,7 @@ func TestValidateInstallConfig(t *testing.T) {
 				}
 				return c
 			}(),
-			expectedError: `^compute\[0\]\.platform\.openstack: Invalid value: openstack\.MachinePool{FlavorName:"", RootVolume:\(\*openstack\.RootVolume\)\(nil\)}: cannot specify "openstack" for machine pool when cluster is using "aws"$`,
+			expectedError: `^compute\[0\]\.platform\.openstack: Invalid value: openstack\.MachinePool{FlavorName:"", AdditionalSecurityGroupIDs:\[]string\(nil\), RootVolume:\(\*openstack\.RootVolume\)\(nil\)}: cannot specify "openstack" for machine pool when cluster is using "aws"$`,
 		},
 		{
 			name: "missing platform",
@@ -499,7 +545,7 @@ func TestValidateInstallConfig(t *testing.T) {
 				}
 				return c
 			}(),
-			expectedError: `^platform\.aws\.region: Unsupported value: "": supported values: "ap-northeast-1", "ap-northeast-2", "ap-south-1", "ap-southeast-1", "ap-southeast-2", "ca-central-1", "eu-central-1", "eu-north-1", "eu-west-1", "eu-west-2", "eu-west-3", "me-south-1", "sa-east-1", "us-east-1", "us-east-2", "us-west-1", "us-west-2"$`,
+			expectedError: `^platform\.aws\.region: Unsupported value: "": supported values: "ap-northeast-1", "ap-northeast-2", "ap-south-1", "ap-southeast-1", "ap-southeast-2", "ca-central-1", "cn-north-1", "cn-northwest-1", "eu-central-1", "eu-north-1", "eu-west-1", "eu-west-2", "eu-west-3", "me-south-1", "sa-east-1", "us-east-1", "us-east-2", "us-west-1", "us-west-2"$`,
 		},
 		{
 			name: "valid libvirt platform",
@@ -811,7 +857,26 @@ func TestValidateInstallConfig(t *testing.T) {
 				c.Publish = types.PublishingStrategy("ExternalInternalDoNotCare")
 				return c
 			}(),
-			expectedError: `^publish: Unsupported value: \"ExternalInternalDoNotCare\": supported values: \"External\", \"Internal\"`,
+			expectedError: `^publish: Unsupported value: \"ExternalInternalDoNotCare\": supported values: \"External\", \"Internal\", \"Mixed\"`,
+		},
+		{
+			name: "internal publishing on a platform without internal support",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Platform = types.Platform{VSphere: validVSpherePlatform()}
+				c.Publish = types.InternalPublishingStrategy
+				return c
+			}(),
+			expectedError: `^publish: Invalid value: \"Internal\": the \"vsphere\" platform only supports the following publishing strategies: External$`,
+		},
+		{
+			name: "mixed publishing is not implemented yet",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Publish = types.MixedPublishingStrategy
+				return c
+			}(),
+			expectedError: `^publish: Invalid value: \"Mixed\": the Mixed publishing strategy is not implemented by any platform yet$`,
 		},
 
 		{
@@ -874,6 +939,52 @@ func TestValidateInstallConfig(t *testing.T) {
 			}(),
 			expectedError: `IPv6 is not supported for this networking plugin`,
 		},
+		{
+			name: "invalid dual-stack configuration, azure region without IPv6 LBs",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Platform = types.Platform{Azure: validAzurePlatform()}
+				c.Platform.Azure.Region = "usgovvirginia"
+				c.Networking = validDualStackNetworkingConfig()
+				return c
+			}(),
+			expectedError: `this region does not support IPv6 on Standard load balancers`,
+		},
+		{
+			name: "invalid IPv6 configuration, third-party plugin",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Platform = types.Platform{None: &none.Platform{}}
+				c.Networking = validIPv6NetworkingConfig()
+				c.Networking.NetworkType = "Calico"
+				return c
+			}(),
+			expectedError: `OVNKubernetes is required when any IPv6 network is present`,
+		},
+		{
+			name: "invalid dual-stack configuration, mismatched primary family",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Platform = types.Platform{None: &none.Platform{}}
+				c.Networking = validDualStackNetworkingConfig()
+				c.Networking.ServiceNetwork = []ipnet.IPNet{
+					c.Networking.ServiceNetwork[1],
+					c.Networking.ServiceNetwork[0],
+				}
+				return c
+			}(),
+			expectedError: `all network lists must use the same primary IP family`,
+		},
+		{
+			name: "invalid hostPrefix for the address family",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.Networking = validIPv4NetworkingConfig()
+				c.Networking.ClusterNetwork[0].HostPrefix = 64
+				return c
+			}(),
+			expectedError: `hostPrefix exceeds the 32 bits of its address family`,
+		},
 		{
 			name: "invalid dual-stack configuration, machine has no IPv6",
 			installConfig: func() *types.InstallConfig {
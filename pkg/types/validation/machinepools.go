@@ -2,7 +2,10 @@ package validation
 
 import (
 	"fmt"
+	"strings"
 
+	"k8s.io/apimachinery/pkg/util/sets"
+	utilvalidation "k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	"github.com/openshift/installer/pkg/types"
@@ -12,10 +15,16 @@ import (
 	azurevalidation "github.com/openshift/installer/pkg/types/azure/validation"
 	"github.com/openshift/installer/pkg/types/baremetal"
 	baremetalvalidation "github.com/openshift/installer/pkg/types/baremetal/validation"
+	"github.com/openshift/installer/pkg/types/gcp"
+	gcpvalidation "github.com/openshift/installer/pkg/types/gcp/validation"
 	"github.com/openshift/installer/pkg/types/libvirt"
 	libvirtvalidation "github.com/openshift/installer/pkg/types/libvirt/validation"
 	"github.com/openshift/installer/pkg/types/openstack"
 	openstackvalidation "github.com/openshift/installer/pkg/types/openstack/validation"
+	"github.com/openshift/installer/pkg/types/ovirt"
+	ovirtvalidation "github.com/openshift/installer/pkg/types/ovirt/validation"
+	"github.com/openshift/installer/pkg/types/vsphere"
+	vspherevalidation "github.com/openshift/installer/pkg/types/vsphere/validation"
 )
 
 var (
@@ -61,10 +70,129 @@ func ValidateMachinePool(platform *types.Platform, p *types.MachinePool, fldPath
 	if !validArchitectures[p.Architecture] {
 		allErrs = append(allErrs, field.NotSupported(fldPath.Child("architecture"), p.Architecture, validArchitectureValues))
 	}
+	if p.CreateMachineSets != nil && !*p.CreateMachineSets {
+		switch {
+		case platform.BareMetal != nil, platform.OpenStack != nil, platform.Ovirt != nil:
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("createMachineSets"), *p.CreateMachineSets,
+				fmt.Sprintf("machinesets cannot be disabled on the %s platform, which relies on them for ingress wiring", platform.Name())))
+		}
+	}
+	allErrs = append(allErrs, validateKernelArguments(p, fldPath)...)
+	allErrs = append(allErrs, validateNodeLabels(p, fldPath)...)
+	allErrs = append(allErrs, validateReplicaDistribution(p, fldPath)...)
 	allErrs = append(allErrs, validateMachinePoolPlatform(platform, &p.Platform, fldPath.Child("platform"))...)
 	return allErrs
 }
 
+// forbiddenKernelArgumentPrefixes are arguments that would break the
+// machine's boot or the installer's assumptions if overridden.
+var forbiddenKernelArgumentPrefixes = []string{"root=", "initrd=", "BOOT_IMAGE=", "coreos.inst", "ignition."}
+
+// maxKernelArguments bounds the per-role argument list.
+const maxKernelArguments = 25
+
+func validateKernelArguments(p *types.MachinePool, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if len(p.KernelArguments) > maxKernelArguments {
+		allErrs = append(allErrs, field.TooMany(fldPath.Child("kernelArguments"), len(p.KernelArguments), maxKernelArguments))
+	}
+	seen := map[string]bool{}
+	for idx, argument := range p.KernelArguments {
+		argumentPath := fldPath.Child("kernelArguments").Index(idx)
+		if argument == "" {
+			allErrs = append(allErrs, field.Required(argumentPath, "kernel argument must not be empty"))
+			continue
+		}
+		if seen[argument] {
+			allErrs = append(allErrs, field.Duplicate(argumentPath, argument))
+		}
+		seen[argument] = true
+		for _, prefix := range forbiddenKernelArgumentPrefixes {
+			if strings.HasPrefix(argument, prefix) {
+				allErrs = append(allErrs, field.Invalid(argumentPath, argument, fmt.Sprintf("arguments starting with %q would break the machine's boot and are not allowed", prefix)))
+			}
+		}
+	}
+	return allErrs
+}
+
+// reservedLabelPrefixes are label namespaces owned by Kubernetes that
+// pools must not write into, with the conventional exception of node
+// roles.
+var reservedLabelPrefixes = []string{"kubernetes.io/", "k8s.io/"}
+
+func validateNodeLabels(p *types.MachinePool, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for key, value := range p.Labels {
+		keyPath := fldPath.Child("labels").Key(key)
+		for _, message := range utilvalidation.IsQualifiedName(key) {
+			allErrs = append(allErrs, field.Invalid(keyPath, key, message))
+		}
+		for _, message := range utilvalidation.IsValidLabelValue(value) {
+			allErrs = append(allErrs, field.Invalid(keyPath, value, message))
+		}
+		for _, prefix := range reservedLabelPrefixes {
+			if (strings.HasPrefix(key, prefix) || strings.Contains(key, "."+prefix)) && !strings.HasPrefix(key, "node-role.kubernetes.io/") {
+				allErrs = append(allErrs, field.Invalid(keyPath, key, fmt.Sprintf("label keys in the %s namespace are reserved", prefix)))
+			}
+		}
+	}
+	for idx, taint := range p.Taints {
+		taintPath := fldPath.Child("taints").Index(idx)
+		for _, message := range utilvalidation.IsQualifiedName(taint.Key) {
+			allErrs = append(allErrs, field.Invalid(taintPath.Child("key"), taint.Key, message))
+		}
+		switch taint.Effect {
+		case "NoSchedule", "PreferNoSchedule", "NoExecute":
+		default:
+			allErrs = append(allErrs, field.NotSupported(taintPath.Child("effect"), taint.Effect, []string{"NoSchedule", "PreferNoSchedule", "NoExecute"}))
+		}
+	}
+	return allErrs
+}
+
+func validateReplicaDistribution(p *types.MachinePool, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	switch p.ReplicaDistribution {
+	case "", types.ReplicaDistributionBalanced, types.ReplicaDistributionSpreadRemainderRandom:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("replicaDistribution"), p.ReplicaDistribution,
+			[]string{string(types.ReplicaDistributionBalanced), string(types.ReplicaDistributionSpreadRemainderRandom)}))
+	}
+
+	if len(p.ZoneReplicas) == 0 {
+		return allErrs
+	}
+
+	var zones []string
+	switch {
+	case p.Platform.AWS != nil:
+		zones = p.Platform.AWS.Zones
+	case p.Platform.Azure != nil:
+		zones = p.Platform.Azure.Zones
+	case p.Platform.GCP != nil:
+		zones = p.Platform.GCP.Zones
+	default:
+		return append(allErrs, field.Invalid(fldPath.Child("zoneReplicas"), p.ZoneReplicas, "explicit zone replicas are only supported on platforms with configurable zones"))
+	}
+
+	zoneSet := sets.NewString(zones...)
+	sum := int64(0)
+	for zone, count := range p.ZoneReplicas {
+		if !zoneSet.Has(zone) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("zoneReplicas").Key(zone), count, "zone is not configured for this machine pool"))
+		}
+		if count < 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("zoneReplicas").Key(zone), count, "zone replica count must not be negative"))
+		}
+		sum += count
+	}
+	if p.Replicas != nil && sum != *p.Replicas {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("zoneReplicas"), sum, fmt.Sprintf("zone replica counts must sum to replicas (%d)", *p.Replicas)))
+	}
+	return allErrs
+}
+
 func validateMachinePoolPlatform(platform *types.Platform, p *types.MachinePoolPlatform, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 	platformName := platform.Name()
@@ -91,5 +219,14 @@ func validateMachinePoolPlatform(platform *types.Platform, p *types.MachinePoolP
 	if p.BareMetal != nil {
 		validate(baremetal.Name, p.BareMetal, func(f *field.Path) field.ErrorList { return baremetalvalidation.ValidateMachinePool(p.BareMetal, f) })
 	}
+	if p.GCP != nil {
+		validate(gcp.Name, p.GCP, func(f *field.Path) field.ErrorList { return gcpvalidation.ValidateMachinePool(platform.GCP, p.GCP, f) })
+	}
+	if p.VSphere != nil {
+		validate(vsphere.Name, p.VSphere, func(f *field.Path) field.ErrorList { return vspherevalidation.ValidateMachinePool(p.VSphere, f) })
+	}
+	if p.Ovirt != nil {
+		validate(ovirt.Name, p.Ovirt, func(f *field.Path) field.ErrorList { return ovirtvalidation.ValidateMachinePool(p.Ovirt, f) })
+	}
 	return allErrs
 }
@@ -10,8 +10,10 @@ import (
 	"github.com/openshift/installer/pkg/types"
 	"github.com/openshift/installer/pkg/types/aws"
 	"github.com/openshift/installer/pkg/types/azure"
+	"github.com/openshift/installer/pkg/types/gcp"
 	"github.com/openshift/installer/pkg/types/libvirt"
 	"github.com/openshift/installer/pkg/types/openstack"
+	"github.com/openshift/installer/pkg/types/vsphere"
 )
 
 func validMachinePool(name string) *types.MachinePool {
@@ -56,6 +58,69 @@ func TestValidateMachinePool(t *testing.T) {
 			}(),
 			valid: false,
 		},
+		{
+			name:     "invalid replica distribution",
+			platform: &types.Platform{AWS: &aws.Platform{Region: "us-east-1"}},
+			pool: func() *types.MachinePool {
+				p := validMachinePool("test-name")
+				p.ReplicaDistribution = "bad-strategy"
+				return p
+			}(),
+			valid: false,
+		},
+		{
+			name:     "valid explicit zone replicas",
+			platform: &types.Platform{AWS: &aws.Platform{Region: "us-east-1"}},
+			pool: func() *types.MachinePool {
+				p := validMachinePool("test-name")
+				p.Platform.AWS = &aws.MachinePool{Zones: []string{"us-east-1a"}}
+				p.ZoneReplicas = map[string]int64{"us-east-1a": 1}
+				return p
+			}(),
+			valid: true,
+		},
+		{
+			name:     "zone replicas do not sum to replicas",
+			platform: &types.Platform{AWS: &aws.Platform{Region: "us-east-1"}},
+			pool: func() *types.MachinePool {
+				p := validMachinePool("test-name")
+				p.Platform.AWS = &aws.MachinePool{Zones: []string{"us-east-1a"}}
+				p.ZoneReplicas = map[string]int64{"us-east-1a": 2}
+				return p
+			}(),
+			valid: false,
+		},
+		{
+			name:     "zone replicas reference unknown zone",
+			platform: &types.Platform{AWS: &aws.Platform{Region: "us-east-1"}},
+			pool: func() *types.MachinePool {
+				p := validMachinePool("test-name")
+				p.Platform.AWS = &aws.MachinePool{Zones: []string{"us-east-1a"}}
+				p.ZoneReplicas = map[string]int64{"us-east-1b": 1}
+				return p
+			}(),
+			valid: false,
+		},
+		{
+			name:     "gcp pool on an azure install",
+			platform: &types.Platform{Azure: &azure.Platform{Region: "eastus"}},
+			pool: func() *types.MachinePool {
+				p := validMachinePool("test-name")
+				p.Platform.GCP = &gcp.MachinePool{}
+				return p
+			}(),
+			valid: false,
+		},
+		{
+			name:     "vsphere pool on an aws install",
+			platform: &types.Platform{AWS: &aws.Platform{Region: "us-east-1"}},
+			pool: func() *types.MachinePool {
+				p := validMachinePool("test-name")
+				p.Platform.VSphere = &vsphere.MachinePool{}
+				return p
+			}(),
+			valid: false,
+		},
 		{
 			name:     "valid aws",
 			platform: &types.Platform{AWS: &aws.Platform{Region: "us-east-1"}},
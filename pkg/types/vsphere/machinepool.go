@@ -15,6 +15,27 @@ type MachinePool struct {
 
 	// OSDisk defines the storage for instance.
 	OSDisk `json:"osDisk"`
+
+	// Folder overrides the cluster-wide folder for this pool's VMs, so
+	// masters and workers can live in separately permissioned folders.
+	// +optional
+	Folder string `json:"folder,omitempty"`
+
+	// ResourcePool overrides the cluster-wide resource pool for this
+	// pool's VMs.
+	// +optional
+	ResourcePool string `json:"resourcePool,omitempty"`
+
+	// Network overrides the cluster-wide portgroup for this pool's
+	// primary NIC, for segregated traffic.
+	// +optional
+	Network string `json:"network,omitempty"`
+
+	// AdditionalNetworks names portgroups attached as extra NICs.
+	// Ignition only configures the primary NIC unless static IP host
+	// entries are supplied separately.
+	// +optional
+	AdditionalNetworks []string `json:"additionalNetworks,omitempty"`
 }
 
 // OSDisk defines the disk for a virtual machine.
@@ -29,6 +50,22 @@ func (p *MachinePool) Set(required *MachinePool) {
 		return
 	}
 
+	if required.Folder != "" {
+		p.Folder = required.Folder
+	}
+
+	if required.ResourcePool != "" {
+		p.ResourcePool = required.ResourcePool
+	}
+
+	if required.Network != "" {
+		p.Network = required.Network
+	}
+
+	if len(required.AdditionalNetworks) > 0 {
+		p.AdditionalNetworks = required.AdditionalNetworks
+	}
+
 	if required.NumCPUs != 0 {
 		p.NumCPUs = required.NumCPUs
 	}
@@ -21,12 +21,24 @@ type Platform struct {
 	// virtual machines.
 	Folder string `json:"folder,omitempty"`
 
+	// ResourcePool is the absolute path of the resource pool where VMs
+	// will be created, e.g. /datacenter/host/cluster/Resources/pool.
+	// When empty the cluster's root resource pool is used.
+	// +optional
+	ResourcePool string `json:"resourcePool,omitempty"`
+
 	// Cluster is the name of the cluster virtual machines will be cloned into.
 	Cluster string `json:"cluster,omitempty"`
 
 	// ClusterOSImage overrides the url provided in rhcos.json to download the RHCOS OVA
 	ClusterOSImage string `json:"clusterOSImage,omitempty"`
 
+	// DNSForwarders are the upstream resolvers the node-hosted CoreDNS
+	// forwards to instead of the DHCP-provided resolv.conf entries.
+	// CoreDNS accepts at most 3 forwarders.
+	// +optional
+	DNSForwarders []string `json:"dnsForwarders,omitempty"`
+
 	// APIVIP is the virtual IP address for the api endpoint
 	APIVIP string `json:"apiVIP,omitempty"`
 
@@ -1,6 +1,7 @@
 package validation
 
 import (
+	"github.com/sirupsen/logrus"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	"github.com/openshift/installer/pkg/types/vsphere"
@@ -8,5 +9,13 @@ import (
 
 // ValidateMachinePool checks that the specified machine pool is valid.
 func ValidateMachinePool(p *vsphere.MachinePool, fldPath *field.Path) field.ErrorList {
+	if len(p.AdditionalNetworks) > 0 {
+		logrus.Warn("Ignition only configures the primary NIC; supply static IP host entries for the additional networks or configure them day-2")
+	}
+	for i, network := range p.AdditionalNetworks {
+		if network == "" {
+			return field.ErrorList{field.Required(fldPath.Child("additionalNetworks").Index(i), "portgroup name must not be empty")}
+		}
+	}
 	return field.ErrorList{}
 }
@@ -13,6 +13,7 @@ import (
 	"strings"
 
 	uuid "github.com/google/uuid"
+	"github.com/sirupsen/logrus"
 	"golang.org/x/crypto/ssh"
 	k8serrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/validation"
@@ -197,3 +198,31 @@ func UUID(val string) error {
 	_, err := uuid.Parse(val)
 	return err
 }
+
+// Disk size bounds for RHCOS nodes, in GB. RHCOS needs room to pivot
+// to the machine-os-content during first boot; below the recommended
+// minimum the node can fill its disk under normal operation.
+const (
+	hardMinDiskSizeGB        = 16
+	recommendedMinDiskSizeGB = 100
+)
+
+// DiskSizeGB validates an OS disk size in GB against the minimum
+// required by RHCOS and an optional platform maximum (pass 0 for no
+// maximum). Sizes below the recommended minimum only produce a
+// warning.
+func DiskSizeGB(sizeGB int64, platformMaxGB int64) error {
+	if sizeGB <= 0 {
+		return fmt.Errorf("disk size must be a positive integer")
+	}
+	if sizeGB < hardMinDiskSizeGB {
+		return fmt.Errorf("disk size %d GB is below the %d GB minimum required by RHCOS", sizeGB, hardMinDiskSizeGB)
+	}
+	if platformMaxGB > 0 && sizeGB > platformMaxGB {
+		return fmt.Errorf("disk size %d GB exceeds the platform maximum of %d GB", sizeGB, platformMaxGB)
+	}
+	if sizeGB < recommendedMinDiskSizeGB {
+		logrus.Warnf("Disk size %d GB is below the recommended minimum of %d GB; the node may run out of space", sizeGB, recommendedMinDiskSizeGB)
+	}
+	return nil
+}
@@ -571,3 +571,29 @@ func TestURI(t *testing.T) {
 		})
 	}
 }
+
+func TestDiskSizeGB(t *testing.T) {
+	cases := []struct {
+		name     string
+		size     int64
+		max      int64
+		expected string
+	}{
+		{name: "valid", size: 120},
+		{name: "valid at maximum", size: 120, max: 120},
+		{name: "zero", size: 0, expected: "disk size must be a positive integer"},
+		{name: "negative", size: -10, expected: "disk size must be a positive integer"},
+		{name: "below minimum", size: 10, expected: "disk size 10 GB is below the 16 GB minimum required by RHCOS"},
+		{name: "above maximum", size: 200, max: 128, expected: "disk size 200 GB exceeds the platform maximum of 128 GB"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := DiskSizeGB(tc.size, tc.max)
+			if tc.expected == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expected)
+			}
+		})
+	}
+}